@@ -1,40 +1,268 @@
 package commands
 
 import (
+	"bufio"
 	"context"
-	"net"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
 	"time"
 
+	"github.com/ipfs/go-cid"
 	samqp "github.com/rabbitmq/amqp091-go"
 
+	"github.com/ipfs-search/ipfs-search/components/queue"
 	"github.com/ipfs-search/ipfs-search/components/queue/amqp"
+	"github.com/ipfs-search/ipfs-search/components/worker/pool"
 	"github.com/ipfs-search/ipfs-search/config"
 	"github.com/ipfs-search/ipfs-search/instr"
 	t "github.com/ipfs-search/ipfs-search/types"
-	"github.com/ipfs-search/ipfs-search/utils"
 )
 
-// AddHash queues a single IPFS hash for indexing
-func AddHash(ctx context.Context, cfg *config.Config, hash string) error {
-	instFlusher, err := instr.Install(cfg.InstrConfig(), "ipfs-crawler add")
+// maxAddLineSize is the maximum length of a line when adding from a reader.
+const maxAddLineSize = 1 << 20
+
+// AddOptions configure adding resources to the crawler queue.
+type AddOptions struct {
+	Priority         uint8         // Priority for queued resources, from 0 to 9.
+	Rate             float64       // Maximum number of resources queued per second, 0 for unlimited.
+	Progress         io.Writer     // Writer for progress reports and skipped items, nil to disable.
+	ProgressInterval time.Duration // Interval between progress reports.
+}
+
+// DefaultAddOptions returns the default options for adding resources; at highest priority, as manually added
+// resources are supposed to be available, without rate limiting nor progress reports.
+func DefaultAddOptions() *AddOptions {
+	return &AddOptions{
+		Priority:         9,
+		ProgressInterval: 10 * time.Second,
+	}
+}
+
+// AddSummary reports on the result of adding resources.
+type AddSummary struct {
+	Queued   int           // Number of resources queued.
+	Skipped  int           // Number of invalid items skipped.
+	Duration time.Duration // Time taken.
+}
+
+// String returns a human-readable summary.
+func (s *AddSummary) String() string {
+	return fmt.Sprintf("Queued %d resources, skipped %d invalid items in %s.",
+		s.Queued, s.Skipped, s.Duration.Round(time.Millisecond))
+}
+
+// addItem is an item to add, as given on a line of input.
+type addItem struct {
+	Hash   string `json:"hash"`   // IPFS hash, resource URI or path.
+	Name   string `json:"name"`   // Optional name for the resource, for reference.
+	Source string `json:"source"` // Optional source type, defaults to "manual".
+}
+
+// getResource returns the Resource for a URI (e.g. ipfs://<cid>), a path (e.g. /ipns/<name>) or,
+// for plain hashes, an IPFS Resource.
+func getResource(hash string) (*t.Resource, error) {
+	if strings.Contains(hash, "://") {
+		return t.ParseURI(hash)
+	}
+
+	if scheme, id, found := strings.Cut(strings.TrimPrefix(hash, "/"), "/"); found && strings.HasPrefix(hash, "/") {
+		return t.ParseURI(scheme + "://" + id)
+	}
+
+	return &t.Resource{
+		Protocol: t.IPFSProtocol,
+		ID:       hash,
+	}, nil
+}
+
+// getAnnotatedResource returns the AnnotatedResource to queue for an item.
+func (i *addItem) getAnnotatedResource() (*t.AnnotatedResource, error) {
+	resource, err := getResource(i.Hash)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer instFlusher(ctx)
 
-	i := instr.New()
+	if resource.Protocol == t.IPFSProtocol {
+		if _, err := cid.Decode(resource.ID); err != nil {
+			return nil, fmt.Errorf("invalid CID '%s': %w", resource.ID, err)
+		}
+	}
+
+	source := t.ManualSource
+	if i.Source != "" {
+		var ok bool
+		if source, ok = t.SourceTypeFromString(i.Source); !ok {
+			return nil, fmt.Errorf("unknown source '%s'", i.Source)
+		}
+	}
+
+	return &t.AnnotatedResource{
+		Resource:  resource,
+		Source:    source,
+		Reference: t.Reference{Name: i.Name},
+	}, nil
+}
+
+// parseAddLine parses a line with a hash, resource URI or path, or a JSON object with hash, name and source.
+func parseAddLine(line string) (*addItem, error) {
+	if strings.HasPrefix(line, "{") {
+		item := new(addItem)
+		if err := json.Unmarshal([]byte(line), item); err != nil {
+			return nil, err
+		}
+
+		if item.Hash == "" {
+			return nil, fmt.Errorf("missing hash")
+		}
+
+		return item, nil
+	}
+
+	return &addItem{Hash: line}, nil
+}
+
+// adder queues items over a single publisher, with rate limiting and progress reports.
+type adder struct {
+	publisher queue.Publisher
+	options   *AddOptions
+	summary   AddSummary
+	limiter   *time.Ticker
+	started   time.Time
+	reported  time.Time
+}
+
+func newAdder(p queue.Publisher, o *AddOptions) *adder {
+	a := &adder{
+		publisher: p,
+		options:   o,
+		started:   time.Now(),
+	}
 
-	dialer := &utils.RetryingDialer{
-		Dialer: net.Dialer{
-			Timeout:   30 * time.Second,
-			KeepAlive: 30 * time.Second,
-			DualStack: false,
-		},
-		Context: ctx,
+	a.reported = a.started
+
+	if o.Rate > 0 {
+		// Rates of over one per nanosecond are too high for a ticker, hence unlimited.
+		if interval := time.Duration(float64(time.Second) / o.Rate); interval > 0 {
+			a.limiter = time.NewTicker(interval)
+		}
 	}
 
+	return a
+}
+
+func (a *adder) stop() *AddSummary {
+	if a.limiter != nil {
+		a.limiter.Stop()
+	}
+
+	a.summary.Duration = time.Since(a.started)
+
+	return &a.summary
+}
+
+func (a *adder) printf(format string, v ...interface{}) {
+	if a.options.Progress != nil {
+		fmt.Fprintf(a.options.Progress, format, v...)
+	}
+}
+
+// skip reports and counts an invalid item.
+func (a *adder) skip(desc string, err error) {
+	a.summary.Skipped++
+	a.printf("Skipping %s: %s\n", desc, err)
+}
+
+func (a *adder) report() {
+	if now := time.Now(); now.Sub(a.reported) >= a.options.ProgressInterval {
+		a.reported = now
+
+		rate := float64(a.summary.Queued) / now.Sub(a.started).Seconds()
+		a.printf("Queued %d, skipped %d (%.0f/s)\n", a.summary.Queued, a.summary.Skipped, rate)
+	}
+}
+
+// add queues an item, skipping invalid items. Errors are only returned when publishing fails.
+func (a *adder) add(ctx context.Context, item *addItem, desc string) error {
+	r, err := item.getAnnotatedResource()
+	if err != nil {
+		a.skip(desc, err)
+		return nil
+	}
+
+	if a.limiter != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-a.limiter.C:
+		}
+	}
+
+	if err := a.publisher.Publish(ctx, r, a.options.Priority); err != nil {
+		return fmt.Errorf("queueing %s: %w", r, err)
+	}
+
+	a.summary.Queued++
+	a.report()
+
+	return nil
+}
+
+// addFromLines queues every non-empty line in r which is not a comment.
+func (a *adder) addFromLines(ctx context.Context, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxAddLineSize)
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		desc := fmt.Sprintf("line %d", lineNo)
+
+		item, err := parseAddLine(line)
+		if err != nil {
+			a.skip(desc, err)
+			continue
+		}
+
+		if err := a.add(ctx, item, desc); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// addFromCAR queues the roots and, unless rootsOnly, the blocks in a CAR archive.
+func (a *adder) addFromCAR(ctx context.Context, r io.Reader, rootsOnly bool) error {
+	return readCAR(r, rootsOnly, func(c cid.Cid, root bool) error {
+		return a.add(ctx, &addItem{Hash: c.String()}, c.String())
+	})
+}
+
+// addWith queues resources over a single connection through the given add function.
+func addWith(ctx context.Context, cfg *config.Config, o *AddOptions, add func(*adder) error) (*AddSummary, error) {
+	if err := requireSharedQueues(cfg); err != nil {
+		return nil, err
+	}
+
+	instFlusher, err := instr.Install(cfg.InstrConfig().WithoutMetricsServer(), "ipfs-crawler add")
+	if err != nil {
+		return nil, err
+	}
+	defer instFlusher(ctx)
+
+	i := instr.New()
+
 	amqpConfig := &samqp.Config{
-		Dial: dialer.Dial,
+		Dial: getDialer(ctx, i).Dial,
 	}
 
 	f := amqp.PublisherFactory{
@@ -44,23 +272,63 @@ func AddHash(ctx context.Context, cfg *config.Config, hash string) error {
 		Instrumentation: i,
 	}
 
-	queue, err := f.NewPublisher(ctx)
+	publisher, err := f.NewPublisher(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	resource := &t.Resource{
-		Protocol: t.IPFSProtocol,
-		ID:       hash,
+	a := newAdder(publisher, o)
+	err = add(a)
+
+	return a.stop(), err
+}
+
+// seed queues resources from r, as AddFromReader does, on the hashes queue of a started pool. As opposed to adding,
+// this supports in-process queues.
+func seed(ctx context.Context, p *pool.Pool, r io.Reader, o *AddOptions) (*AddSummary, error) {
+	queues, err := p.Queues(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	r := t.AnnotatedResource{
-		Resource: resource,
-		Source:   t.ManualSource,
+	a := newAdder(queues.Hashes, o)
+	err = a.addFromLines(ctx, r)
+
+	return a.stop(), err
+}
+
+// AddHash queues a single IPFS hash, resource URI or path for indexing
+func AddHash(ctx context.Context, cfg *config.Config, hash string) error {
+	resource, err := getResource(hash)
+	if err != nil {
+		return err
 	}
 
-	// TODO: Use provider here
+	_, err = addWith(ctx, cfg, DefaultAddOptions(), func(a *adder) error {
+		// Add with highest priority, as this is supposed to be available
+		r := t.AnnotatedResource{
+			Resource: resource,
+			Source:   t.ManualSource,
+		}
+
+		return a.publisher.Publish(ctx, &r, a.options.Priority)
+	})
+
+	return err
+}
+
+// AddFromReader queues resources from r, one per line, as a hash, resource URI or path or as a JSON object with
+// hash and optional name and source, e.g. {"hash": "Qm...", "name": "file.txt", "source": "user"}.
+// Empty lines and lines starting with '#' are ignored; invalid lines are skipped and reported.
+func AddFromReader(ctx context.Context, cfg *config.Config, r io.Reader, o *AddOptions) (*AddSummary, error) {
+	return addWith(ctx, cfg, o, func(a *adder) error {
+		return a.addFromLines(ctx, r)
+	})
+}
 
-	// Add with highest priority, as this is supposed to be available
-	return queue.Publish(ctx, &r, 9)
+// AddFromCAR queues the roots and, unless rootsOnly, the other blocks of a CARv1 or CARv2 archive read from r.
+func AddFromCAR(ctx context.Context, cfg *config.Config, r io.Reader, rootsOnly bool, o *AddOptions) (*AddSummary, error) {
+	return addWith(ctx, cfg, o, func(a *adder) error {
+		return a.addFromCAR(ctx, r, rootsOnly)
+	})
 }
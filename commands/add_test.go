@@ -0,0 +1,282 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+const (
+	testCID   = "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp"
+	testCIDv1 = "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"
+)
+
+type AddTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+	pub *queue.Mock
+	out *bytes.Buffer
+	a   *adder
+}
+
+func (s *AddTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.pub = &queue.Mock{}
+	s.out = &bytes.Buffer{}
+
+	o := DefaultAddOptions()
+	o.Progress = s.out
+
+	s.a = newAdder(s.pub, o)
+}
+
+func (s *AddTestSuite) expectPublish(r *t.AnnotatedResource) {
+	s.pub.On("Publish", mock.Anything, r, uint8(9)).Return(nil).Once()
+}
+
+func (s *AddTestSuite) TestAddFromLines() {
+	input := strings.Join([]string{
+		"# Comment",
+		testCID,
+		"",
+		"ipfs://" + testCIDv1,
+		`{"hash": "/ipns/ipfs-search.com", "name": "site", "source": "user"}`,
+		"invalid",
+		`{"hash": "` + testCID + `", "source": "bogus"}`,
+		`{"name": "nohash"}`,
+		`{"broken`,
+	}, "\n")
+
+	s.expectPublish(&t.AnnotatedResource{
+		Resource: &t.Resource{Protocol: t.IPFSProtocol, ID: testCID},
+		Source:   t.ManualSource,
+	})
+	s.expectPublish(&t.AnnotatedResource{
+		Resource: &t.Resource{Protocol: t.IPFSProtocol, ID: testCIDv1},
+		Source:   t.ManualSource,
+	})
+	s.expectPublish(&t.AnnotatedResource{
+		Resource:  &t.Resource{Protocol: t.IPNSProtocol, ID: "ipfs-search.com"},
+		Source:    t.UserSource,
+		Reference: t.Reference{Name: "site"},
+	})
+
+	err := s.a.addFromLines(s.ctx, strings.NewReader(input))
+	summary := s.a.stop()
+
+	s.NoError(err)
+	s.pub.AssertExpectations(s.T())
+	s.Equal(3, summary.Queued)
+	s.Equal(4, summary.Skipped)
+
+	s.Contains(s.out.String(), "Skipping line 6")
+	s.Contains(s.out.String(), "Skipping line 9")
+}
+
+func (s *AddTestSuite) TestAddPublishError() {
+	errPublish := errors.New("publish failed")
+	s.pub.On("Publish", mock.Anything, mock.Anything, uint8(9)).Return(errPublish).Once()
+
+	err := s.a.addFromLines(s.ctx, strings.NewReader(testCID+"\n"+testCIDv1))
+
+	s.ErrorIs(err, errPublish)
+	s.pub.AssertNumberOfCalls(s.T(), "Publish", 1)
+}
+
+func (s *AddTestSuite) TestAddRateLimited() {
+	o := DefaultAddOptions()
+	o.Rate = 20
+	s.a = newAdder(s.pub, o)
+
+	s.pub.On("Publish", mock.Anything, mock.Anything, uint8(9)).Return(nil)
+
+	err := s.a.addFromLines(s.ctx, strings.NewReader(testCID+"\n"+testCID+"\n"+testCID))
+	summary := s.a.stop()
+
+	s.NoError(err)
+	s.Equal(3, summary.Queued)
+	s.GreaterOrEqual(summary.Duration, 3*time.Second/20)
+}
+
+func (s *AddTestSuite) TestAddRateUnlimited() {
+	o := DefaultAddOptions()
+	o.Rate = 2e9
+	s.a = newAdder(s.pub, o)
+
+	s.pub.On("Publish", mock.Anything, mock.Anything, uint8(9)).Return(nil)
+
+	err := s.a.addFromLines(s.ctx, strings.NewReader(testCID))
+	summary := s.a.stop()
+
+	s.NoError(err)
+	s.Equal(1, summary.Queued)
+}
+
+func (s *AddTestSuite) TestAddRateLimitedCanceled() {
+	o := DefaultAddOptions()
+	o.Rate = 0.001
+	s.a = newAdder(s.pub, o)
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	cancel()
+
+	err := s.a.addFromLines(ctx, strings.NewReader(testCID))
+
+	s.ErrorIs(err, context.Canceled)
+	s.pub.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// carSection returns a length-prefixed CAR section.
+func carSection(data []byte) []byte {
+	return append(binary.AppendUvarint(nil, uint64(len(data))), data...)
+}
+
+// carV1 returns a CARv1 archive with the given roots and blocks with their CID as data.
+func (s *AddTestSuite) carV1(roots []cid.Cid, blocks []cid.Cid) []byte {
+	h := carHeader{Version: 1}
+	for _, r := range roots {
+		h.Roots = append(h.Roots, cbor.Tag{Number: cidTag, Content: append([]byte{0}, r.Bytes()...)})
+	}
+
+	header, err := cbor.Marshal(h)
+	s.Require().NoError(err)
+
+	car := carSection(header)
+	for _, b := range blocks {
+		car = append(car, carSection(append(b.Bytes(), b.String()...))...)
+	}
+
+	return car
+}
+
+// carV2 wraps a CARv1 archive in a CARv2 archive, with some padding and without index.
+func (s *AddTestSuite) carV2(inner []byte) []byte {
+	pragma, err := cbor.Marshal(carHeader{Version: 2})
+	s.Require().NoError(err)
+
+	car := carSection(pragma)
+	s.Require().Len(car, carV2PragmaSize)
+
+	const padding = 5
+
+	header := make([]byte, carV2HeaderSize)
+	binary.LittleEndian.PutUint64(header[16:], carV2PragmaSize+carV2HeaderSize+padding)
+	binary.LittleEndian.PutUint64(header[24:], uint64(len(inner)))
+
+	car = append(car, header...)
+	car = append(car, make([]byte, padding)...)
+
+	return append(car, inner...)
+}
+
+func (s *AddTestSuite) readCAR(car []byte, rootsOnly bool) ([]string, []string) {
+	var roots, blocks []string
+
+	err := readCAR(bytes.NewReader(car), rootsOnly, func(c cid.Cid, root bool) error {
+		if root {
+			roots = append(roots, c.String())
+		} else {
+			blocks = append(blocks, c.String())
+		}
+
+		return nil
+	})
+	s.Require().NoError(err)
+
+	return roots, blocks
+}
+
+func (s *AddTestSuite) testCIDs() (cid.Cid, cid.Cid) {
+	root, err := cid.Decode(testCID)
+	s.Require().NoError(err)
+
+	block, err := cid.Decode(testCIDv1)
+	s.Require().NoError(err)
+
+	return root, block
+}
+
+func (s *AddTestSuite) TestReadCARV1() {
+	root, block := s.testCIDs()
+	car := s.carV1([]cid.Cid{root}, []cid.Cid{root, block})
+
+	roots, blocks := s.readCAR(car, false)
+
+	s.Equal([]string{testCID}, roots)
+	s.Equal([]string{testCIDv1}, blocks)
+}
+
+func (s *AddTestSuite) TestReadCARRootsOnly() {
+	root, block := s.testCIDs()
+	car := s.carV1([]cid.Cid{root}, []cid.Cid{root, block})
+
+	roots, blocks := s.readCAR(car, true)
+
+	s.Equal([]string{testCID}, roots)
+	s.Empty(blocks)
+}
+
+func (s *AddTestSuite) TestReadCARV2() {
+	root, block := s.testCIDs()
+	car := s.carV2(s.carV1([]cid.Cid{root}, []cid.Cid{root, block}))
+
+	// Trailing data, e.g. an index, should be ignored.
+	car = append(car, 0xff, 0xff)
+
+	roots, blocks := s.readCAR(car, false)
+
+	s.Equal([]string{testCID}, roots)
+	s.Equal([]string{testCIDv1}, blocks)
+}
+
+func (s *AddTestSuite) TestReadCARTruncated() {
+	root, block := s.testCIDs()
+	car := s.carV1([]cid.Cid{root}, []cid.Cid{root, block})
+
+	err := readCAR(bytes.NewReader(car[:len(car)-3]), false, func(cid.Cid, bool) error { return nil })
+
+	s.ErrorIs(err, errInvalidCAR)
+}
+
+func (s *AddTestSuite) TestReadCARInvalid() {
+	err := readCAR(strings.NewReader(testCID), false, func(cid.Cid, bool) error { return nil })
+
+	s.ErrorIs(err, errInvalidCAR)
+}
+
+func (s *AddTestSuite) TestAddFromCAR() {
+	root, block := s.testCIDs()
+	car := s.carV1([]cid.Cid{root}, []cid.Cid{root, block})
+
+	s.expectPublish(&t.AnnotatedResource{
+		Resource: &t.Resource{Protocol: t.IPFSProtocol, ID: testCID},
+		Source:   t.ManualSource,
+	})
+	s.expectPublish(&t.AnnotatedResource{
+		Resource: &t.Resource{Protocol: t.IPFSProtocol, ID: testCIDv1},
+		Source:   t.ManualSource,
+	})
+
+	err := s.a.addFromCAR(s.ctx, bytes.NewReader(car), false)
+
+	s.NoError(err)
+	s.pub.AssertExpectations(s.T())
+	s.Equal(2, s.a.stop().Queued)
+}
+
+func TestAddTestSuite(t *testing.T) {
+	suite.Run(t, new(AddTestSuite))
+}
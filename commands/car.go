@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/ipfs/go-cid"
+)
+
+const (
+	// maxCARHeaderSize limits the size of the header to read, guarding against corrupt archives.
+	maxCARHeaderSize = 32 << 20
+
+	// carV2PragmaSize is the size of the length-prefixed CARv1 header identifying a CARv2 archive.
+	carV2PragmaSize = 11
+
+	// carV2HeaderSize is the size of the fixed CARv2 header following the pragma.
+	carV2HeaderSize = 40
+
+	// maxCIDSize is the maximum number of bytes read to parse the CID at the start of a block section.
+	maxCIDSize = 128
+
+	// cidTag is the CBOR tag for CIDs in DAG-CBOR.
+	cidTag = 42
+)
+
+// errInvalidCAR is returned for unreadable CAR archives.
+var errInvalidCAR = errors.New("invalid CAR archive")
+
+// carHeader is the DAG-CBOR encoded header of a CARv1 archive, or the pragma of a CARv2 archive.
+type carHeader struct {
+	Roots   []cbor.Tag `cbor:"roots,omitempty"`
+	Version uint64     `cbor:"version"`
+}
+
+// carVisitor is called for the roots and blocks in a CAR archive.
+type carVisitor func(c cid.Cid, root bool) error
+
+func readCARHeader(r *bufio.Reader) (*carHeader, error) {
+	l, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading header length: %s", errInvalidCAR, err)
+	}
+
+	if l == 0 || l > maxCARHeaderSize {
+		return nil, fmt.Errorf("%w: invalid header length %d", errInvalidCAR, l)
+	}
+
+	buf := make([]byte, l)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("%w: reading header: %s", errInvalidCAR, err)
+	}
+
+	h := new(carHeader)
+	if err := cbor.Unmarshal(buf, h); err != nil {
+		return nil, fmt.Errorf("%w: decoding header: %s", errInvalidCAR, err)
+	}
+
+	return h, nil
+}
+
+// rootCID decodes a DAG-CBOR CID link, which is prefixed by a multibase identity byte.
+func rootCID(t cbor.Tag) (cid.Cid, error) {
+	b, ok := t.Content.([]byte)
+	if t.Number != cidTag || !ok || len(b) < 1 || b[0] != 0 {
+		return cid.Undef, fmt.Errorf("%w: invalid root", errInvalidCAR)
+	}
+
+	return cid.Cast(b[1:])
+}
+
+// skipCARV2Header skips the CARv2 header and returns a reader for the inner CARv1 archive.
+func skipCARV2Header(r *bufio.Reader) (*bufio.Reader, error) {
+	buf := make([]byte, carV2HeaderSize)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("%w: reading CARv2 header: %s", errInvalidCAR, err)
+	}
+
+	// The header starts with 16 bytes of characteristics, followed by the data offset and size.
+	offset := binary.LittleEndian.Uint64(buf[16:24])
+	size := binary.LittleEndian.Uint64(buf[24:32])
+
+	if offset < carV2PragmaSize+carV2HeaderSize {
+		return nil, fmt.Errorf("%w: invalid CARv2 data offset %d", errInvalidCAR, offset)
+	}
+
+	if _, err := r.Discard(int(offset - carV2PragmaSize - carV2HeaderSize)); err != nil {
+		return nil, fmt.Errorf("%w: seeking CARv2 data: %s", errInvalidCAR, err)
+	}
+
+	return bufio.NewReader(io.LimitReader(r, int64(size))), nil
+}
+
+// readCARBlock reads the next block section, returning the CID of the block and discarding its data.
+// Returns io.EOF when no more blocks are available.
+func readCARBlock(r *bufio.Reader) (cid.Cid, error) {
+	var l uint64
+
+	// Zero length sections are used as padding.
+	for l == 0 {
+		var err error
+
+		if l, err = binary.ReadUvarint(r); err != nil {
+			if errors.Is(err, io.EOF) {
+				return cid.Undef, io.EOF
+			}
+
+			return cid.Undef, fmt.Errorf("%w: reading section length: %s", errInvalidCAR, err)
+		}
+	}
+
+	buf := make([]byte, maxCIDSize)
+	if l < maxCIDSize {
+		buf = buf[:l]
+	}
+
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return cid.Undef, fmt.Errorf("%w: reading section: %s", errInvalidCAR, err)
+	}
+
+	n, c, err := cid.CidFromBytes(buf)
+	if err != nil {
+		return cid.Undef, fmt.Errorf("%w: reading block CID: %s", errInvalidCAR, err)
+	}
+
+	if uint64(n) > l {
+		return cid.Undef, fmt.Errorf("%w: section shorter than CID", errInvalidCAR)
+	}
+
+	// Skip block data.
+	if _, err := io.CopyN(io.Discard, r, int64(l)-int64(len(buf))); err != nil {
+		return cid.Undef, fmt.Errorf("%w: reading block data: %s", errInvalidCAR, err)
+	}
+
+	return c, nil
+}
+
+// readCAR calls visit for every root in a CARv1 or CARv2 archive and, unless rootsOnly, for every block
+// which is not a root.
+func readCAR(r io.Reader, rootsOnly bool, visit carVisitor) error {
+	br := bufio.NewReader(r)
+
+	h, err := readCARHeader(br)
+	if err != nil {
+		return err
+	}
+
+	switch h.Version {
+	case 1:
+	case 2:
+		if br, err = skipCARV2Header(br); err != nil {
+			return err
+		}
+
+		if h, err = readCARHeader(br); err != nil {
+			return err
+		}
+
+		if h.Version != 1 {
+			return fmt.Errorf("%w: unexpected inner version %d", errInvalidCAR, h.Version)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported version %d", errInvalidCAR, h.Version)
+	}
+
+	roots := make(map[cid.Cid]struct{}, len(h.Roots))
+
+	for _, t := range h.Roots {
+		c, err := rootCID(t)
+		if err != nil {
+			return err
+		}
+
+		roots[c] = struct{}{}
+
+		if err := visit(c, true); err != nil {
+			return err
+		}
+	}
+
+	if rootsOnly {
+		return nil
+	}
+
+	for {
+		c, err := readCARBlock(br)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if _, isRoot := roots[c]; isRoot {
+			continue
+		}
+
+		if err := visit(c, false); err != nil {
+			return err
+		}
+	}
+}
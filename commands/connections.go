@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	samqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/ipfs-search/ipfs-search/components/index/opensearch"
+	"github.com/ipfs-search/ipfs-search/components/queue/amqp"
+	"github.com/ipfs-search/ipfs-search/config"
+	"github.com/ipfs-search/ipfs-search/instr"
+	"github.com/ipfs-search/ipfs-search/utils"
+)
+
+func getDialer(ctx context.Context, i *instr.Instrumentation) *utils.RetryingDialer {
+	return &utils.RetryingDialer{
+		Dialer: net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			DualStack: false,
+		},
+		Context: ctx,
+		Logger:  i.Component("dialer"),
+	}
+}
+
+// errNoSharedQueues is returned by commands publishing to or consuming from the crawler's queues, when these are
+// in-process queues, which are only available from within the crawler.
+var errNoSharedQueues = errors.New("command requires the amqp queue backend; seed in-process queues with crawl --seed")
+
+// requireSharedQueues returns errNoSharedQueues when the queues are not shared through AMQP.
+func requireSharedQueues(cfg *config.Config) error {
+	if cfg.Queues.Backend != config.AMQPBackend {
+		return errNoSharedQueues
+	}
+
+	return nil
+}
+
+// errNoSearchIndexes is returned by commands searching the indexes, when these are not stored in OpenSearch. Other
+// backends only support retrieval by hash.
+var errNoSearchIndexes = errors.New("command requires the opensearch index backend, as other backends can't be searched")
+
+// requireSearchIndexes returns errNoSearchIndexes when any of indexes is not stored in OpenSearch.
+func requireSearchIndexes(indexes ...config.Index) error {
+	for _, i := range indexes {
+		if i.Backend != config.OpenSearchBackend {
+			return fmt.Errorf("%w: %s uses %s", errNoSearchIndexes, i.Name, i.Backend)
+		}
+	}
+
+	return nil
+}
+
+func getAMQPConnection(ctx context.Context, cfg *config.Config, i *instr.Instrumentation) (*amqp.Connection, error) {
+	if err := requireSharedQueues(cfg); err != nil {
+		return nil, err
+	}
+
+	amqpConfig := &samqp.Config{
+		Dial: getDialer(ctx, i).Dial,
+	}
+
+	return amqp.NewConnection(ctx, cfg.AMQPConfig(), amqpConfig, i)
+}
+
+func getOpenSearchClient(ctx context.Context, cfg *config.Config, i *instr.Instrumentation) (*opensearch.Client, error) {
+	return opensearch.NewClient(&opensearch.ClientConfig{
+		URL:       cfg.OpenSearch.URL,
+		Transport: utils.GetHTTPTransport(getDialer(ctx, i).DialContext, 100),
+
+		BulkIndexerWorkers:      cfg.OpenSearch.BulkIndexerWorkers,
+		BulkIndexerFlushBytes:   int(cfg.OpenSearch.BulkIndexerFlushBytes),
+		BulkIndexerFlushTimeout: cfg.OpenSearch.BulkIndexerFlushTimeout,
+		BulkGetterBatchSize:     cfg.OpenSearch.BulkGetterBatchSize,
+		BulkGetterBatchTimeout:  cfg.OpenSearch.BulkGetterBatchTimeout,
+	}, i)
+}
@@ -2,7 +2,11 @@ package commands
 
 import (
 	"context"
+	"io"
 
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ipfs-search/ipfs-search/components/admin"
 	"github.com/ipfs-search/ipfs-search/components/worker/pool"
 	"github.com/ipfs-search/ipfs-search/config"
 	"github.com/ipfs-search/ipfs-search/instr"
@@ -10,8 +14,12 @@ import (
 	"log"
 )
 
-// Crawl configures and initializes crawling
-func Crawl(ctx context.Context, cfg *config.Config) error {
+// Crawl configures and initializes crawling. When ctx is closed, consuming stops and in-flight crawls are given the
+// configured grace period to finish before their deliveries are requeued; then the indexes are flushed.
+// Configuration is reloaded from configFile, if any, and the environment on SIGHUP or when the file changes.
+// Resources read from seed, if not nil, are queued in the background once crawling has started, as with
+// AddFromReader; this allows seeding in-process queues.
+func Crawl(ctx context.Context, cfg *config.Config, configFile string, seed io.Reader) error {
 	instFlusher, err := instr.Install(cfg.InstrConfig(), "ipfs-crawler")
 	if err != nil {
 		log.Fatal(err)
@@ -23,15 +31,91 @@ func Crawl(ctx context.Context, cfg *config.Config) error {
 	ctx, span := i.Tracer.Start(ctx, "commands.Crawl")
 	defer span.End()
 
-	pool, err := pool.New(ctx, cfg, i)
+	// Clients and connections outlive ctx, so that in-flight crawls can be finished and their results flushed.
+	runCtx, stop := context.WithCancel(trace.ContextWithSpan(context.Background(), span))
+	defer stop()
+
+	// Serve liveness during initialization and shutdown, readiness once the pool has started.
+	var adminServer *admin.Server
+	if cfg.Admin.Listen != "" {
+		adminServer = admin.New(cfg.AdminConfig(), i)
+
+		go func() {
+			if err := adminServer.Start(runCtx); err != nil && runCtx.Err() == nil {
+				i.Logger.ErrorCtx(runCtx, "Error serving admin endpoints", "err", err)
+			}
+		}()
+	}
+
+	pool, err := pool.New(runCtx, cfg, i)
 	if err != nil {
 		return err
 	}
 
-	pool.Start(ctx)
+	if err := pool.Start(ctx); err != nil {
+		return err
+	}
+
+	if adminServer != nil {
+		for name, check := range pool.Checks() {
+			adminServer.AddCheck(name, check)
+		}
+
+		adminServer.SetStatus(func() interface{} { return pool.Stats() })
+		adminServer.SetScaler(pool)
+		adminServer.SetReady(true)
+	}
+
+	watcher := config.NewWatcher(configFile, cfg, i)
+	watcher.Add(config.ReloaderFunc(func(c *config.Config) error {
+		return instr.SetLogLevels(c.InstrConfig())
+	}))
+	watcher.Add(pool)
+
+	go watcher.Watch(ctx)
+
+	// Seed in the background, so that large or open-ended input does not hold up readiness. Seeding stops
+	// queueing when ctx is closed, but is not waited for, as reading input may block.
+	if seed != nil {
+		go seedQueues(ctx, pool, seed, i)
+	}
 
 	// Context closure or panic is the only way to stop crawling
 	<-ctx.Done()
 
+	if adminServer != nil {
+		adminServer.SetReady(false)
+	}
+
+	summary := pool.Drain(cfg.Workers.GracePeriod)
+
+	// Close clients, flushing the indexes.
+	stop()
+
+	if err := pool.Close(context.Background()); err != nil {
+		i.Logger.Error("Error closing pool", "err", err)
+	}
+
+	i.Logger.Info("Crawler stopped",
+		"in_flight", summary.InFlight,
+		"finished", summary.Finished,
+		"requeued", summary.Requeued,
+		"duration", summary.Duration,
+	)
+
 	return ctx.Err()
 }
+
+// seedQueues queues resources read from r on the pool's queues, logging the result. Invalid lines are skipped.
+func seedQueues(ctx context.Context, p *pool.Pool, r io.Reader, i *instr.Instrumentation) {
+	log := i.Component("seed")
+
+	summary, err := seed(ctx, p, r, DefaultAddOptions())
+	if err != nil {
+		log.ErrorCtx(ctx, "Error seeding queues", "err", err)
+	}
+
+	if summary != nil {
+		log.InfoCtx(ctx, "Seeded queues", "queued", summary.Queued, "skipped", summary.Skipped, "duration", summary.Duration)
+	}
+}
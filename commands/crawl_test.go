@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ipfs-search/ipfs-search/config"
+)
+
+// singleNodeConfig returns configuration for crawling without external services, besides the IPFS API at apiURL.
+func singleNodeConfig(dir, apiURL string) *config.Config {
+	cfg := config.Default()
+
+	for _, i := range []*config.Index{
+		&cfg.Indexes.Files, &cfg.Indexes.Directories, &cfg.Indexes.Invalids, &cfg.Indexes.Partials,
+		&cfg.Indexes.Names, &cfg.Indexes.Dags, &cfg.Indexes.Checkpoints, &cfg.Indexes.Extractions,
+	} {
+		i.Backend = config.BoltBackend
+	}
+
+	cfg.Bolt.Path = filepath.Join(dir, "ipfs-search.db")
+	cfg.Queues.Backend = config.MemoryBackend
+	cfg.MemoryQueue.Dir = filepath.Join(dir, "queues")
+	cfg.IPFS.APIURL = apiURL
+	cfg.Instr.Exporter = "none"
+	cfg.Instr.MetricsAddress = ""
+	cfg.Admin.Listen = ""
+	cfg.Workers.AdaptInterval = 0
+
+	return cfg
+}
+
+func TestCrawlSeed(t *testing.T) {
+	listed := make(chan struct{})
+
+	// IPFS API serving an empty directory.
+	ipfs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v0/files/stat":
+			w.Write([]byte(`{"Type":"directory","CumulativeSize":4}`))
+		case "/api/v0/ls":
+			close(listed)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ipfs.Close()
+
+	cfg := singleNodeConfig(t.TempDir(), ipfs.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	crawled := make(chan error)
+	go func() {
+		crawled <- Crawl(ctx, cfg, "", strings.NewReader("# Seed\n"+testCID+"\ninvalid\n"))
+	}()
+
+	select {
+	case <-listed:
+	case err := <-crawled:
+		require.FailNow(t, "crawler stopped", err)
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "seeded resource not crawled")
+	}
+
+	// Stopping finishes the crawl and flushes the indexes.
+	cancel()
+	assert.ErrorIs(t, <-crawled, context.Canceled)
+
+	err := withIndexes(context.Background(), cfg, func(ctx context.Context, indexes []namedIndex) error {
+		directories, err := findIndex(indexes, "directories")
+		require.NoError(t, err)
+
+		var dir map[string]interface{}
+		found, err := directories.Get(ctx, testCID, &dir)
+		require.NoError(t, err)
+		assert.True(t, found)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestCrawlSeedOpenInput(t *testing.T) {
+	listed := make(chan struct{})
+
+	ipfs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/v0/files/stat":
+			w.Write([]byte(`{"Type":"directory","CumulativeSize":4}`))
+		case "/api/v0/ls":
+			close(listed)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ipfs.Close()
+
+	cfg := singleNodeConfig(t.TempDir(), ipfs.URL)
+
+	// Input which stays open, as stdin from a pipe.
+	seed, w := io.Pipe()
+	defer w.Close()
+
+	go w.Write([]byte(testCID + "\n"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	crawled := make(chan error)
+	go func() {
+		crawled <- Crawl(ctx, cfg, "", seed)
+	}()
+
+	select {
+	case <-listed:
+	case err := <-crawled:
+		require.FailNow(t, "crawler stopped", err)
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "seeded resource not crawled")
+	}
+
+	// Stopping does not wait for the input to close.
+	cancel()
+
+	select {
+	case err := <-crawled:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "crawler did not stop")
+	}
+}
+
+func TestSearchCommandsRequireOpenSearch(t *testing.T) {
+	cfg := singleNodeConfig(t.TempDir(), "http://localhost:5001")
+
+	assert.ErrorIs(t, Serve(context.Background(), cfg), errNoSearchIndexes)
+	assert.ErrorIs(t, Recrawl(context.Background(), cfg, true), errNoSearchIndexes)
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ipfs-search/ipfs-search/components/crawler"
+	"github.com/ipfs-search/ipfs-search/components/crawler/explain"
+	"github.com/ipfs-search/ipfs-search/components/worker/pool"
+	"github.com/ipfs-search/ipfs-search/config"
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+// CrawlOne crawls a single hash, resource URI or path, writing a trace of the crawler's decisions to w.
+// When dryRun is true, nothing is written to indexes or published to queues.
+func CrawlOne(ctx context.Context, cfg *config.Config, hash string, dryRun bool, w io.Writer) error {
+	r, err := (&addItem{Hash: hash}).getAnnotatedResource()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	i := instr.New()
+
+	ix, wait, err := pool.GetIndexes(ctx, cfg, i)
+
+	// Close index clients, flushing pending writes, when done.
+	defer wait()
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	var qs *crawler.Queues
+
+	if !dryRun {
+		// Published resources should be crawled by a running crawler, rather than lost with in-process queues.
+		if err := requireSharedQueues(cfg); err != nil {
+			return err
+		}
+
+		if qs, err = pool.GetQueues(ctx, cfg, i); err != nil {
+			return err
+		}
+	}
+
+	e := explain.New(w, dryRun, uint64(cfg.IPFS.PartialSize))
+
+	p := pool.GetProtocol(ctx, cfg, i)
+	c := crawler.New(
+		cfg.CrawlerConfig(),
+		e.Indexes(ix),
+		e.Queues(qs),
+		e.Protocol(p),
+		e.Extractors(pool.GetExtractors(ctx, cfg, i, p)),
+		i,
+	)
+
+	fmt.Fprintf(w, "Crawling %s\n", r)
+
+	err = c.Crawl(ctx, r)
+
+	// Summarize regardless of errors; the trace shows where crawling failed.
+	fmt.Fprintln(w, e.Summary())
+
+	return err
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	"github.com/ipfs-search/ipfs-search/components/queue/amqp"
+	"github.com/ipfs-search/ipfs-search/components/worker"
+	"github.com/ipfs-search/ipfs-search/config"
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+// getQueueConfig returns the configuration for a crawler queue by its name.
+func getQueueConfig(cfg *config.Config, name string) (config.Queue, error) {
+	for _, q := range []config.Queue{cfg.Queues.Files, cfg.Queues.Directories, cfg.Queues.Hashes} {
+		if q.Name == name {
+			return q, nil
+		}
+	}
+
+	return config.Queue{}, fmt.Errorf("unknown queue '%s'", name)
+}
+
+// getDeadLetterQueues returns the dead-letter queue for a crawler queue as well as the queue itself.
+func getDeadLetterQueues(ctx context.Context, cfg *config.Config, name string) (*amqp.Queue, *amqp.Queue, error) {
+	q, err := getQueueConfig(cfg, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := getAMQPConnection(ctx, cfg, instr.New())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ch, err := conn.NewChannel(ctx, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deadLetter, err := ch.DeadLetterQueue(ctx, q.DeadLetterName())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target, err := ch.Queue(ctx, q.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return deadLetter, target, nil
+}
+
+// InspectDeadLetters writes the deliveries in the dead-letter queue for a crawler queue to w, leaving them in the queue.
+func InspectDeadLetters(ctx context.Context, cfg *config.Config, name string, w io.Writer) error {
+	deadLetter, _, err := getDeadLetterQueues(ctx, cfg, name)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []queue.Delivery
+
+	// Return all deliveries to the queue after listing them.
+	defer func() {
+		for _, d := range deliveries {
+			d.Nack(true)
+		}
+	}()
+
+	for {
+		d, ok, err := deadLetter.Get(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			break
+		}
+
+		deliveries = append(deliveries, d)
+
+		headers := d.Headers()
+		fmt.Fprintf(w, "%s\tattempts=%v\tclass=%v\terror=%v\n", d.Body(),
+			headers[worker.AttemptHeader], headers[worker.ErrorClassHeader], headers[worker.ErrorHeader])
+	}
+
+	fmt.Fprintf(w, "%d dead-lettered deliveries in %s\n", len(deliveries), deadLetter)
+
+	return nil
+}
+
+// ReplayDeadLetters moves the deliveries in the dead-letter queue for a crawler queue back to the queue,
+// resetting their attempts. It returns the amount of replayed deliveries.
+func ReplayDeadLetters(ctx context.Context, cfg *config.Config, name string) (int, error) {
+	deadLetter, target, err := getDeadLetterQueues(ctx, cfg, name)
+	if err != nil {
+		return 0, err
+	}
+
+	cnt := 0
+
+	for {
+		d, ok, err := deadLetter.Get(ctx)
+		if err != nil {
+			return cnt, err
+		}
+
+		if !ok {
+			return cnt, nil
+		}
+
+		if err := target.PublishDelivery(ctx, d, nil, 0); err != nil {
+			d.Nack(true)
+			return cnt, err
+		}
+
+		if err := d.Ack(); err != nil {
+			return cnt, err
+		}
+
+		cnt++
+	}
+}
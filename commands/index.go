@@ -0,0 +1,376 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ipfs-search/ipfs-search/components/index"
+	"github.com/ipfs-search/ipfs-search/components/index/cache"
+	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+	"github.com/ipfs-search/ipfs-search/components/worker/pool"
+	"github.com/ipfs-search/ipfs-search/config"
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+var (
+	errUnknownIndex     = errors.New("unknown index")
+	errDocumentNotFound = errors.New("document not found")
+	errNotCached        = errors.New("index is not cached")
+	errNoSampler        = errors.New("index does not support sampling, specify ID's to verify")
+)
+
+// namedIndex is a crawler index, along with the name used to refer to it.
+type namedIndex struct {
+	Name string
+	index.Index
+}
+
+// withIndexes calls f with the crawler's indexes as configured, in a fixed order, closing them afterwards.
+func withIndexes(ctx context.Context, cfg *config.Config, f func(context.Context, []namedIndex) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+
+	ix, wait, err := pool.GetIndexes(ctx, cfg, instr.New())
+
+	// Close index clients, flushing pending writes, when done.
+	defer wait()
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	indexes := []namedIndex{
+		{"files", ix.Files},
+		{"directories", ix.Directories},
+		{"invalids", ix.Invalids},
+		{"partials", ix.Partials},
+		{"dags", ix.Dags},
+		{"names", ix.Names},
+		{"checkpoints", ix.Checkpoints},
+		{"extractions", ix.Extractions},
+	}
+
+	return f(ctx, indexes)
+}
+
+// indexNames returns the names of all indexes, for usage and error messages.
+func indexNames(indexes []namedIndex) string {
+	names := make([]string, len(indexes))
+	for i, idx := range indexes {
+		names[i] = idx.Name
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// findIndex returns the index called name.
+func findIndex(indexes []namedIndex, name string) (index.Index, error) {
+	for _, idx := range indexes {
+		if idx.Name == name {
+			return idx.Index, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w '%s', should be one of: %s", errUnknownIndex, name, indexNames(indexes))
+}
+
+// sourceIndex returns the index which is the source of truth; for cached indexes the backing index.
+func sourceIndex(i index.Index) index.Index {
+	if c, ok := i.(*cache.Index); ok {
+		return c.Backing()
+	}
+
+	return i
+}
+
+// document holds a complete document of any type, exposing the cached Update fields.
+type document struct {
+	indexTypes.Update
+	fields map[string]json.RawMessage
+}
+
+// UnmarshalJSON retains all fields, decoding the cached ones into Update.
+func (d *document) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &d.fields); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, &d.Update)
+}
+
+// MarshalJSON returns all fields, as read.
+func (d *document) MarshalJSON() ([]byte, error) {
+	if d.fields == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(d.fields)
+}
+
+// documentIndexes are the names of indexes holding documents by their CID.
+var documentIndexes = []string{"files", "directories", "invalids", "partials", "dags", "names"}
+
+// GetDocument writes the first document with id found in any of the crawler's document indexes to w.
+func GetDocument(ctx context.Context, cfg *config.Config, id string, w io.Writer) error {
+	return withIndexes(ctx, cfg, func(ctx context.Context, indexes []namedIndex) error {
+		var docIndexes []namedIndex
+
+		for _, name := range documentIndexes {
+			idx, err := findIndex(indexes, name)
+			if err != nil {
+				return err
+			}
+
+			docIndexes = append(docIndexes, namedIndex{name, idx})
+		}
+
+		return getDocument(ctx, docIndexes, id, w)
+	})
+}
+
+func getDocument(ctx context.Context, indexes []namedIndex, id string, w io.Writer) error {
+	// Get from source indexes, as caches only hold part of documents.
+	sources := make([]index.Index, len(indexes))
+	for i, idx := range indexes {
+		sources[i] = sourceIndex(idx.Index)
+	}
+
+	doc := new(document)
+
+	found, err := index.MultiGet(ctx, sources, id, doc)
+	if err != nil {
+		return err
+	}
+
+	if found == nil {
+		return fmt.Errorf("%w: %s", errDocumentNotFound, id)
+	}
+
+	for i, s := range sources {
+		if s == found {
+			fmt.Fprintf(w, "Found %s in %s (%s):\n", id, indexes[i].Name, found)
+		}
+	}
+
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+
+	return e.Encode(doc)
+}
+
+// DeleteDocuments deletes documents by their id from the named index, including its cache.
+func DeleteDocuments(ctx context.Context, cfg *config.Config, name string, ids []string, w io.Writer) error {
+	return withIndexes(ctx, cfg, func(ctx context.Context, indexes []namedIndex) error {
+		return deleteDocuments(ctx, indexes, name, ids, w)
+	})
+}
+
+func deleteDocuments(ctx context.Context, indexes []namedIndex, name string, ids []string, w io.Writer) error {
+	idx, err := findIndex(indexes, name)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := idx.Delete(ctx, id); err != nil {
+			return fmt.Errorf("deleting %s from %s: %w", id, name, err)
+		}
+
+		fmt.Fprintf(w, "Deleted %s from %s.\n", id, name)
+	}
+
+	return nil
+}
+
+// MoveDocuments moves documents by their id between named indexes, including their caches.
+func MoveDocuments(ctx context.Context, cfg *config.Config, from, to string, ids []string, w io.Writer) error {
+	return withIndexes(ctx, cfg, func(ctx context.Context, indexes []namedIndex) error {
+		return moveDocuments(ctx, indexes, from, to, ids, w)
+	})
+}
+
+func moveDocuments(ctx context.Context, indexes []namedIndex, from, to string, ids []string, w io.Writer) error {
+	src, err := findIndex(indexes, from)
+	if err != nil {
+		return err
+	}
+
+	dst, err := findIndex(indexes, to)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		doc := new(document)
+
+		found, err := sourceIndex(src).Get(ctx, id, doc)
+		if err != nil {
+			return fmt.Errorf("getting %s from %s: %w", id, from, err)
+		}
+
+		if !found {
+			return fmt.Errorf("%w: %s in %s", errDocumentNotFound, id, from)
+		}
+
+		// Write first; when deleting fails, the document is in both indexes rather than in neither.
+		if err := dst.Index(ctx, id, doc); err != nil {
+			return fmt.Errorf("indexing %s in %s: %w", id, to, err)
+		}
+
+		if err := src.Delete(ctx, id); err != nil {
+			return fmt.Errorf("deleting %s from %s: %w", id, from, err)
+		}
+
+		fmt.Fprintf(w, "Moved %s from %s to %s.\n", id, from, to)
+	}
+
+	return nil
+}
+
+// CountDocuments writes the number of documents in each of the crawler's indexes to w.
+func CountDocuments(ctx context.Context, cfg *config.Config, w io.Writer) error {
+	return withIndexes(ctx, cfg, func(ctx context.Context, indexes []namedIndex) error {
+		return countDocuments(ctx, indexes, w)
+	})
+}
+
+func countDocuments(ctx context.Context, indexes []namedIndex, w io.Writer) error {
+	for _, idx := range indexes {
+		var (
+			n   int64
+			err = index.ErrNotCounter
+		)
+
+		if c, ok := idx.Index.(index.Counter); ok {
+			n, err = c.Count(ctx)
+		}
+
+		switch {
+		case errors.Is(err, index.ErrNotCounter):
+			fmt.Fprintf(w, "%-12s %s: %s\n", idx.Name, idx.Index, index.ErrNotCounter)
+		case err != nil:
+			return fmt.Errorf("counting %s: %w", idx.Name, err)
+		default:
+			fmt.Fprintf(w, "%-12s %s: %d\n", idx.Name, idx.Index, n)
+		}
+	}
+
+	return nil
+}
+
+// VerifySummary reports on the agreement between a cache and its backing index.
+type VerifySummary struct {
+	Checked   int // Number of documents checked.
+	Agreeing  int // Documents which are identical in the cache and the backing index.
+	NotCached int // Documents in the backing index which are not cached.
+	Stale     int // Documents in the cache which are not in the backing index.
+	Differing int // Documents which are cached with different values.
+}
+
+// String returns a human-readable summary.
+func (s *VerifySummary) String() string {
+	return fmt.Sprintf("Checked %d documents: %d agreeing, %d not cached, %d stale, %d differing.",
+		s.Checked, s.Agreeing, s.NotCached, s.Stale, s.Differing)
+}
+
+// Consistent returns true when no stale or differing documents were found; documents may legitimately
+// be missing from a cache.
+func (s *VerifySummary) Consistent() bool {
+	return s.Stale == 0 && s.Differing == 0
+}
+
+// sampler is implemented by indexes which can return a random sample of their document ID's.
+type sampler interface {
+	Sample(ctx context.Context, n int) ([]string, error)
+}
+
+// cachedFields are the names of the fields of indexTypes.Update in a backing index.
+var cachedFields = []string{"last-seen", "references", "providers"}
+
+// verifyDocument compares the cached and the backing version of a document.
+func verifyDocument(ctx context.Context, c *cache.Index, id string, s *VerifySummary, w io.Writer) error {
+	cached, backing := new(indexTypes.Update), new(indexTypes.Update)
+
+	inCache, err := c.Caching().Get(ctx, id, cached)
+	if err != nil {
+		return fmt.Errorf("getting %s from cache %s: %w", id, c.Caching(), err)
+	}
+
+	inBacking, err := c.Backing().Get(ctx, id, backing, cachedFields...)
+	if err != nil {
+		return fmt.Errorf("getting %s from %s: %w", id, c.Backing(), err)
+	}
+
+	s.Checked++
+
+	switch {
+	case !inCache && !inBacking:
+		fmt.Fprintf(w, "%s: not found\n", id)
+	case !inCache:
+		s.NotCached++
+		fmt.Fprintf(w, "%s: not cached\n", id)
+	case !inBacking:
+		s.Stale++
+		fmt.Fprintf(w, "%s: stale, cached but not in %s\n", id, c.Backing())
+	case !cached.Equal(backing):
+		s.Differing++
+		fmt.Fprintf(w, "%s: differing, cached %+v, indexed %+v\n", id, *cached, *backing)
+	default:
+		s.Agreeing++
+	}
+
+	return nil
+}
+
+// VerifyCache compares documents in the cache of the named index with those in its backing index, reporting
+// disagreements to w. Documents are specified by their ids or, when none are given, randomly sampled.
+func VerifyCache(ctx context.Context, cfg *config.Config, name string, ids []string, sample int, w io.Writer) (*VerifySummary, error) {
+	var summary *VerifySummary
+
+	err := withIndexes(ctx, cfg, func(ctx context.Context, indexes []namedIndex) error {
+		idx, err := findIndex(indexes, name)
+		if err != nil {
+			return err
+		}
+
+		summary, err = verifyCache(ctx, name, idx, ids, sample, w)
+
+		return err
+	})
+
+	return summary, err
+}
+
+func verifyCache(ctx context.Context, name string, idx index.Index, ids []string, sample int, w io.Writer) (*VerifySummary, error) {
+	var err error
+
+	c, ok := idx.(*cache.Index)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNotCached, name)
+	}
+
+	if len(ids) == 0 {
+		s, ok := c.Backing().(sampler)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errNoSampler, name)
+		}
+
+		if ids, err = s.Sample(ctx, sample); err != nil {
+			return nil, err
+		}
+	}
+
+	summary := new(VerifySummary)
+
+	for _, id := range ids {
+		if err := verifyDocument(ctx, c, id, summary, w); err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/components/index"
+	"github.com/ipfs-search/ipfs-search/components/index/cache"
+	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+type IndexCommandsTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+	out *bytes.Buffer
+	now time.Time
+
+	filesBacking *index.Mock
+	filesCache   *index.Mock
+	invalids     *index.Mock
+
+	indexes []namedIndex
+}
+
+func (s *IndexCommandsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.out = &bytes.Buffer{}
+	s.now = time.Now().Truncate(time.Second).UTC()
+
+	s.filesBacking = &index.Mock{}
+	s.filesBacking.Test(s.T())
+	s.filesCache = &index.Mock{}
+	s.filesCache.Test(s.T())
+	s.invalids = &index.Mock{}
+	s.invalids.Test(s.T())
+
+	s.indexes = []namedIndex{
+		{"files", cache.New(s.filesBacking, s.filesCache, indexTypes.Update{}, instr.New())},
+		{"invalids", s.invalids},
+	}
+}
+
+func (s *IndexCommandsTestSuite) TearDownTest() {
+	s.filesBacking.AssertExpectations(s.T())
+	s.filesCache.AssertExpectations(s.T())
+	s.invalids.AssertExpectations(s.T())
+}
+
+// expectGet sets dst to the JSON-decoded source when Get is called for testCID.
+func expectGet(m *index.Mock, source string, fields []string) {
+	m.On("Get", mock.Anything, testCID, mock.Anything, fields).
+		Run(func(args mock.Arguments) {
+			if err := json.Unmarshal([]byte(source), args.Get(2)); err != nil {
+				panic(err)
+			}
+		}).
+		Return(true, nil).
+		Once()
+}
+
+func (s *IndexCommandsTestSuite) TestDocumentRoundTrip() {
+	source := `{"error": "unsupported", "last-seen": "2022-01-02T03:04:05Z"}`
+
+	doc := new(document)
+	s.NoError(json.Unmarshal([]byte(source), doc))
+	s.Equal(time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC), *doc.LastSeen)
+
+	out, err := json.Marshal(doc)
+	s.NoError(err)
+	s.JSONEq(source, string(out))
+
+	out, err = json.Marshal(new(document))
+	s.NoError(err)
+	s.Equal("{}", string(out))
+}
+
+func (s *IndexCommandsTestSuite) TestGetDocument() {
+	s.filesBacking.On("Get", mock.Anything, testCID, mock.Anything, []string(nil)).Return(false, nil).Maybe()
+	expectGet(s.invalids, `{"error": "unsupported"}`, nil)
+
+	err := getDocument(s.ctx, s.indexes, testCID, s.out)
+	s.NoError(err)
+
+	s.Contains(s.out.String(), "Found "+testCID+" in invalids")
+	s.Contains(s.out.String(), `"error": "unsupported"`)
+}
+
+func (s *IndexCommandsTestSuite) TestGetDocumentNotFound() {
+	s.filesBacking.On("Get", mock.Anything, testCID, mock.Anything, []string(nil)).Return(false, nil)
+	s.invalids.On("Get", mock.Anything, testCID, mock.Anything, []string(nil)).Return(false, nil)
+
+	err := getDocument(s.ctx, s.indexes, testCID, s.out)
+	s.ErrorIs(err, errDocumentNotFound)
+}
+
+func (s *IndexCommandsTestSuite) TestMoveDocument() {
+	expectGet(s.invalids, `{"error": "unsupported", "last-seen": "2022-01-02T03:04:05Z"}`, nil)
+
+	isMoved := func(props interface{}) bool {
+		b, err := json.Marshal(props)
+		return err == nil && string(b) == `{"error":"unsupported","last-seen":"2022-01-02T03:04:05Z"}`
+	}
+
+	lastSeen := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	isCached := func(u *indexTypes.Update) bool {
+		return u.LastSeen != nil && u.LastSeen.Equal(lastSeen)
+	}
+
+	s.filesBacking.On("Index", mock.Anything, testCID, mock.MatchedBy(isMoved)).Return(nil).Once()
+	s.filesCache.On("Index", mock.Anything, testCID, mock.MatchedBy(isCached)).Return(nil).Once()
+	s.invalids.On("Delete", mock.Anything, testCID).Return(nil).Once()
+
+	err := moveDocuments(s.ctx, s.indexes, "invalids", "files", []string{testCID}, s.out)
+	s.NoError(err)
+}
+
+func (s *IndexCommandsTestSuite) TestMoveUnknownIndex() {
+	err := moveDocuments(s.ctx, s.indexes, "invalids", "bogus", []string{testCID}, s.out)
+	s.ErrorIs(err, errUnknownIndex)
+}
+
+func (s *IndexCommandsTestSuite) TestMoveNotFound() {
+	s.invalids.On("Get", mock.Anything, testCID, mock.Anything, []string(nil)).Return(false, nil).Once()
+
+	err := moveDocuments(s.ctx, s.indexes, "invalids", "files", []string{testCID}, s.out)
+	s.ErrorIs(err, errDocumentNotFound)
+}
+
+func (s *IndexCommandsTestSuite) TestDeleteDocuments() {
+	s.filesCache.On("Delete", mock.Anything, testCID).Return(nil).Once()
+	s.filesBacking.On("Delete", mock.Anything, testCID).Return(nil).Once()
+
+	err := deleteDocuments(s.ctx, s.indexes, "files", []string{testCID}, s.out)
+	s.NoError(err)
+	s.Contains(s.out.String(), "Deleted "+testCID+" from files.")
+}
+
+func (s *IndexCommandsTestSuite) TestCountNotCounter() {
+	err := countDocuments(s.ctx, s.indexes, s.out)
+	s.NoError(err)
+	s.Contains(s.out.String(), index.ErrNotCounter.Error())
+}
+
+func (s *IndexCommandsTestSuite) TestVerifyAgreeing() {
+	expectGet(s.filesCache, `{"last-seen": "2022-01-02T03:04:05Z"}`, nil)
+	expectGet(s.filesBacking, `{"last-seen": "2022-01-02T04:04:05+01:00"}`, cachedFields)
+
+	summary, err := verifyCache(s.ctx, "files", s.indexes[0].Index, []string{testCID}, 0, s.out)
+	s.NoError(err)
+	s.Equal(&VerifySummary{Checked: 1, Agreeing: 1}, summary)
+	s.True(summary.Consistent())
+}
+
+func (s *IndexCommandsTestSuite) TestVerifyDiffering() {
+	expectGet(s.filesCache, `{"last-seen": "2022-01-02T03:04:05Z"}`, nil)
+	expectGet(s.filesBacking, `{"last-seen": "2022-01-03T03:04:05Z"}`, cachedFields)
+
+	summary, err := verifyCache(s.ctx, "files", s.indexes[0].Index, []string{testCID}, 0, s.out)
+	s.NoError(err)
+	s.Equal(&VerifySummary{Checked: 1, Differing: 1}, summary)
+	s.False(summary.Consistent())
+	s.Contains(s.out.String(), testCID+": differing")
+}
+
+func (s *IndexCommandsTestSuite) TestVerifyStaleAndNotCached() {
+	s.filesCache.On("Get", mock.Anything, testCID, mock.Anything, []string(nil)).Return(true, nil).Once()
+	s.filesBacking.On("Get", mock.Anything, testCID, mock.Anything, cachedFields).Return(false, nil).Once()
+	s.filesCache.On("Get", mock.Anything, testCIDv1, mock.Anything, []string(nil)).Return(false, nil).Once()
+	s.filesBacking.On("Get", mock.Anything, testCIDv1, mock.Anything, cachedFields).Return(true, nil).Once()
+
+	summary, err := verifyCache(s.ctx, "files", s.indexes[0].Index, []string{testCID, testCIDv1}, 0, s.out)
+	s.NoError(err)
+	s.Equal(&VerifySummary{Checked: 2, Stale: 1, NotCached: 1}, summary)
+	s.False(summary.Consistent())
+}
+
+func (s *IndexCommandsTestSuite) TestVerifyNotCached() {
+	_, err := verifyCache(s.ctx, "invalids", s.invalids, []string{testCID}, 0, s.out)
+	s.ErrorIs(err, errNotCached)
+}
+
+func (s *IndexCommandsTestSuite) TestVerifyNoSampler() {
+	_, err := verifyCache(s.ctx, "files", s.indexes[0].Index, nil, 10, s.out)
+	s.ErrorIs(err, errNoSampler)
+}
+
+func TestIndexCommandsTestSuite(t *testing.T) {
+	suite.Run(t, new(IndexCommandsTestSuite))
+}
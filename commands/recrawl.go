@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/ipfs-search/ipfs-search/components/recrawler"
+	"github.com/ipfs-search/ipfs-search/config"
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// Recrawl schedules re-crawling of stale documents; once or periodically until the context is closed.
+func Recrawl(ctx context.Context, cfg *config.Config, once bool) error {
+	idx := cfg.Indexes
+	if err := requireSearchIndexes(idx.Files, idx.Directories, idx.Dags); err != nil {
+		return err
+	}
+
+	instFlusher, err := instr.Install(cfg.InstrConfig().WithoutMetricsServer(), "ipfs-search recrawl")
+	if err != nil {
+		return err
+	}
+	defer instFlusher(ctx)
+
+	i := instr.New()
+
+	ctx, span := i.Tracer.Start(ctx, "commands.Recrawl")
+	defer span.End()
+
+	client, err := getOpenSearchClient(ctx, cfg, i)
+	if err != nil {
+		return err
+	}
+
+	conn, err := getAMQPConnection(ctx, cfg, i)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Only used for publishing; prefetch is irrelevant.
+	ch, err := conn.NewChannel(ctx, 1)
+	if err != nil {
+		return err
+	}
+
+	fq, err := ch.Queue(ctx, cfg.Queues.Files.Name)
+	if err != nil {
+		return err
+	}
+
+	dq, err := ch.Queue(ctx, cfg.Queues.Directories.Name)
+	if err != nil {
+		return err
+	}
+
+	hq, err := ch.Queue(ctx, cfg.Queues.Hashes.Name)
+	if err != nil {
+		return err
+	}
+
+	targets := []recrawler.Target{
+		{Index: cfg.Indexes.Files.Name, Queue: fq, Type: t.FileType},
+		{Index: cfg.Indexes.Directories.Name, Queue: dq, Type: t.DirectoryType},
+		// DAGs are not supported by the crawler as type; they are recognized after Stat.
+		{Index: cfg.Indexes.Dags.Name, Queue: hq, Type: t.UndefinedType},
+	}
+
+	r := recrawler.New(cfg.RecrawlerConfig(), client, targets, i)
+
+	if once {
+		return r.Schedule(ctx)
+	}
+
+	return r.Start(ctx)
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/ipfs-search/ipfs-search/components/api"
+	"github.com/ipfs-search/ipfs-search/config"
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+// Serve serves the search API until the context is closed.
+func Serve(ctx context.Context, cfg *config.Config) error {
+	idx := cfg.Indexes
+	if err := requireSearchIndexes(idx.Files, idx.Directories, idx.Invalids, idx.Partials); err != nil {
+		return err
+	}
+
+	instFlusher, err := instr.Install(cfg.InstrConfig(), "ipfs-search serve")
+	if err != nil {
+		return err
+	}
+	defer instFlusher(ctx)
+
+	i := instr.New()
+
+	ctx, span := i.Tracer.Start(ctx, "commands.Serve")
+	defer span.End()
+
+	client, err := getOpenSearchClient(ctx, cfg, i)
+	if err != nil {
+		return err
+	}
+
+	// Work processes batched metadata lookups; keep it running until the context is closed.
+	go func() {
+		log := i.Component("api")
+
+		for ctx.Err() == nil {
+			if err := client.Work(ctx); err != nil {
+				log.ErrorCtx(ctx, "Error in OpenSearch client, restarting", "err", err)
+				time.Sleep(time.Second)
+			}
+		}
+	}()
+
+	searchIndexes := &api.SearchIndexes{
+		Files:       cfg.Indexes.Files.Name,
+		Directories: cfg.Indexes.Directories.Name,
+	}
+
+	indexes := &api.Indexes{
+		Files:       client.NewIndex(cfg.Indexes.Files.Name),
+		Directories: client.NewIndex(cfg.Indexes.Directories.Name),
+		Invalids:    client.NewIndex(cfg.Indexes.Invalids.Name),
+		Partials:    client.NewIndex(cfg.Indexes.Partials.Name),
+	}
+
+	s := api.New(cfg.APIConfig(), client, searchIndexes, indexes, i)
+
+	return s.Start(ctx)
+}
@@ -0,0 +1,21 @@
+package admin
+
+import (
+	"time"
+)
+
+// Config contains configuration for the admin server.
+type Config struct {
+	Listen       string        // Address to listen on, e.g. "127.0.0.1:9616"; the server is disabled when empty.
+	CheckTimeout time.Duration // Timeout for readiness checks.
+	AllowChanges bool          // Allow changes at runtime, such as worker limits; off by default, as requests are not authenticated.
+}
+
+// DefaultConfig generates a default configuration for the admin server.
+func DefaultConfig() *Config {
+	return &Config{
+		Listen:       "127.0.0.1:9616", // Only locally, as pprof exposes internals.
+		CheckTimeout: 5 * time.Second,
+		AllowChanges: false,
+	}
+}
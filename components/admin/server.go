@@ -0,0 +1,284 @@
+// Package admin provides an HTTP server exposing the health, readiness and runtime status of a process, as well as
+// profiling through pprof.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+// CheckFunc returns an error when a dependency is unavailable.
+type CheckFunc func(context.Context) error
+
+// StatusFunc returns the runtime status of the process, which is encoded as JSON.
+type StatusFunc func() interface{}
+
+// WorkerLimits bounds the number of workers of a pool.
+type WorkerLimits struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// Scaler scales the workers of named pools within limits, which can be changed at runtime.
+type Scaler interface {
+	WorkerLimits() map[string]WorkerLimits
+	SetWorkerLimits(pool string, limits WorkerLimits) error
+}
+
+// Server answers health, readiness, status and profiling requests over HTTP.
+type Server struct {
+	config *Config
+
+	mu     sync.RWMutex
+	ready  bool
+	checks map[string]CheckFunc
+	status StatusFunc
+	scaler Scaler
+
+	*instr.Instrumentation
+	log *slog.Logger
+}
+
+// New returns a new admin server, which is not ready until SetReady is called.
+func New(config *Config, i *instr.Instrumentation) *Server {
+	return &Server{
+		config:          config,
+		checks:          make(map[string]CheckFunc),
+		Instrumentation: i,
+		log:             i.Component("admin"),
+	}
+}
+
+// AddCheck adds a named readiness check, replacing any existing check with the same name.
+func (s *Server) AddCheck(name string, f CheckFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checks[name] = f
+}
+
+// SetStatus sets the function reporting the runtime status.
+func (s *Server) SetStatus(f StatusFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status = f
+}
+
+// SetScaler sets the Scaler for changing worker limits.
+func (s *Server) SetScaler(scaler Scaler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scaler = scaler
+}
+
+// SetReady sets whether the process is ready to do work, e.g. after initialization or before shutting down.
+func (s *Server) SetReady(ready bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ready = ready
+}
+
+// Handler returns the HTTP handler for the admin server.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", s.handleHealth)
+	mux.HandleFunc("/readyz", s.handleReady)
+	mux.HandleFunc("/status", s.handleStatus)
+	mux.HandleFunc("/workers", s.handleWorkers)
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return mux
+}
+
+// Start serves the admin endpoints until the context is closed.
+func (s *Server) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:    s.config.Listen,
+		Handler: s.Handler(),
+		BaseContext: func(_ net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		// Use background context because current context is already closed.
+		if err := srv.Shutdown(context.Background()); err != nil {
+			s.log.Error("Error shutting down admin server", "err", err)
+		}
+	}()
+
+	s.log.Info("Serving admin endpoints", "listen", s.config.Listen)
+
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+type healthResponse struct {
+	Status string `json:"status"`
+}
+
+type readyResponse struct {
+	Ready  bool              `json:"ready"`
+	Checks map[string]string `json:"checks"`
+}
+
+// handleHealth reports that the process is alive; it is answered as long as the server runs.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.writeJSON(w, http.StatusOK, healthResponse{"ok"})
+}
+
+// handleReady runs all checks, reporting ready only when the process is ready and all checks pass.
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), s.config.CheckTimeout)
+	defer cancel()
+
+	s.mu.RLock()
+	ready := s.ready
+	checks := make(map[string]CheckFunc, len(s.checks))
+	for name, f := range s.checks {
+		checks[name] = f
+	}
+	s.mu.RUnlock()
+
+	resp := readyResponse{
+		Ready:  ready,
+		Checks: s.runChecks(ctx, checks),
+	}
+
+	for _, result := range resp.Checks {
+		if result != "ok" {
+			resp.Ready = false
+		}
+	}
+
+	status := http.StatusOK
+	if !resp.Ready {
+		status = http.StatusServiceUnavailable
+	}
+
+	s.writeJSON(w, status, resp)
+}
+
+// runChecks runs checks concurrently, returning "ok" or the error for each of them.
+func (s *Server) runChecks(ctx context.Context, checks map[string]CheckFunc) map[string]string {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]string, len(checks))
+	)
+
+	for name, f := range checks {
+		wg.Add(1)
+
+		go func(name string, f CheckFunc) {
+			defer wg.Done()
+
+			result := "ok"
+			if err := f(ctx); err != nil {
+				s.log.WarnCtx(ctx, "Readiness check failed", "check", name, "err", err)
+				result = "error: " + err.Error()
+			}
+
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}(name, f)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// handleStatus writes the runtime status.
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	status := s.status
+	s.mu.RUnlock()
+
+	if status == nil {
+		s.writeJSON(w, http.StatusOK, struct{}{})
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, status())
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// handleWorkers writes the worker limits of each pool, changing limits of the pools in PUT request bodies first when
+// changes are allowed.
+func (s *Server) handleWorkers(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	scaler := s.scaler
+	s.mu.RUnlock()
+
+	if scaler == nil {
+		s.writeJSON(w, http.StatusServiceUnavailable, errorResponse{"workers not started"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut:
+		if !s.config.AllowChanges {
+			s.writeJSON(w, http.StatusForbidden, errorResponse{"changes not allowed, see admin.allow_changes"})
+			return
+		}
+
+		limits := make(map[string]WorkerLimits)
+		if err := json.NewDecoder(r.Body).Decode(&limits); err != nil {
+			s.writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+			return
+		}
+
+		for pool, l := range limits {
+			if err := scaler.SetWorkerLimits(pool, l); err != nil {
+				s.writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+				return
+			}
+
+			s.log.InfoCtx(r.Context(), "Changed worker limits", "pool", pool, "min", l.Min, "max", l.Max)
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		s.writeJSON(w, http.StatusMethodNotAllowed, errorResponse{"method not allowed"})
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, scaler.WorkerLimits())
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.log.Error("Error writing admin response", "err", err)
+	}
+}
@@ -0,0 +1,171 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+
+	server  *Server
+	handler http.Handler
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.server = New(DefaultConfig(), instr.New())
+	s.handler = s.server.Handler()
+}
+
+func (s *ServerTestSuite) get(path string, v interface{}) int {
+	return s.do(http.MethodGet, path, "", v)
+}
+
+func (s *ServerTestSuite) do(method, path, body string, v interface{}) int {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	s.handler.ServeHTTP(rec, req)
+
+	if v != nil {
+		s.NoError(json.Unmarshal(rec.Body.Bytes(), v))
+	}
+
+	return rec.Code
+}
+
+func (s *ServerTestSuite) TestHealth() {
+	var resp healthResponse
+
+	s.Equal(http.StatusOK, s.get("/healthz", &resp))
+	s.Equal("ok", resp.Status)
+}
+
+func (s *ServerTestSuite) TestNotReady() {
+	s.server.AddCheck("amqp", func(context.Context) error { return nil })
+
+	var resp readyResponse
+
+	s.Equal(http.StatusServiceUnavailable, s.get("/readyz", &resp))
+	s.False(resp.Ready)
+	s.Equal("ok", resp.Checks["amqp"])
+}
+
+func (s *ServerTestSuite) TestReady() {
+	s.server.AddCheck("amqp", func(context.Context) error { return nil })
+	s.server.AddCheck("redis", func(context.Context) error { return nil })
+	s.server.SetReady(true)
+
+	var resp readyResponse
+
+	s.Equal(http.StatusOK, s.get("/readyz", &resp))
+	s.True(resp.Ready)
+	s.Equal(map[string]string{"amqp": "ok", "redis": "ok"}, resp.Checks)
+}
+
+func (s *ServerTestSuite) TestCheckFails() {
+	s.server.AddCheck("amqp", func(context.Context) error { return nil })
+	s.server.AddCheck("redis", func(context.Context) error { return errors.New("connection refused") })
+	s.server.SetReady(true)
+
+	var resp readyResponse
+
+	s.Equal(http.StatusServiceUnavailable, s.get("/readyz", &resp))
+	s.False(resp.Ready)
+	s.Equal("ok", resp.Checks["amqp"])
+	s.Equal("error: connection refused", resp.Checks["redis"])
+}
+
+func (s *ServerTestSuite) TestCheckTimeout() {
+	s.server.config.CheckTimeout = 0
+	s.server.AddCheck("opensearch", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	s.server.SetReady(true)
+
+	var resp readyResponse
+
+	s.Equal(http.StatusServiceUnavailable, s.get("/readyz", &resp))
+	s.Equal("error: "+context.DeadlineExceeded.Error(), resp.Checks["opensearch"])
+}
+
+func (s *ServerTestSuite) TestStatus() {
+	var empty map[string]interface{}
+
+	s.Equal(http.StatusOK, s.get("/status", &empty))
+	s.Empty(empty)
+
+	s.server.SetStatus(func() interface{} {
+		return map[string]int{"files": 3}
+	})
+
+	var resp map[string]int
+
+	s.Equal(http.StatusOK, s.get("/status", &resp))
+	s.Equal(map[string]int{"files": 3}, resp)
+}
+
+func (s *ServerTestSuite) TestPprof() {
+	s.Equal(http.StatusOK, s.get("/debug/pprof/", nil))
+	s.Equal(http.StatusOK, s.get("/debug/pprof/goroutine?debug=1", nil))
+}
+
+type scalerMock struct {
+	limits map[string]WorkerLimits
+}
+
+func (m *scalerMock) WorkerLimits() map[string]WorkerLimits {
+	return m.limits
+}
+
+func (m *scalerMock) SetWorkerLimits(pool string, limits WorkerLimits) error {
+	if _, ok := m.limits[pool]; !ok {
+		return fmt.Errorf("unknown pool %s", pool)
+	}
+
+	m.limits[pool] = limits
+
+	return nil
+}
+
+func (s *ServerTestSuite) TestWorkers() {
+	s.Equal(http.StatusServiceUnavailable, s.get("/workers", nil))
+
+	s.server.SetScaler(&scalerMock{map[string]WorkerLimits{
+		"files":  {10, 120},
+		"hashes": {10, 70},
+	}})
+
+	var resp map[string]WorkerLimits
+
+	s.Equal(http.StatusOK, s.get("/workers", &resp))
+	s.Equal(WorkerLimits{10, 120}, resp["files"])
+
+	// Changes are not allowed by default.
+	s.Equal(http.StatusForbidden, s.do(http.MethodPut, "/workers", `{"files": {"min": 5, "max": 200}}`, nil))
+
+	s.server.config.AllowChanges = true
+
+	s.Equal(http.StatusOK, s.do(http.MethodPut, "/workers", `{"files": {"min": 5, "max": 200}}`, &resp))
+	s.Equal(WorkerLimits{5, 200}, resp["files"])
+	s.Equal(WorkerLimits{10, 70}, resp["hashes"])
+
+	s.Equal(http.StatusBadRequest, s.do(http.MethodPut, "/workers", `{"bogus": {"min": 5, "max": 200}}`, nil))
+	s.Equal(http.StatusBadRequest, s.do(http.MethodPut, "/workers", `[]`, nil))
+	s.Equal(http.StatusMethodNotAllowed, s.do(http.MethodDelete, "/workers", "", nil))
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"time"
+)
+
+// Config contains configuration for the API server.
+type Config struct {
+	Listen          string        // Address to listen on, e.g. ":9615".
+	DefaultPageSize uint          // Results per page when not specified.
+	MaxPageSize     uint          // Maximum results per page.
+	MaxResults      uint          // Maximum results to page through; deeper pages are refused.
+	Timeout         time.Duration // Timeout for handling requests.
+}
+
+// DefaultConfig generates a default configuration for the API server.
+func DefaultConfig() *Config {
+	return &Config{
+		Listen:          ":9615",
+		DefaultPageSize: 15,
+		MaxPageSize:     100,
+		MaxResults:      10000, // OpenSearch default for index.max_result_window
+		Timeout:         30 * time.Second,
+	}
+}
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"github.com/ipfs-search/ipfs-search/components/index"
+)
+
+// Indexes used for metadata lookups.
+type Indexes struct {
+	Files       index.Index
+	Directories index.Index
+	Invalids    index.Index
+	Partials    index.Index
+}
+
+// SearchIndexes are the names of the indexes to search.
+type SearchIndexes struct {
+	Files       string
+	Directories string
+}
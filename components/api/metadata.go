@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ipfs-search/ipfs-search/components/index"
+)
+
+var errNotFound = errors.New("not found")
+
+type metadataResponse struct {
+	Hash     string          `json:"hash"`
+	Type     string          `json:"type"`
+	Document json.RawMessage `json:"document"`
+}
+
+// metadataTypes are the result types corresponding to metadataIndexes.
+var metadataTypes = []string{fileType, directoryType, invalidType, partialType}
+
+// metadataIndexes returns the indexes for metadata lookups.
+func (s *Server) metadataIndexes() []index.Index {
+	return []index.Index{s.indexes.Files, s.indexes.Directories, s.indexes.Invalids, s.indexes.Partials}
+}
+
+func (s *Server) handleMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.Tracer.Start(r.Context(), "api.Metadata")
+	defer span.End()
+
+	if !s.allowMethod(w, r) {
+		return
+	}
+
+	hash := strings.TrimPrefix(r.URL.Path, "/v1/metadata/")
+	if hash == "" || strings.Contains(hash, "/") {
+		s.writeError(w, http.StatusBadRequest, fmt.Errorf("%w: invalid hash '%s'", errInvalidParameter, hash))
+		return
+	}
+
+	var (
+		doc     json.RawMessage
+		indexes = s.metadataIndexes()
+	)
+
+	found, err := index.MultiGet(ctx, indexes, hash, &doc)
+	if err != nil {
+		span.RecordError(err)
+		s.writeError(w, errorStatus(err), err)
+		return
+	}
+
+	if found == nil {
+		s.writeError(w, http.StatusNotFound, fmt.Errorf("%w: %s", errNotFound, hash))
+		return
+	}
+
+	resp := metadataResponse{
+		Hash:     hash,
+		Document: doc,
+	}
+
+	for i, idx := range indexes {
+		if idx == found {
+			resp.Type = metadataTypes[i]
+		}
+	}
+
+	s.writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ipfs-search/ipfs-search/components/index/opensearch"
+)
+
+// Result types.
+const (
+	fileType      = "file"
+	directoryType = "directory"
+	invalidType   = "invalid"
+	partialType   = "partial"
+	anyType       = "any"
+)
+
+var errInvalidParameter = errors.New("invalid parameter")
+
+// Fields excluded from search results; they are available through metadata lookups.
+var excludeFields = []string{"content", "links"}
+
+type searchRequest struct {
+	query    string
+	docType  string
+	mime     string
+	page     uint
+	pageSize uint
+}
+
+type searchHit struct {
+	Hash   string          `json:"hash"`
+	Type   string          `json:"type"`
+	Score  float64         `json:"score"`
+	Source json.RawMessage `json:"source"`
+}
+
+type searchResponse struct {
+	Total     uint        `json:"total"`
+	MaxScore  float64     `json:"max_score"`
+	Page      uint        `json:"page"`
+	PageSize  uint        `json:"page_size"`
+	PageCount uint        `json:"page_count"`
+	Hits      []searchHit `json:"hits"`
+}
+
+func parseUint(r *http.Request, name string, dflt uint) (uint, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return dflt, nil
+	}
+
+	i, err := strconv.ParseUint(v, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s must be a positive integer", errInvalidParameter, name)
+	}
+
+	return uint(i), nil
+}
+
+func (s *Server) parseSearchRequest(r *http.Request) (*searchRequest, error) {
+	var err error
+
+	values := r.URL.Query()
+
+	req := &searchRequest{
+		query:   values.Get("q"),
+		docType: values.Get("type"),
+		mime:    values.Get("mime"),
+	}
+
+	if req.query == "" {
+		return nil, fmt.Errorf("%w: q is required", errInvalidParameter)
+	}
+
+	switch req.docType {
+	case "":
+		req.docType = anyType
+	case fileType, directoryType, anyType:
+	default:
+		return nil, fmt.Errorf("%w: type must be one of %s, %s or %s", errInvalidParameter, fileType, directoryType, anyType)
+	}
+
+	if req.mime != "" && req.docType == directoryType {
+		return nil, fmt.Errorf("%w: mime cannot be used for directories", errInvalidParameter)
+	}
+
+	if req.page, err = parseUint(r, "page", 0); err != nil {
+		return nil, err
+	}
+
+	if req.pageSize, err = parseUint(r, "page_size", s.config.DefaultPageSize); err != nil {
+		return nil, err
+	}
+
+	if req.pageSize == 0 || req.pageSize > s.config.MaxPageSize {
+		return nil, fmt.Errorf("%w: page_size must be between 1 and %d", errInvalidParameter, s.config.MaxPageSize)
+	}
+
+	if (req.page+1)*req.pageSize > s.config.MaxResults {
+		return nil, fmt.Errorf("%w: page beyond the first %d results", errInvalidParameter, s.config.MaxResults)
+	}
+
+	return req, nil
+}
+
+// searchIndexes returns the indexes to search for a type.
+func (s *Server) getSearchIndexes(docType string) []string {
+	switch docType {
+	case fileType:
+		return []string{s.searchIndexes.Files}
+	case directoryType:
+		return []string{s.searchIndexes.Directories}
+	default:
+		return []string{s.searchIndexes.Files, s.searchIndexes.Directories}
+	}
+}
+
+// hitType returns the result type from the index of a hit. As configured index names might be aliases,
+// the concrete index name is matched by prefix.
+func (s *Server) hitType(h *opensearch.SearchHit) string {
+	switch {
+	case strings.HasPrefix(h.Index, s.searchIndexes.Files):
+		return fileType
+	case strings.HasPrefix(h.Index, s.searchIndexes.Directories):
+		return directoryType
+	default:
+		return h.Index
+	}
+}
+
+// getQuery returns the query DSL for a search request.
+func getQuery(req *searchRequest) map[string]interface{} {
+	boolQuery := map[string]interface{}{
+		"must": map[string]interface{}{
+			"query_string": map[string]interface{}{
+				"query":            req.query,
+				"default_operator": "AND",
+			},
+		},
+		"must_not": []interface{}{
+			// Exclude additional pages of large directories.
+			map[string]interface{}{
+				"exists": map[string]interface{}{
+					"field": "page",
+				},
+			},
+			// Exclude content which could not be retrieved when re-crawling.
+			map[string]interface{}{
+				"term": map[string]interface{}{
+					"unreachable": true,
+				},
+			},
+		},
+	}
+
+	if req.mime != "" {
+		boolQuery["filter"] = map[string]interface{}{
+			"prefix": map[string]interface{}{
+				"metadata.Content-Type": req.mime,
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": boolQuery,
+		},
+		"from": req.page * req.pageSize,
+		"size": req.pageSize,
+		"_source": map[string]interface{}{
+			"excludes": excludeFields,
+		},
+	}
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.Tracer.Start(r.Context(), "api.Search")
+	defer span.End()
+
+	if !s.allowMethod(w, r) {
+		return
+	}
+
+	req, err := s.parseSearchRequest(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.searcher.Search(ctx, s.getSearchIndexes(req.docType), getQuery(req))
+	if err != nil {
+		span.RecordError(err)
+		s.writeError(w, errorStatus(err), err)
+		return
+	}
+
+	resp := searchResponse{
+		Total:     result.Total,
+		MaxScore:  result.MaxScore,
+		Page:      req.page,
+		PageSize:  req.pageSize,
+		PageCount: (result.Total + req.pageSize - 1) / req.pageSize,
+		Hits:      make([]searchHit, len(result.Hits)),
+	}
+
+	for i := range result.Hits {
+		h := &result.Hits[i]
+
+		resp.Hits[i] = searchHit{
+			Hash:   h.ID,
+			Type:   s.hitType(h),
+			Score:  h.Score,
+			Source: h.Source,
+		}
+	}
+
+	s.writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,125 @@
+// Package api provides an HTTP server for searching the indexes and retrieving metadata.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/ipfs-search/ipfs-search/components/index/opensearch"
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// Searcher executes search queries over one or more indexes.
+type Searcher interface {
+	Search(ctx context.Context, indexes []string, body interface{}) (*opensearch.SearchResult, error)
+}
+
+// Server answers search and metadata queries over HTTP.
+type Server struct {
+	config        *Config
+	searcher      Searcher
+	searchIndexes *SearchIndexes
+	indexes       *Indexes
+
+	*instr.Instrumentation
+	log *slog.Logger
+}
+
+// New returns a new API server.
+func New(config *Config, searcher Searcher, searchIndexes *SearchIndexes, indexes *Indexes, i *instr.Instrumentation) *Server {
+	return &Server{
+		config:          config,
+		searcher:        searcher,
+		searchIndexes:   searchIndexes,
+		indexes:         indexes,
+		Instrumentation: i,
+		log:             i.Component("api"),
+	}
+}
+
+// Handler returns the HTTP handler for the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/search", s.handleSearch)
+	mux.HandleFunc("/v1/metadata/", s.handleMetadata)
+
+	return http.TimeoutHandler(mux, s.config.Timeout, `{"error":"timeout"}`)
+}
+
+// Start serves the API until the context is closed.
+func (s *Server) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:    s.config.Listen,
+		Handler: s.Handler(),
+		BaseContext: func(_ net.Listener) context.Context {
+			return ctx
+		},
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		// Use background context because current context is already closed.
+		if err := srv.Shutdown(context.Background()); err != nil {
+			s.log.Error("Error shutting down API server", "err", err)
+		}
+	}()
+
+	s.log.Info("Serving API", "listen", s.config.Listen)
+
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.log.Error("Error writing API response", "err", err)
+	}
+}
+
+func (s *Server) writeError(w http.ResponseWriter, status int, err error) {
+	s.writeJSON(w, status, errorResponse{err.Error()})
+}
+
+// errorStatus returns the HTTP status for errors from the indexes.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, opensearch.ErrBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, t.ErrRequest), errors.Is(err, t.ErrUnexpectedResponse):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// allowMethod returns true when the request method is GET, writing an error otherwise.
+func (s *Server) allowMethod(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+
+	w.Header().Set("Allow", "GET, HEAD")
+	s.writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+
+	return false
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/components/index"
+	"github.com/ipfs-search/ipfs-search/components/index/opensearch"
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+type searcherMock struct {
+	mock.Mock
+}
+
+func (m *searcherMock) Search(ctx context.Context, indexes []string, body interface{}) (*opensearch.SearchResult, error) {
+	args := m.Called(ctx, indexes, body)
+	result, _ := args.Get(0).(*opensearch.SearchResult)
+	return result, args.Error(1)
+}
+
+type ServerTestSuite struct {
+	suite.Suite
+
+	searcher *searcherMock
+	fileIdx  *index.Mock
+	dirIdx   *index.Mock
+	invIdx   *index.Mock
+	partIdx  *index.Mock
+
+	handler http.Handler
+}
+
+func (s *ServerTestSuite) SetupTest() {
+	s.searcher = &searcherMock{}
+	s.fileIdx, s.dirIdx, s.invIdx, s.partIdx = &index.Mock{}, &index.Mock{}, &index.Mock{}, &index.Mock{}
+
+	searchIndexes := &SearchIndexes{
+		Files:       "ipfs_files",
+		Directories: "ipfs_directories",
+	}
+
+	indexes := &Indexes{
+		Files:       s.fileIdx,
+		Directories: s.dirIdx,
+		Invalids:    s.invIdx,
+		Partials:    s.partIdx,
+	}
+
+	s.handler = New(DefaultConfig(), s.searcher, searchIndexes, indexes, instr.New()).Handler()
+}
+
+func (s *ServerTestSuite) assertExpectations() {
+	s.searcher.AssertExpectations(s.T())
+	s.fileIdx.AssertExpectations(s.T())
+	s.dirIdx.AssertExpectations(s.T())
+	s.invIdx.AssertExpectations(s.T())
+	s.partIdx.AssertExpectations(s.T())
+}
+
+func (s *ServerTestSuite) get(url string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+
+	var body map[string]interface{}
+	s.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+
+	return w, body
+}
+
+func (s *ServerTestSuite) TestSearch() {
+	result := &opensearch.SearchResult{
+		Total:    31,
+		MaxScore: 2,
+		Hits: []opensearch.SearchHit{
+			{Index: "ipfs_files_v9", ID: "QmFile", Score: 2, Source: json.RawMessage(`{"size":5}`)},
+			{Index: "ipfs_directories_v9", ID: "QmDir", Score: 1, Source: json.RawMessage(`{}`)},
+		},
+	}
+
+	s.searcher.
+		On("Search", mock.Anything, []string{"ipfs_files", "ipfs_directories"}, mock.MatchedBy(func(q map[string]interface{}) bool {
+			return q["from"] == uint(30) && q["size"] == uint(15)
+		})).
+		Return(result, nil).
+		Once()
+
+	w, body := s.get("/v1/search?q=hello&page=2")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal(float64(31), body["total"])
+	s.Equal(float64(3), body["page_count"])
+
+	hits := body["hits"].([]interface{})
+	s.Len(hits, 2)
+	s.Equal("file", hits[0].(map[string]interface{})["type"])
+	s.Equal("QmFile", hits[0].(map[string]interface{})["hash"])
+	s.Equal("directory", hits[1].(map[string]interface{})["type"])
+
+	s.assertExpectations()
+}
+
+func (s *ServerTestSuite) TestSearchFilters() {
+	s.searcher.
+		On("Search", mock.Anything, []string{"ipfs_files"}, mock.MatchedBy(func(q map[string]interface{}) bool {
+			boolQuery := q["query"].(map[string]interface{})["bool"].(map[string]interface{})
+			filter := boolQuery["filter"].(map[string]interface{})["prefix"].(map[string]interface{})
+
+			return filter["metadata.Content-Type"] == "text/html" && q["size"] == uint(50)
+		})).
+		Return(&opensearch.SearchResult{}, nil).
+		Once()
+
+	w, body := s.get("/v1/search?q=hello&type=file&mime=text/html&page_size=50")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal(float64(0), body["total"])
+
+	s.assertExpectations()
+}
+
+func (s *ServerTestSuite) TestSearchInvalid() {
+	for _, url := range []string{
+		"/v1/search",
+		"/v1/search?q=hello&type=bogus",
+		"/v1/search?q=hello&type=directory&mime=text/html",
+		"/v1/search?q=hello&page_size=1000",
+		"/v1/search?q=hello&page=-1",
+		"/v1/search?q=hello&page=10000",
+	} {
+		w, body := s.get(url)
+
+		s.Equal(http.StatusBadRequest, w.Code, url)
+		s.Contains(body["error"], "invalid parameter", url)
+	}
+
+	s.assertExpectations()
+}
+
+func (s *ServerTestSuite) TestSearchError() {
+	s.searcher.
+		On("Search", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, t.ErrUnexpectedResponse).
+		Once()
+
+	w, _ := s.get("/v1/search?q=hello")
+
+	s.Equal(http.StatusBadGateway, w.Code)
+	s.assertExpectations()
+}
+
+func (s *ServerTestSuite) TestSearchBadRequest() {
+	s.searcher.
+		On("Search", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: 400 Bad Request", opensearch.ErrBadRequest)).
+		Once()
+
+	w, body := s.get("/v1/search?q=title:(hello")
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(body["error"], "bad request")
+	s.assertExpectations()
+}
+
+func (s *ServerTestSuite) TestMetadata() {
+	s.fileIdx.On("Get", mock.Anything, "QmDir", mock.Anything, []string(nil)).Return(false, nil)
+	s.invIdx.On("Get", mock.Anything, "QmDir", mock.Anything, []string(nil)).Return(false, nil)
+	s.partIdx.On("Get", mock.Anything, "QmDir", mock.Anything, []string(nil)).Return(false, nil)
+	s.dirIdx.
+		On("Get", mock.Anything, "QmDir", mock.Anything, []string(nil)).
+		Run(func(args mock.Arguments) {
+			dst := args.Get(2).(*json.RawMessage)
+			*dst = json.RawMessage(`{"size":3}`)
+		}).
+		Return(true, nil).
+		Once()
+
+	w, body := s.get("/v1/metadata/QmDir")
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("QmDir", body["hash"])
+	s.Equal("directory", body["type"])
+	s.Equal(map[string]interface{}{"size": float64(3)}, body["document"])
+}
+
+func (s *ServerTestSuite) TestMetadataNotFound() {
+	for _, idx := range []*index.Mock{s.fileIdx, s.dirIdx, s.invIdx, s.partIdx} {
+		idx.On("Get", mock.Anything, "QmNone", mock.Anything, []string(nil)).Return(false, nil).Once()
+	}
+
+	w, _ := s.get("/v1/metadata/QmNone")
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.assertExpectations()
+}
+
+func (s *ServerTestSuite) TestMethodNotAllowed() {
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/search?q=hello", nil))
+
+	s.Equal(http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestServerTestSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
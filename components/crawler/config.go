@@ -9,8 +9,14 @@ type Config struct {
 	DirEntryBufferSize uint          // Size of buffer for processing directory entry channels.
 	MinUpdateAge       time.Duration // The minimum age for items to be updated.
 	StatTimeout        time.Duration // Timeout for Stat() calls.
+	ResolveTimeout     time.Duration // Timeout for Resolve() calls.
 	DirEntryTimeout    time.Duration // Timeout *between* directory entries.
-	MaxDirSize         uint          // Maximum number of directory entries
+	MaxDirSize         uint          // Maximum number of directory entries per document (page).
+	CheckpointTTL      time.Duration // Maximum age of directory checkpoints for resuming crawls.
+	DagTimeout         time.Duration // Timeout for GetDag() calls.
+	MaxDagFields       uint          // Maximum number of fields (and links) indexed for DAGs; only indexed links are queued.
+	MaxProviders       uint          // Maximum number of providing peers recorded per document.
+	MaxNameHistory     uint          // Maximum number of (most recent) resolutions recorded per name.
 }
 
 // DefaultConfig generates a default configuration for a Crawler.
@@ -19,7 +25,13 @@ func DefaultConfig() *Config {
 		DirEntryBufferSize: 8192,
 		MinUpdateAge:       time.Hour,
 		StatTimeout:        60 * time.Second,
+		ResolveTimeout:     60 * time.Second,
 		DirEntryTimeout:    60 * time.Second,
 		MaxDirSize:         32768,
+		CheckpointTTL:      24 * time.Hour,
+		DagTimeout:         60 * time.Second,
+		MaxDagFields:       1024,
+		MaxProviders:       32,
+		MaxNameHistory:     100,
 	}
 }
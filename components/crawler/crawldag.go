@@ -0,0 +1,157 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"path"
+	"sort"
+	"strconv"
+
+	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// dagLinkKey is the key for links and bytes in the IPLD data model, as represented in DAG-JSON.
+const dagLinkKey = "/"
+
+// dagWalker flattens IPLD data model nodes into fields and links.
+type dagWalker struct {
+	fields []indexTypes.DagField
+	links  indexTypes.Links
+}
+
+func formatDagValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		// Bytes and unexpected types; use their JSON representation.
+		b, err := json.Marshal(v)
+		if err != nil {
+			panic(fmt.Sprintf("unexpected value in DAG: %v", err))
+		}
+
+		return string(b)
+	}
+}
+
+func (w *dagWalker) walk(node interface{}, p string) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if v, ok := n[dagLinkKey]; ok && len(n) == 1 {
+			if hash, ok := v.(string); ok {
+				w.links = append(w.links, indexTypes.Link{
+					Hash: hash,
+					Name: p,
+					Type: indexTypes.UnknownLinkType,
+				})
+
+				return
+			}
+
+			// Bytes: {"/": {"bytes": "<base64>"}}
+			w.fields = append(w.fields, indexTypes.DagField{Path: p, Value: formatDagValue(v)})
+
+			return
+		}
+
+		// Sort keys for deterministic results.
+		keys := make([]string, 0, len(n))
+		for k := range n {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			w.walk(n[k], path.Join(p, k))
+		}
+
+	case []interface{}:
+		for i, v := range n {
+			w.walk(v, path.Join(p, strconv.Itoa(i)))
+		}
+
+	case nil:
+		// Skip null values.
+
+	default:
+		w.fields = append(w.fields, indexTypes.DagField{Path: p, Value: formatDagValue(n)})
+	}
+}
+
+func (c *Crawler) crawlDag(ctx context.Context, r *t.AnnotatedResource, properties *indexTypes.Dag) error {
+	ctx, span := c.Tracer.Start(ctx, "crawler.crawlDag")
+	defer span.End()
+
+	getCtx, cancel := context.WithTimeout(ctx, c.config.Load().DagTimeout)
+	defer cancel()
+
+	node, err := c.protocol.GetDag(getCtx, r)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	w := new(dagWalker)
+	w.walk(node, "")
+
+	maxFields := c.config.Load().MaxDagFields
+
+	if uint(len(w.fields)) > maxFields || uint(len(w.links)) > maxFields {
+		span.AddEvent("large-dag")
+		c.log.InfoCtx(ctx, "DAG is large, indexing and queueing only part of its fields and links", "cid", r.ID, "max_fields", maxFields)
+	}
+
+	// Only index and queue up to limit, preventing oversized documents and queue floods.
+	properties.Fields = truncateFields(w.fields, maxFields)
+	properties.Links = truncateLinks(w.links, maxFields)
+
+	for i := range properties.Links {
+		if err := c.queueDagLink(ctx, r, &properties.Links[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func truncateFields(fields []indexTypes.DagField, max uint) []indexTypes.DagField {
+	if uint(len(fields)) > max {
+		return fields[:max]
+	}
+
+	return fields
+}
+
+func truncateLinks(links indexTypes.Links, max uint) indexTypes.Links {
+	if uint(len(links)) > max {
+		return links[:max]
+	}
+
+	return links
+}
+
+func (c *Crawler) queueDagLink(ctx context.Context, parent *t.AnnotatedResource, l *indexTypes.Link) error {
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: parent.Protocol,
+			ID:       l.Hash,
+		},
+		Source: t.DagSource,
+		Reference: t.Reference{
+			Parent: parent.Resource,
+			Name:   l.Name,
+		},
+	}
+
+	// Random lower priority, as for directory entries.
+	priority := uint8(1 + rand.Intn(7))
+
+	return c.queues.Hashes.Publish(ctx, r, priority)
+}
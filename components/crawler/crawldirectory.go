@@ -3,28 +3,93 @@ package crawler
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
 	"math/rand"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/ipfs-search/ipfs-search/components/index"
+	"github.com/ipfs-search/ipfs-search/components/index/cache"
 	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
 	t "github.com/ipfs-search/ipfs-search/types"
 )
 
 var (
-	// ErrDirectoryTooLarge is returned by Ls() when a directory is larger `Config.MaxDirSize`.
-	ErrDirectoryTooLarge = t.WrappedError{Err: t.ErrInvalidResource, Msg: "directory too large"}
-
 	// errEndOfLs is an internal error to communicate the end of hte list from processNextDirEntry to processDirEntries.
 	errEndOfLs = errors.New("end of list")
+
+	// errCheckpointMismatch is returned when a directory listing does not match its checkpoint.
+	errCheckpointMismatch = errors.New("directory listing does not match checkpoint")
 )
 
+// pageID returns the document ID for additional pages of a directory.
+func pageID(r *t.AnnotatedResource, page uint) string {
+	return fmt.Sprintf("%s.%d", r.ID, page)
+}
+
+// pagesIndex returns the index for additional pages of directories. Pages lack the fields cached for directories,
+// hence they are written to the backing index of a cached directories index.
+func (c *Crawler) pagesIndex() index.Index {
+	if cached, ok := c.indexes.Directories.(*cache.Index); ok {
+		return cached.Backing()
+	}
+
+	return c.indexes.Directories
+}
+
+// getCheckpoint returns the checkpoint for an earlier, interrupted, crawl of a directory or an empty
+// checkpoint if there is none. Checkpoints older than CheckpointTTL are discarded.
+func (c *Crawler) getCheckpoint(ctx context.Context, r *t.AnnotatedResource) (*indexTypes.Checkpoint, error) {
+	cp := new(indexTypes.Checkpoint)
+
+	found, err := c.indexes.Checkpoints.Get(ctx, r.ID, cp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return cp, nil
+	}
+
+	if age := time.Since(cp.Time); age > c.config.Load().CheckpointTTL {
+		c.log.InfoCtx(ctx, "Discarding expired checkpoint", "cid", r.ID, "entries", cp.Count, "age", age)
+
+		if err := c.indexes.Checkpoints.Delete(ctx, r.ID); err != nil {
+			return nil, err
+		}
+
+		return new(indexTypes.Checkpoint), nil
+	}
+
+	c.log.InfoCtx(ctx, "Resuming directory", "cid", r.ID, "entries", cp.Count, "name", cp.Name)
+
+	return cp, nil
+}
+
+// discardCheckpoint deletes the checkpoint of a directory whose listing changed, so that the next attempt starts
+// from scratch. It returns err, or the error deleting the checkpoint.
+func (c *Crawler) discardCheckpoint(ctx context.Context, r *t.AnnotatedResource, err error) error {
+	c.log.WarnCtx(ctx, "Discarding checkpoint", "cid", r.ID, "err", err)
+
+	if deleteErr := c.indexes.Checkpoints.Delete(ctx, r.ID); deleteErr != nil {
+		return deleteErr
+	}
+
+	return err
+}
+
 func (c *Crawler) crawlDir(ctx context.Context, r *t.AnnotatedResource, properties *indexTypes.Directory) error {
 	ctx, span := c.Tracer.Start(ctx, "crawler.crawlDir")
 	defer span.End()
 
-	entries := make(chan *t.AnnotatedResource, c.config.DirEntryBufferSize)
+	cp, err := c.getCheckpoint(ctx, r)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	entries := make(chan *t.AnnotatedResource, c.config.Load().DirEntryBufferSize)
 
 	wg, ctx := errgroup.WithContext(ctx)
 
@@ -43,7 +108,7 @@ func (c *Crawler) crawlDir(ctx context.Context, r *t.AnnotatedResource, properti
 				panicVar = r
 			}
 		}()
-		return c.processDirEntries(ctx, entries, properties)
+		return c.processDirEntries(ctx, r, cp, entries, properties)
 	})
 
 	wg.Go(func() error {
@@ -74,8 +139,8 @@ func resourceToLinkType(r *t.AnnotatedResource) indexTypes.LinkType {
 	}
 }
 
-func addLink(e *t.AnnotatedResource, properties *indexTypes.Directory) {
-	properties.Links = append(properties.Links, indexTypes.Link{
+func addLink(e *t.AnnotatedResource, links *indexTypes.Links) {
+	*links = append(*links, indexTypes.Link{
 		Hash: e.ID,
 		Name: e.Reference.Name,
 		Size: e.Size,
@@ -83,19 +148,37 @@ func addLink(e *t.AnnotatedResource, properties *indexTypes.Directory) {
 	})
 }
 
-func (c *Crawler) processDirEntries(ctx context.Context, entries <-chan *t.AnnotatedResource, properties *indexTypes.Directory) error {
+// completePage indexes a completed page (beyond the first) and checkpoints progress.
+func (c *Crawler) completePage(ctx context.Context, r *t.AnnotatedResource, page *indexTypes.DirectoryPage, cnt uint, last *t.AnnotatedResource) error {
+	if page != nil {
+		if err := c.pagesIndex().Index(ctx, pageID(r, page.Page), page); err != nil {
+			return err
+		}
+	}
+
+	return c.indexes.Checkpoints.Index(ctx, r.ID, &indexTypes.Checkpoint{
+		Count: cnt,
+		Name:  last.Reference.Name,
+		Time:  time.Now(),
+	})
+}
+
+// processDirEntries adds links for entries to the directory properties, for up to MaxDirSize entries, and
+// to additional pages for larger directories. Progress is checkpointed after every page, allowing
+// processing to resume after the entries in checkpoint cp.
+func (c *Crawler) processDirEntries(ctx context.Context, r *t.AnnotatedResource, cp *indexTypes.Checkpoint, entries <-chan *t.AnnotatedResource, properties *indexTypes.Directory) error {
 	ctx, span := c.Tracer.Start(ctx, "crawler.processDirEntries")
 	defer span.End()
 
 	var (
-		dirCnt  uint = 0
-		isLarge bool = false
+		dirCnt       uint = 0
+		page         *indexTypes.DirectoryPage
+		checkpointed = cp.Count > 0
 	)
 
-	// Question: do we need a maximum entry cutoff point? E.g. 10^6 entries or something?
 	processNextDirEntry := func() error {
 		// Create (and cancel!) a new timeout context for every entry.
-		ctx, cancel := context.WithTimeout(ctx, c.config.DirEntryTimeout)
+		ctx, cancel := context.WithTimeout(ctx, c.config.Load().DirEntryTimeout)
 		defer cancel()
 
 		select {
@@ -107,22 +190,51 @@ func (c *Crawler) processDirEntries(ctx context.Context, entries <-chan *t.Annot
 			}
 
 			if dirCnt > 0 && dirCnt%1024 == 0 {
-				log.Printf("Processed %d directory entries in %v.", dirCnt, entry.Parent)
-				log.Printf("Latest entry: %v", entry)
+				c.log.DebugCtx(ctx, "Processing directory entries", "cid", r.ID, "entries", dirCnt, "latest", entry.String())
 			}
 
-			// Only add to properties up to limit (preventing oversized directory entries) - but queue entries nonetheless.
-			if dirCnt == c.config.MaxDirSize {
-				span.AddEvent("large-directory")
-				log.Printf("Directory %v is large, crawling entries but not directory itself.", entry.Parent)
-				isLarge = true
+			resumed := dirCnt < cp.Count
+
+			// The first page is part of the directory document, which is indexed last. Hence, it
+			// is gathered even for resumed entries.
+			if pageNum := dirCnt / c.config.Load().MaxDirSize; pageNum == 0 {
+				addLink(entry, &properties.Links)
+			} else if !resumed {
+				if page == nil {
+					page = &indexTypes.DirectoryPage{
+						Directory: r.ID,
+						Page:      pageNum,
+					}
+				}
+
+				addLink(entry, &page.Links)
+			}
+
+			if resumed {
+				// Resumed entries have been queued before.
+				if dirCnt == cp.Count-1 && entry.Reference.Name != cp.Name {
+					return fmt.Errorf("%w: expected '%s' but got '%s'", errCheckpointMismatch, cp.Name, entry.Reference.Name)
+				}
+
+				return nil
 			}
 
-			if !isLarge {
-				addLink(entry, properties)
+			if err := c.queueDirEntry(ctx, entry); err != nil {
+				return err
+			}
+
+			if (dirCnt+1)%c.config.Load().MaxDirSize == 0 {
+				span.AddEvent("large-directory")
+
+				if err := c.completePage(ctx, r, page, dirCnt+1, entry); err != nil {
+					return err
+				}
+
+				page = nil
+				checkpointed = true
 			}
 
-			return c.queueDirEntry(ctx, entry)
+			return nil
 		}
 	}
 
@@ -130,21 +242,29 @@ func (c *Crawler) processDirEntries(ctx context.Context, entries <-chan *t.Annot
 
 	// Process entries until error.
 	for err == nil {
-		err = processNextDirEntry()
-		dirCnt++
+		if err = processNextDirEntry(); err == nil {
+			dirCnt++
+		}
 	}
 
-	if errors.Is(err, errEndOfLs) {
+	switch {
+	case errors.Is(err, errEndOfLs):
 		// Normal exit of loop, reset error condition
-		err = nil
+		err = c.finishDirEntries(ctx, r, cp, page, dirCnt, properties, checkpointed)
 
-		if isLarge {
-			err = ErrDirectoryTooLarge
+		if errors.Is(err, errCheckpointMismatch) {
+			// Listing shrunk; start from scratch on the next attempt.
+			err = c.discardCheckpoint(ctx, r, err)
 		}
-	} else {
+
+	case errors.Is(err, errCheckpointMismatch):
+		// Listing changed; start from scratch on the next attempt.
+		err = c.discardCheckpoint(ctx, r, err)
+
+	default:
 		// Unknown error situation: fail hard
 		// Prefer less over incomplete or inconsistent data.
-		log.Printf("Unexpected error processing directory entries: %v", err)
+		c.log.ErrorCtx(ctx, "Unexpected error processing directory entries", "cid", r.ID, "err", err)
 	}
 
 	if err != nil {
@@ -154,6 +274,30 @@ func (c *Crawler) processDirEntries(ctx context.Context, entries <-chan *t.Annot
 	return err
 }
 
+// finishDirEntries indexes the last page and removes the checkpoint after all dirCnt entries have been processed.
+func (c *Crawler) finishDirEntries(ctx context.Context, r *t.AnnotatedResource, cp *indexTypes.Checkpoint, page *indexTypes.DirectoryPage, dirCnt uint, properties *indexTypes.Directory, checkpointed bool) error {
+	if dirCnt < cp.Count {
+		return fmt.Errorf("%w: expected at least %d entries but got %d", errCheckpointMismatch, cp.Count, dirCnt)
+	}
+
+	if page != nil {
+		if err := c.pagesIndex().Index(ctx, pageID(r, page.Page), page); err != nil {
+			return err
+		}
+	}
+
+	if pages := (dirCnt + c.config.Load().MaxDirSize - 1) / c.config.Load().MaxDirSize; pages > 1 {
+		c.log.InfoCtx(ctx, "Directory is large, indexed in pages", "cid", r.ID, "entries", dirCnt, "pages", pages)
+		properties.Pages = pages
+	}
+
+	if checkpointed {
+		return c.indexes.Checkpoints.Delete(ctx, r.ID)
+	}
+
+	return nil
+}
+
 func (c *Crawler) queueDirEntry(ctx context.Context, r *t.AnnotatedResource) error {
 	// Generate random lower priority for items in this directory
 	// Rationale; directories might have different availability but
@@ -4,10 +4,12 @@ package crawler
 import (
 	"context"
 	"errors"
-	"log"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
 
 	"github.com/ipfs-search/ipfs-search/components/extractor"
 	"github.com/ipfs-search/ipfs-search/components/protocol"
@@ -18,13 +20,16 @@ import (
 
 // Crawler allows crawling of resources.
 type Crawler struct {
-	config     *Config
+	config     atomic.Pointer[Config]
 	indexes    *Indexes
 	queues     *Queues
 	protocol   protocol.Protocol
 	extractors []extractor.Extractor
 
+	extractionVersion string
+
 	*instr.Instrumentation
+	log *slog.Logger
 }
 
 func isSupportedType(rType t.ResourceType) bool {
@@ -36,6 +41,15 @@ func isSupportedType(rType t.ResourceType) bool {
 	}
 }
 
+// Outcomes of crawls, recorded in metrics.
+const (
+	outcomeNew      = "new"
+	outcomeExisting = "existing"
+	outcomeInvalid  = "invalid"
+	outcomePartial  = "partial"
+	outcomeError    = "error"
+)
+
 // Crawl updates existing or crawls new resources, extracting metadata where applicable.
 func (c *Crawler) Crawl(ctx context.Context, r *t.AnnotatedResource) error {
 	ctx, span := c.Tracer.Start(ctx, "crawler.Crawl",
@@ -43,14 +57,41 @@ func (c *Crawler) Crawl(ctx context.Context, r *t.AnnotatedResource) error {
 	)
 	defer span.End()
 
-	var err error
-
 	if r.Protocol == t.InvalidProtocol {
 		// Sending items with an invalid protocol to Crawl() is a programming error and
 		// should never happen.
 		panic("invalid protocol")
 	}
 
+	start := time.Now()
+
+	outcome, err := c.crawl(ctx, r)
+	if err != nil {
+		span.RecordError(err)
+		outcome = outcomeError
+	}
+
+	attrs := attribute.String("outcome", outcome)
+	c.Metrics.Crawls.Add(ctx, 1, attrs)
+	c.Metrics.CrawlDuration.Record(ctx, milliseconds(time.Since(start)), attrs)
+
+	return err
+}
+
+// milliseconds returns d as fractional milliseconds, for metrics.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// crawl crawls r, returning the outcome.
+func (c *Crawler) crawl(ctx context.Context, r *t.AnnotatedResource) (string, error) {
+	span := trace.SpanFromContext(ctx)
+
+	if r.Protocol == t.IPNSProtocol {
+		// Names are mutable; resolve and index them rather than their content.
+		return c.crawlName(ctx, r)
+	}
+
 	if !isSupportedType(r.Type) {
 		// Calling crawler with unsupported types is undefined behaviour.
 		panic("invalid type for crawler")
@@ -58,50 +99,52 @@ func (c *Crawler) Crawl(ctx context.Context, r *t.AnnotatedResource) error {
 
 	exists, err := c.updateMaybeExisting(ctx, r)
 	if err != nil {
-		span.RecordError(err)
-		return err
+		return "", err
 	}
 
 	if exists {
-		log.Printf("Done processing existing resource: %v", r)
+		c.log.DebugCtx(ctx, "Done processing existing resource", "cid", r.ID)
 		span.AddEvent("existing resource")
-		return nil
+		return outcomeExisting, nil
 	}
 
 	if err := c.ensureType(ctx, r); err != nil {
 		if errors.Is(err, t.ErrInvalidResource) {
 			// Resource is invalid, index as such, throwing away ErrInvalidResource in favor of the result of indexing operation.
-			log.Printf("Indexing invalid resource %v", r)
+			c.log.InfoCtx(ctx, "Indexing invalid resource", "cid", r.ID, "err", err)
 			span.AddEvent("Indexing invalid resource")
 
-			err = c.indexInvalid(ctx, r, err)
+			return outcomeInvalid, c.indexInvalid(ctx, r, err)
 		}
 
 		// Errors from ensureType imply that no type could be found, hence we can't index.
-		if err != nil {
-			span.RecordError(err)
-		}
-		return err
+		return "", err
 	}
 
-	log.Printf("Indexing new item %v", r)
-	err = c.index(ctx, r)
-	if err != nil {
-		span.RecordError(err)
-	}
-	return err
+	c.log.InfoCtx(ctx, "Indexing new item", "cid", r.ID, "resource", r.String())
+	return c.index(ctx, r)
 }
 
 // New instantiates a Crawler.
 func New(config *Config, indexes *Indexes, queues *Queues, protocol protocol.Protocol, extractors []extractor.Extractor, i *instr.Instrumentation) *Crawler {
-	return &Crawler{
-		config,
-		indexes,
-		queues,
-		protocol,
-		extractors,
-		i,
+	c := &Crawler{
+		indexes:           indexes,
+		queues:            queues,
+		protocol:          protocol,
+		extractors:        extractors,
+		extractionVersion: getExtractionVersion(extractors),
+		Instrumentation:   i,
+		log:               i.Component("crawler"),
 	}
+
+	c.config.Store(config)
+
+	return c
+}
+
+// SetConfig replaces the crawler's configuration, applying to subsequent crawls.
+func (c *Crawler) SetConfig(config *Config) {
+	c.config.Store(config)
 }
 
 func (c *Crawler) ensureType(ctx context.Context, r *t.AnnotatedResource) error {
@@ -111,7 +154,7 @@ func (c *Crawler) ensureType(ctx context.Context, r *t.AnnotatedResource) error
 	var err error
 
 	if r.Type == t.UndefinedType {
-		ctx, cancel := context.WithTimeout(ctx, c.config.StatTimeout)
+		ctx, cancel := context.WithTimeout(ctx, c.config.Load().StatTimeout)
 		defer cancel()
 
 		err = c.protocol.Stat(ctx, r)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/ipfs-search/ipfs-search/components/extractor"
 	"github.com/ipfs-search/ipfs-search/components/index"
+	"github.com/ipfs-search/ipfs-search/components/index/cache"
 	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
 	"github.com/ipfs-search/ipfs-search/components/protocol"
 	"github.com/ipfs-search/ipfs-search/components/queue"
@@ -39,6 +41,9 @@ type CrawlerTestSuite struct {
 	dirIdx     *index.Mock
 	invalidIdx *index.Mock
 	partialIdx *index.Mock
+	nameIdx    *index.Mock
+	dagIdx     *index.Mock
+	cpIdx      *index.Mock
 
 	dirQ  *queue.Mock
 	fileQ *queue.Mock
@@ -50,12 +55,16 @@ func (s *CrawlerTestSuite) SetupTest() {
 
 	// Creat a crawler with mocked dependencies
 	s.fileIdx, s.dirIdx, s.invalidIdx, s.partialIdx = &index.Mock{}, &index.Mock{}, &index.Mock{}, &index.Mock{}
+	s.nameIdx, s.dagIdx, s.cpIdx = &index.Mock{}, &index.Mock{}, &index.Mock{}
 
 	s.indexes = &Indexes{
 		Files:       s.fileIdx,
 		Directories: s.dirIdx,
 		Invalids:    s.invalidIdx,
 		Partials:    s.partialIdx,
+		Names:       s.nameIdx,
+		Dags:        s.dagIdx,
+		Checkpoints: s.cpIdx,
 	}
 
 	s.fileQ, s.dirQ, s.hashQ = &queue.Mock{}, &queue.Mock{}, &queue.Mock{}
@@ -84,6 +93,9 @@ func (s *CrawlerTestSuite) assertExpectations() {
 		s.fileIdx,
 		s.dirIdx,
 		s.invalidIdx,
+		s.nameIdx,
+		s.dagIdx,
+		s.cpIdx,
 		s.fileQ,
 		s.dirQ,
 		s.hashQ,
@@ -95,22 +107,34 @@ func (s *CrawlerTestSuite) assertExpectations() {
 
 func (s *CrawlerTestSuite) assertNotExists(rID string) {
 	s.fileIdx.
-		On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Once()
 
 	s.dirIdx.
-		On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Once()
 
 	s.invalidIdx.
-		On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Once()
 
 	s.partialIdx.
-		On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Return(false, nil).
+		Once()
+
+	s.dagIdx.
+		On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Return(false, nil).
+		Once()
+}
+
+func (s *CrawlerTestSuite) assertNoCheckpoint(rID string) {
+	s.cpIdx.
+		On("Get", mock.Anything, rID, mock.Anything, mock.Anything).
 		Return(false, nil).
 		Once()
 }
@@ -342,25 +366,30 @@ func (s *CrawlerTestSuite) TestCrawlReferencedPartialType() {
 		Once()
 
 	s.fileIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.dirIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.invalidIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.partialIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(true, nil).
 		Once()
 
+	s.dagIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Return(false, nil).
+		Maybe()
+
 	s.partialIdx.
 		On("Delete", mock.Anything, r.Resource.ID).
 		Return(nil).
@@ -477,6 +506,177 @@ func (s *CrawlerTestSuite) TestCrawlMultiExtractor() {
 	s.assertExpectations()
 }
 
+// versionedExtractor is a mocked extractor of which the results may be cached.
+type versionedExtractor struct {
+	*extractor.Mock
+	version string
+}
+
+func (e versionedExtractor) Version() string {
+	return e.version
+}
+
+// setupExtractionCache sets up the crawler with versioned extractors and an extractions index.
+func (s *CrawlerTestSuite) setupExtractionCache() *index.Mock {
+	extractionIdx := &index.Mock{}
+	extractionIdx.Test(s.T())
+
+	s.indexes.Extractions = extractionIdx
+
+	extractors := []extractor.Extractor{
+		versionedExtractor{s.extractor1, "one-1"},
+		versionedExtractor{s.extractor2, "two-1"},
+	}
+
+	s.c = New(s.cfg, s.indexes, s.queues, s.protocol, extractors, s.instr)
+
+	return extractionIdx
+}
+
+func (s *CrawlerTestSuite) TestCrawlExtractionCacheHit() {
+	extractionIdx := s.setupExtractionCache()
+
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Stat: t.Stat{
+			Type: t.FileType,
+			Size: 15,
+		},
+	}
+
+	key := r.Resource.ID + ":one-1,two-1"
+
+	extractionIdx.
+		On("Get", mock.Anything, key, mock.Anything, indexTypes.ExtractionFields).
+		Run(func(args mock.Arguments) {
+			e := args.Get(2).(*indexTypes.Extraction)
+			e.Content = "cachedContent"
+		}).
+		Return(true, nil).
+		Once()
+
+	s.fileIdx.
+		On("Index", mock.Anything, r.Resource.ID, mock.MatchedBy(func(f *indexTypes.File) bool {
+			return f.Content == "cachedContent" && f.Size == 15
+		})).
+		Return(nil).
+		Once()
+
+	s.assertNotExists(r.Resource.ID)
+
+	err := s.c.Crawl(s.ctx, r)
+
+	s.NoError(err)
+	s.extractor1.AssertNotCalled(s.T(), "Extract", mock.Anything, mock.Anything, mock.Anything)
+	s.extractor2.AssertNotCalled(s.T(), "Extract", mock.Anything, mock.Anything, mock.Anything)
+	extractionIdx.AssertExpectations(s.T())
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlExtractionCacheMiss() {
+	extractionIdx := s.setupExtractionCache()
+
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Stat: t.Stat{
+			Type: t.FileType,
+		},
+	}
+
+	key := r.Resource.ID + ":one-1,two-1"
+
+	extractionIdx.
+		On("Get", mock.Anything, key, mock.Anything, indexTypes.ExtractionFields).
+		Return(false, nil).
+		Once()
+
+	s.extractor1.
+		On("Extract", mock.Anything, r, mock.Anything).
+		Run(func(args mock.Arguments) {
+			f := args.Get(2).(*indexTypes.File)
+			f.Content = "testContent"
+		}).
+		Return(nil).
+		Once()
+
+	s.extractor2.
+		On("Extract", mock.Anything, r, mock.Anything).
+		Return(nil).
+		Once()
+
+	extractionIdx.
+		On("Index", mock.Anything, key, mock.MatchedBy(func(e *indexTypes.Extraction) bool {
+			return e.Content == "testContent"
+		})).
+		Return(nil).
+		Once()
+
+	s.fileIdx.
+		On("Index", mock.Anything, r.Resource.ID, mock.IsType(&indexTypes.File{})).
+		Return(nil).
+		Once()
+
+	s.assertNotExists(r.Resource.ID)
+
+	err := s.c.Crawl(s.ctx, r)
+
+	s.NoError(err)
+	extractionIdx.AssertExpectations(s.T())
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlExtractionCacheFailed() {
+	extractionIdx := s.setupExtractionCache()
+
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Stat: t.Stat{
+			Type: t.FileType,
+		},
+	}
+
+	extractionIdx.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, indexTypes.ExtractionFields).
+		Return(false, nil).
+		Once()
+
+	extractErr := errors.New("extraction failed")
+
+	s.extractor1.
+		On("Extract", mock.Anything, r, mock.Anything).
+		Return(extractErr).
+		Once()
+
+	s.extractor2.
+		On("Extract", mock.Anything, r, mock.Anything).
+		Return(nil).
+		Once()
+
+	s.fileIdx.
+		On("Index", mock.Anything, r.Resource.ID, mock.IsType(&indexTypes.File{})).
+		Return(nil).
+		Once()
+
+	s.assertNotExists(r.Resource.ID)
+
+	err := s.c.Crawl(s.ctx, r)
+
+	// Results of failed extractions are not cached.
+	s.NoError(err)
+	extractionIdx.AssertNotCalled(s.T(), "Index", mock.Anything, mock.Anything, mock.Anything)
+	extractionIdx.AssertExpectations(s.T())
+	s.assertExpectations()
+}
+
 func (s *CrawlerTestSuite) TestCrawlLargeFile() {
 	// Prepare resource
 	r := &t.AnnotatedResource{
@@ -623,6 +823,7 @@ func (s *CrawlerTestSuite) TestCrawlReferencedDirectory() {
 		Return(nil).
 		Once()
 
+	s.assertNoCheckpoint(r.Resource.ID)
 	s.assertNotExists(r.Resource.ID)
 
 	// Crawl
@@ -777,6 +978,7 @@ func (s *CrawlerTestSuite) TestCrawlDirectoryType() {
 		Return(nil).
 		Once()
 
+	s.assertNoCheckpoint(r.Resource.ID)
 	s.assertNotExists(r.Resource.ID)
 
 	// Crawl
@@ -820,6 +1022,7 @@ func (s *CrawlerTestSuite) TestCrawlDirectoryUnexpectedType() {
 		Return(nil).
 		Once()
 
+	s.assertNoCheckpoint(r.Resource.ID)
 	s.assertNotExists(r.Resource.ID)
 
 	//// THIS PANIC IS NOT PROPERLY CAUGHT FIXME!!!
@@ -829,6 +1032,45 @@ func (s *CrawlerTestSuite) TestCrawlDirectoryUnexpectedType() {
 	s.Panics(func() { _ = s.c.Crawl(s.ctx, r) })
 }
 
+func (s *CrawlerTestSuite) makeDirEntries(parent *t.Resource, n int) []*t.AnnotatedResource {
+	entries := make([]*t.AnnotatedResource, n)
+
+	for i := range entries {
+		entries[i] = &t.AnnotatedResource{
+			Resource: &t.Resource{
+				Protocol: t.IPFSProtocol,
+				ID:       "QmafrLBfzRLV4XSH1XcaMMeaXEUhDJjmtDfsYU95TrWG87",
+			},
+			Reference: t.Reference{
+				Parent: parent,
+				Name:   fmt.Sprintf("file%d.pdf", i),
+			},
+			Stat: t.Stat{
+				Type: t.FileType,
+				Size: 3431,
+			},
+		}
+	}
+
+	return entries
+}
+
+func linkNames(links indexTypes.Links) []string {
+	names := make([]string, len(links))
+	for i, l := range links {
+		names[i] = l.Name
+	}
+
+	return names
+}
+
+// matchCheckpoint matches a checkpoint after count entries, the last of which is called name, made just now.
+func matchCheckpoint(count uint, name string) interface{} {
+	return mock.MatchedBy(func(cp *indexTypes.Checkpoint) bool {
+		return cp.Count == count && cp.Name == name && time.Since(cp.Time) < time.Minute
+	})
+}
+
 func (s *CrawlerTestSuite) TestCrawlLargeDirectory() {
 	s.cfg = DefaultConfig()
 
@@ -849,53 +1091,68 @@ func (s *CrawlerTestSuite) TestCrawlLargeDirectory() {
 		},
 	}
 
-	// Mock assertions
-	fileEntry := t.AnnotatedResource{
-		Resource: &t.Resource{
-			Protocol: t.IPFSProtocol,
-			ID:       "QmafrLBfzRLV4XSH1XcaMMeaXEUhDJjmtDfsYU95TrWG87",
-		},
-		Reference: t.Reference{
-			Parent: &t.Resource{
-				Protocol: t.IPFSProtocol,
-				ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
-			},
-			Name: "fileName.pdf",
-		},
-		Stat: t.Stat{
-			Type: t.FileType,
-			Size: 3431,
-		},
-	}
+	entries := s.makeDirEntries(r.Resource, 8)
 
 	s.protocol.
 		On("Ls", mock.Anything, r, mock.AnythingOfType("chan<- *types.AnnotatedResource")).
 		Run(func(args mock.Arguments) {
-			// Write bogus directory entry
 			entryChan := args.Get(2).(chan<- *t.AnnotatedResource)
-			entryChan <- &fileEntry
-			entryChan <- &fileEntry
-			entryChan <- &fileEntry
-			entryChan <- &fileEntry
-			entryChan <- &fileEntry
+			for _, e := range entries {
+				entryChan <- e
+			}
 		}).
 		Return(nil).
 		Once()
 
-	s.invalidIdx.
-		On("Index", mock.Anything, r.Resource.ID, mock.MatchedBy(func(f *indexTypes.Invalid) bool {
-			return s.Equal("directory too large", f.Error)
+	s.fileQ.
+		On("Publish", mock.Anything, mock.AnythingOfType("*types.AnnotatedResource"), mock.AnythingOfType("uint8")).
+		Return(nil).
+		Times(8)
+
+	// Checkpoint after every page
+	s.cpIdx.
+		On("Index", mock.Anything, r.Resource.ID, matchCheckpoint(3, "file2.pdf")).
+		Return(nil).
+		Once()
+
+	s.cpIdx.
+		On("Index", mock.Anything, r.Resource.ID, matchCheckpoint(6, "file5.pdf")).
+		Return(nil).
+		Once()
+
+	s.cpIdx.
+		On("Delete", mock.Anything, r.Resource.ID).
+		Return(nil).
+		Once()
+
+	// Additional pages
+	s.dirIdx.
+		On("Index", mock.Anything, r.Resource.ID+".1", mock.MatchedBy(func(p *indexTypes.DirectoryPage) bool {
+			return r.Resource.ID == p.Directory &&
+				p.Page == 1 &&
+				reflect.DeepEqual([]string{"file3.pdf", "file4.pdf", "file5.pdf"}, linkNames(p.Links))
 		})).
 		Return(nil).
 		Once()
 
-	s.fileQ.
-		On("Publish", mock.Anything, mock.MatchedBy(func(f *t.AnnotatedResource) bool {
-			return s.Equal(fileEntry, *f)
-		}), mock.AnythingOfType("uint8")).
+	s.dirIdx.
+		On("Index", mock.Anything, r.Resource.ID+".2", mock.MatchedBy(func(p *indexTypes.DirectoryPage) bool {
+			return p.Page == 2 &&
+				reflect.DeepEqual([]string{"file6.pdf", "file7.pdf"}, linkNames(p.Links))
+		})).
 		Return(nil).
-		Times(5)
+		Once()
+
+	// Directory itself, with the first page
+	s.dirIdx.
+		On("Index", mock.Anything, r.Resource.ID, mock.MatchedBy(func(d *indexTypes.Directory) bool {
+			return d.Pages == 3 &&
+				reflect.DeepEqual([]string{"file0.pdf", "file1.pdf", "file2.pdf"}, linkNames(d.Links))
+		})).
+		Return(nil).
+		Once()
 
+	s.assertNoCheckpoint(r.Resource.ID)
 	s.assertNotExists(r.Resource.ID)
 
 	// Crawl
@@ -906,15 +1163,17 @@ func (s *CrawlerTestSuite) TestCrawlLargeDirectory() {
 	s.assertExpectations()
 }
 
-func (s *CrawlerTestSuite) TestCrawlDirEntryTimeout() {
+func (s *CrawlerTestSuite) TestCrawlLargeDirectoryCached() {
 	s.cfg = DefaultConfig()
 
-	// Override dir entry timeout
-	s.cfg.DirEntryTimeout = 5 * time.Millisecond
+	// Override MaxDirSize
+	s.cfg.MaxDirSize = 3
 
-	s.c = New(s.cfg, s.indexes, s.queues, s.protocol, []extractor.Extractor{s.extractor1}, s.instr)
+	// Cache directories, as configured by default.
+	cacheIdx := &index.Mock{}
+	s.indexes.Directories = cache.New(s.dirIdx, cacheIdx, indexTypes.Update{}, s.instr)
 
-	entryDelay := 2 * s.cfg.DirEntryTimeout
+	s.c = New(s.cfg, s.indexes, s.queues, s.protocol, []extractor.Extractor{s.extractor1}, s.instr)
 
 	// Prepare resource
 	r := &t.AnnotatedResource{
@@ -924,49 +1183,420 @@ func (s *CrawlerTestSuite) TestCrawlDirEntryTimeout() {
 		},
 		Stat: t.Stat{
 			Type: t.DirectoryType,
+			Size: 23,
 		},
 	}
 
-	fileEntry := t.AnnotatedResource{
-		Resource: &t.Resource{
-			Protocol: t.IPFSProtocol,
-			ID:       "QmafrLBfzRLV4XSH1XcaMMeaXEUhDJjmtDfsYU95TrWG87",
-		},
-		Reference: t.Reference{
-			Parent: &t.Resource{
-				Protocol: t.IPFSProtocol,
-				ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
-			},
-			Name: "fileName.pdf",
-		},
-		Stat: t.Stat{
-			Type: t.FileType,
-			Size: 3431,
-		},
-	}
-
-	dirEntry := t.AnnotatedResource{
-		Resource: &t.Resource{
-			Protocol: t.IPFSProtocol,
-			ID:       "QmS4ustL54uo8FzR9455qaxZwuMiUhyvMcX9Ba8nUH4uVv",
-		},
-		Reference: t.Reference{
-			Parent: &t.Resource{
-				Protocol: t.IPFSProtocol,
-				ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
-			},
-			Name: "dirName",
-		},
-		Stat: t.Stat{
-			Type: t.DirectoryType,
-			Size: 4534543,
-		},
-	}
+	entries := s.makeDirEntries(r.Resource, 5)
 
-	s.fileQ.
-		On("Publish", mock.Anything, mock.MatchedBy(func(f *t.AnnotatedResource) bool {
-			return s.Equal(*f, fileEntry)
-		}), mock.AnythingOfType("uint8")).
+	s.protocol.
+		On("Ls", mock.Anything, r, mock.AnythingOfType("chan<- *types.AnnotatedResource")).
+		Run(func(args mock.Arguments) {
+			entryChan := args.Get(2).(chan<- *t.AnnotatedResource)
+			for _, e := range entries {
+				entryChan <- e
+			}
+		}).
+		Return(nil).
+		Once()
+
+	s.fileQ.
+		On("Publish", mock.Anything, mock.AnythingOfType("*types.AnnotatedResource"), mock.AnythingOfType("uint8")).
+		Return(nil).
+		Times(5)
+
+	s.cpIdx.
+		On("Index", mock.Anything, r.Resource.ID, matchCheckpoint(3, "file2.pdf")).
+		Return(nil).
+		Once()
+
+	s.cpIdx.
+		On("Delete", mock.Anything, r.Resource.ID).
+		Return(nil).
+		Once()
+
+	// Not in cache.
+	cacheIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, mock.Anything).
+		Return(false, nil).
+		Once()
+
+	// Additional pages bypass the cache.
+	s.dirIdx.
+		On("Index", mock.Anything, r.Resource.ID+".1", mock.MatchedBy(func(p *indexTypes.DirectoryPage) bool {
+			return reflect.DeepEqual([]string{"file3.pdf", "file4.pdf"}, linkNames(p.Links))
+		})).
+		Return(nil).
+		Once()
+
+	// Directory itself is indexed and cached.
+	s.dirIdx.
+		On("Index", mock.Anything, r.Resource.ID, mock.AnythingOfType("*types.Directory")).
+		Return(nil).
+		Once()
+
+	cacheIdx.
+		On("Index", mock.Anything, r.Resource.ID, mock.AnythingOfType("*types.Update")).
+		Return(nil).
+		Once()
+
+	s.assertNoCheckpoint(r.Resource.ID)
+	s.assertNotExists(r.Resource.ID)
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+	cacheIdx.AssertExpectations(s.T())
+}
+
+func (s *CrawlerTestSuite) TestCrawlResumeDirectory() {
+	s.cfg = DefaultConfig()
+
+	// Override MaxDirSize
+	s.cfg.MaxDirSize = 3
+
+	s.c = New(s.cfg, s.indexes, s.queues, s.protocol, []extractor.Extractor{s.extractor1}, s.instr)
+
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Stat: t.Stat{
+			Type: t.DirectoryType,
+			Size: 23,
+		},
+	}
+
+	entries := s.makeDirEntries(r.Resource, 8)
+
+	// Previous crawl was interrupted after the second page.
+	s.cpIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			cp := args.Get(2).(*indexTypes.Checkpoint)
+			cp.Count = 6
+			cp.Name = "file5.pdf"
+			cp.Time = time.Now()
+		}).
+		Return(true, nil).
+		Once()
+
+	s.protocol.
+		On("Ls", mock.Anything, r, mock.AnythingOfType("chan<- *types.AnnotatedResource")).
+		Run(func(args mock.Arguments) {
+			entryChan := args.Get(2).(chan<- *t.AnnotatedResource)
+			for _, e := range entries {
+				entryChan <- e
+			}
+		}).
+		Return(nil).
+		Once()
+
+	// Only entries after the checkpoint are queued.
+	s.fileQ.
+		On("Publish", mock.Anything, entries[6], mock.AnythingOfType("uint8")).
+		Return(nil).
+		Once()
+
+	s.fileQ.
+		On("Publish", mock.Anything, entries[7], mock.AnythingOfType("uint8")).
+		Return(nil).
+		Once()
+
+	s.cpIdx.
+		On("Delete", mock.Anything, r.Resource.ID).
+		Return(nil).
+		Once()
+
+	s.dirIdx.
+		On("Index", mock.Anything, r.Resource.ID+".2", mock.MatchedBy(func(p *indexTypes.DirectoryPage) bool {
+			return reflect.DeepEqual([]string{"file6.pdf", "file7.pdf"}, linkNames(p.Links))
+		})).
+		Return(nil).
+		Once()
+
+	s.dirIdx.
+		On("Index", mock.Anything, r.Resource.ID, mock.MatchedBy(func(d *indexTypes.Directory) bool {
+			return d.Pages == 3 &&
+				reflect.DeepEqual([]string{"file0.pdf", "file1.pdf", "file2.pdf"}, linkNames(d.Links))
+		})).
+		Return(nil).
+		Once()
+
+	s.assertNotExists(r.Resource.ID)
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlResumeDirectoryMismatch() {
+	s.cfg = DefaultConfig()
+
+	// Override MaxDirSize
+	s.cfg.MaxDirSize = 3
+
+	s.c = New(s.cfg, s.indexes, s.queues, s.protocol, []extractor.Extractor{s.extractor1}, s.instr)
+
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Stat: t.Stat{
+			Type: t.DirectoryType,
+			Size: 23,
+		},
+	}
+
+	entries := s.makeDirEntries(r.Resource, 8)
+
+	s.cpIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			cp := args.Get(2).(*indexTypes.Checkpoint)
+			cp.Count = 3
+			cp.Name = "otherName.pdf"
+			cp.Time = time.Now()
+		}).
+		Return(true, nil).
+		Once()
+
+	s.protocol.
+		On("Ls", mock.Anything, r, mock.AnythingOfType("chan<- *types.AnnotatedResource")).
+		Run(func(args mock.Arguments) {
+			entryChan := args.Get(2).(chan<- *t.AnnotatedResource)
+			for _, e := range entries {
+				select {
+				case entryChan <- e:
+				case <-args.Get(0).(context.Context).Done():
+					return
+				}
+			}
+		}).
+		Return(nil).
+		Once()
+
+	// Checkpoint is discarded, to restart on the next attempt.
+	s.cpIdx.
+		On("Delete", mock.Anything, r.Resource.ID).
+		Return(nil).
+		Once()
+
+	s.assertNotExists(r.Resource.ID)
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.ErrorIs(err, errCheckpointMismatch)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlResumeDirectoryShrunk() {
+	s.cfg = DefaultConfig()
+
+	// Override MaxDirSize
+	s.cfg.MaxDirSize = 3
+
+	s.c = New(s.cfg, s.indexes, s.queues, s.protocol, []extractor.Extractor{s.extractor1}, s.instr)
+
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Stat: t.Stat{
+			Type: t.DirectoryType,
+			Size: 23,
+		},
+	}
+
+	// Listing has fewer entries than processed before.
+	entries := s.makeDirEntries(r.Resource, 4)
+
+	s.cpIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			cp := args.Get(2).(*indexTypes.Checkpoint)
+			cp.Count = 6
+			cp.Name = "file5.pdf"
+			cp.Time = time.Now()
+		}).
+		Return(true, nil).
+		Once()
+
+	s.protocol.
+		On("Ls", mock.Anything, r, mock.AnythingOfType("chan<- *types.AnnotatedResource")).
+		Run(func(args mock.Arguments) {
+			entryChan := args.Get(2).(chan<- *t.AnnotatedResource)
+			for _, e := range entries {
+				entryChan <- e
+			}
+		}).
+		Return(nil).
+		Once()
+
+	// Checkpoint is discarded, to restart on the next attempt.
+	s.cpIdx.
+		On("Delete", mock.Anything, r.Resource.ID).
+		Return(nil).
+		Once()
+
+	s.assertNotExists(r.Resource.ID)
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.ErrorIs(err, errCheckpointMismatch)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlResumeDirectoryExpired() {
+	s.cfg = DefaultConfig()
+
+	// Override MaxDirSize
+	s.cfg.MaxDirSize = 3
+
+	s.c = New(s.cfg, s.indexes, s.queues, s.protocol, []extractor.Extractor{s.extractor1}, s.instr)
+
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Stat: t.Stat{
+			Type: t.DirectoryType,
+			Size: 23,
+		},
+	}
+
+	entries := s.makeDirEntries(r.Resource, 2)
+
+	// Checkpoint is older than CheckpointTTL.
+	s.cpIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			cp := args.Get(2).(*indexTypes.Checkpoint)
+			cp.Count = 6
+			cp.Name = "file5.pdf"
+			cp.Time = time.Now().Add(-s.cfg.CheckpointTTL - time.Minute)
+		}).
+		Return(true, nil).
+		Once()
+
+	s.cpIdx.
+		On("Delete", mock.Anything, r.Resource.ID).
+		Return(nil).
+		Once()
+
+	s.protocol.
+		On("Ls", mock.Anything, r, mock.AnythingOfType("chan<- *types.AnnotatedResource")).
+		Run(func(args mock.Arguments) {
+			entryChan := args.Get(2).(chan<- *t.AnnotatedResource)
+			for _, e := range entries {
+				entryChan <- e
+			}
+		}).
+		Return(nil).
+		Once()
+
+	// All entries are queued, as when starting from scratch.
+	s.fileQ.
+		On("Publish", mock.Anything, mock.AnythingOfType("*types.AnnotatedResource"), mock.AnythingOfType("uint8")).
+		Return(nil).
+		Times(2)
+
+	s.dirIdx.
+		On("Index", mock.Anything, r.Resource.ID, mock.MatchedBy(func(d *indexTypes.Directory) bool {
+			return reflect.DeepEqual([]string{"file0.pdf", "file1.pdf"}, linkNames(d.Links))
+		})).
+		Return(nil).
+		Once()
+
+	s.assertNotExists(r.Resource.ID)
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlDirEntryTimeout() {
+	s.cfg = DefaultConfig()
+
+	// Override dir entry timeout
+	s.cfg.DirEntryTimeout = 5 * time.Millisecond
+
+	s.c = New(s.cfg, s.indexes, s.queues, s.protocol, []extractor.Extractor{s.extractor1}, s.instr)
+
+	entryDelay := 2 * s.cfg.DirEntryTimeout
+
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Stat: t.Stat{
+			Type: t.DirectoryType,
+		},
+	}
+
+	fileEntry := t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmafrLBfzRLV4XSH1XcaMMeaXEUhDJjmtDfsYU95TrWG87",
+		},
+		Reference: t.Reference{
+			Parent: &t.Resource{
+				Protocol: t.IPFSProtocol,
+				ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+			},
+			Name: "fileName.pdf",
+		},
+		Stat: t.Stat{
+			Type: t.FileType,
+			Size: 3431,
+		},
+	}
+
+	dirEntry := t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmS4ustL54uo8FzR9455qaxZwuMiUhyvMcX9Ba8nUH4uVv",
+		},
+		Reference: t.Reference{
+			Parent: &t.Resource{
+				Protocol: t.IPFSProtocol,
+				ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+			},
+			Name: "dirName",
+		},
+		Stat: t.Stat{
+			Type: t.DirectoryType,
+			Size: 4534543,
+		},
+	}
+
+	s.fileQ.
+		On("Publish", mock.Anything, mock.MatchedBy(func(f *t.AnnotatedResource) bool {
+			return s.Equal(*f, fileEntry)
+		}), mock.AnythingOfType("uint8")).
 		Return(nil).
 		Once()
 
@@ -981,6 +1611,7 @@ func (s *CrawlerTestSuite) TestCrawlDirEntryTimeout() {
 		Return(nil).
 		Once()
 
+	s.assertNoCheckpoint(r.Resource.ID)
 	s.assertNotExists(r.Resource.ID)
 
 	// Crawl
@@ -1001,7 +1632,7 @@ func (s *CrawlerTestSuite) TestCrawlUpdateLastSeen() {
 
 	// File is found, last seen 1 hour
 	s.fileIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Run(func(args mock.Arguments) {
 			u := args.Get(2).(*indexTypes.Update)
 			lastSeen := time.Now().Add(-2 * time.Hour)
@@ -1011,24 +1642,199 @@ func (s *CrawlerTestSuite) TestCrawlUpdateLastSeen() {
 		Once()
 
 	s.dirIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.invalidIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.partialIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Return(false, nil).
+		Maybe()
+
+	s.dagIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.fileIdx.
-		On("Update", mock.Anything, r.Resource.ID, mock.MatchedBy(func(u *indexTypes.Update) bool {
+		On("Update", mock.Anything, r.Resource.ID, mock.MatchedBy(func(u *indexTypes.Seen) bool {
 			return s.Empty(u.References) &&
-				s.WithinDuration(*u.LastSeen, time.Now(), time.Second)
+				s.WithinDuration(*u.LastSeen, time.Now(), time.Second) &&
+				!u.Unreachable
+		})).
+		Return(nil).
+		Once()
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+}
+
+// expectExistingProviders sets up a file which was recently seen, provided by the given providers.
+func (s *CrawlerTestSuite) expectExistingProviders(rID string, providers indexTypes.Providers) {
+	s.fileIdx.
+		On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Run(func(args mock.Arguments) {
+			u := args.Get(2).(*indexTypes.Update)
+			lastSeen := time.Now()
+			u.LastSeen = &lastSeen
+			u.Providers = providers
+		}).
+		Return(true, nil).
+		Once()
+
+	for _, idx := range []*index.Mock{s.dirIdx, s.invalidIdx, s.partialIdx, s.dagIdx} {
+		idx.
+			On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen", "providers"}).
+			Return(false, nil).
+			Maybe()
+	}
+}
+
+func (s *CrawlerTestSuite) TestCrawlAddProvider() {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Source: t.SnifferSource,
+		Provider: &t.ProviderRecord{
+			PeerID: "QmeTtFXm42Jb2todcKR538j6qHYxXt6suUzpF3rtT9FPSd",
+			Date:   now,
+		},
+	}
+
+	existing := indexTypes.Providers{
+		{PeerID: "QmafrLBfzRLV4XSH1XcaMMeaXEUhDJjmtDfsYU95TrWG87", LastSeen: now},
+	}
+
+	s.expectExistingProviders(r.Resource.ID, existing)
+
+	expected := indexTypes.Providers{
+		existing[0],
+		{PeerID: r.Provider.PeerID, LastSeen: now},
+	}
+
+	s.fileIdx.
+		On("Update", mock.Anything, r.Resource.ID, mock.MatchedBy(func(u *indexTypes.Seen) bool {
+			return u.LastSeen != nil && reflect.DeepEqual(u.Providers, expected)
+		})).
+		Return(nil).
+		Once()
+
+	err := s.c.Crawl(s.ctx, r)
+
+	s.NoError(err)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlKnownProvider() {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Source: t.SnifferSource,
+		Provider: &t.ProviderRecord{
+			PeerID: "QmeTtFXm42Jb2todcKR538j6qHYxXt6suUzpF3rtT9FPSd",
+			Date:   now,
+		},
+	}
+
+	// Provider recently seen, item recently seen; nothing to update.
+	s.expectExistingProviders(r.Resource.ID, indexTypes.Providers{
+		{PeerID: r.Provider.PeerID, LastSeen: now.Add(-time.Minute)},
+	})
+
+	err := s.c.Crawl(s.ctx, r)
+
+	s.NoError(err)
+	s.fileIdx.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything, mock.Anything)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestAddProviderEvictsOldest() {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	providers := indexTypes.Providers{
+		{PeerID: "a", LastSeen: now.Add(-time.Hour)},
+		{PeerID: "b", LastSeen: now.Add(-3 * time.Hour)},
+		{PeerID: "c", LastSeen: now.Add(-2 * time.Hour)},
+	}
+
+	updated, ok := addProvider(providers, &t.ProviderRecord{PeerID: "d", Date: now}, 3, time.Hour)
+
+	s.True(ok)
+	s.Equal(indexTypes.Providers{
+		{PeerID: "a", LastSeen: now.Add(-time.Hour)},
+		{PeerID: "c", LastSeen: now.Add(-2 * time.Hour)},
+		{PeerID: "d", LastSeen: now},
+	}, updated)
+
+	// Refreshing a known, stale, provider does not modify the original.
+	updated, ok = addProvider(providers, &t.ProviderRecord{PeerID: "b", Date: now}, 3, time.Hour)
+
+	s.True(ok)
+	s.Equal(now, updated[1].LastSeen)
+	s.Equal(now.Add(-3*time.Hour), providers[1].LastSeen)
+}
+
+func (s *CrawlerTestSuite) assertExistingFile(rID string) {
+	s.fileIdx.
+		On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Run(func(args mock.Arguments) {
+			u := args.Get(2).(*indexTypes.Update)
+			lastSeen := time.Now().Add(-60 * 24 * time.Hour)
+			u.LastSeen = &lastSeen
+		}).
+		Return(true, nil).
+		Once()
+
+	for _, idx := range []*index.Mock{s.dirIdx, s.invalidIdx, s.partialIdx, s.dagIdx} {
+		idx.
+			On("Get", mock.Anything, rID, mock.Anything, []string{"references", "last-seen", "providers"}).
+			Return(false, nil).
+			Maybe()
+	}
+}
+
+func (s *CrawlerTestSuite) TestCrawlRecrawlAvailable() {
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Source: t.RecrawlSource,
+		Stat: t.Stat{
+			Type: t.FileType,
+		},
+	}
+
+	s.assertExistingFile(r.Resource.ID)
+
+	s.protocol.
+		On("Stat", mock.Anything, r).
+		Return(nil).
+		Once()
+
+	s.fileIdx.
+		On("Update", mock.Anything, r.Resource.ID, mock.MatchedBy(func(u *indexTypes.Recheck) bool {
+			return !u.Unreachable &&
+				u.LastSeen != nil && u.LastSeen.Equal(u.LastChecked) &&
+				time.Since(u.LastChecked) < time.Second
 		})).
 		Return(nil).
 		Once()
@@ -1041,6 +1847,72 @@ func (s *CrawlerTestSuite) TestCrawlUpdateLastSeen() {
 	s.assertExpectations()
 }
 
+func (s *CrawlerTestSuite) TestCrawlRecrawlUnreachable() {
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Source: t.RecrawlSource,
+		Stat: t.Stat{
+			Type: t.FileType,
+		},
+	}
+
+	s.assertExistingFile(r.Resource.ID)
+
+	s.protocol.
+		On("Stat", mock.Anything, r).
+		Return(context.DeadlineExceeded).
+		Once()
+
+	s.fileIdx.
+		On("Update", mock.Anything, r.Resource.ID, mock.MatchedBy(func(u *indexTypes.Recheck) bool {
+			return u.Unreachable && u.LastSeen == nil &&
+				time.Since(u.LastChecked) < time.Second
+		})).
+		Return(nil).
+		Once()
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlRecrawlError() {
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Source: t.RecrawlSource,
+		Stat: t.Stat{
+			Type: t.FileType,
+		},
+	}
+
+	s.assertExistingFile(r.Resource.ID)
+
+	statErr := errors.New("connection refused")
+
+	s.protocol.
+		On("Stat", mock.Anything, r).
+		Return(statErr).
+		Once()
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.ErrorIs(err, statErr)
+	s.assertExpectations()
+}
+
 func (s *CrawlerTestSuite) TestCrawlNotUpdateInvalid() {
 	// Prepare resource
 	r := &t.AnnotatedResource{
@@ -1052,22 +1924,27 @@ func (s *CrawlerTestSuite) TestCrawlNotUpdateInvalid() {
 
 	// File is found, last seen 1 hour
 	s.fileIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Once()
 
 	s.dirIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.partialIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Return(false, nil).
+		Maybe()
+
+	s.dagIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.invalidIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(true, nil).
 		Maybe()
 
@@ -1098,7 +1975,7 @@ func (s *CrawlerTestSuite) TestCrawlAddReference() {
 
 	// File is found, very recently, but a new reference is found.
 	s.fileIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Run(func(args mock.Arguments) {
 			u := args.Get(2).(*indexTypes.Update)
 			lastSeen := time.Now()
@@ -1114,17 +1991,22 @@ func (s *CrawlerTestSuite) TestCrawlAddReference() {
 		Once()
 
 	s.dirIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.invalidIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.partialIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Return(false, nil).
+		Maybe()
+
+	s.dagIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
@@ -1172,22 +2054,27 @@ func (s *CrawlerTestSuite) TestCrawlUpdateGetError() {
 	testErr := errors.New("test")
 
 	s.fileIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, testErr).
 		Maybe()
 
 	s.dirIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.partialIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Return(false, nil).
+		Maybe()
+
+	s.dagIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.invalidIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
@@ -1218,7 +2105,7 @@ func (s *CrawlerTestSuite) TestCrawlUpdateUpdateError() {
 
 	// File is found, very recently, but a new reference is found.
 	s.fileIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Run(func(args mock.Arguments) {
 			u := args.Get(2).(*indexTypes.Update)
 			lastSeen := time.Now()
@@ -1236,17 +2123,22 @@ func (s *CrawlerTestSuite) TestCrawlUpdateUpdateError() {
 	testErr := errors.New("test")
 
 	s.dirIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.invalidIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.partialIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Return(false, nil).
+		Maybe()
+
+	s.dagIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
@@ -1293,7 +2185,7 @@ func (s *CrawlerTestSuite) TestCrawlSameReference() {
 
 	// File is found, very recently, but a new reference is found.
 	s.fileIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Run(func(args mock.Arguments) {
 			u := args.Get(2).(*indexTypes.Update)
 			lastSeen := time.Now()
@@ -1309,17 +2201,22 @@ func (s *CrawlerTestSuite) TestCrawlSameReference() {
 		Once()
 
 	s.dirIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.partialIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Return(false, nil).
+		Maybe()
+
+	s.dagIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.invalidIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
@@ -1350,7 +2247,7 @@ func (s *CrawlerTestSuite) TestCrawlSameReferenceDifferentName() {
 
 	// File is found, very recently, but a new reference is found.
 	s.fileIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Run(func(args mock.Arguments) {
 			u := args.Get(2).(*indexTypes.Update)
 			lastSeen := time.Now()
@@ -1366,17 +2263,22 @@ func (s *CrawlerTestSuite) TestCrawlSameReferenceDifferentName() {
 		Once()
 
 	s.dirIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.partialIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
+		Return(false, nil).
+		Maybe()
+
+	s.dagIdx.
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
 	s.invalidIdx.
-		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen"}).
+		On("Get", mock.Anything, r.Resource.ID, mock.Anything, []string{"references", "last-seen", "providers"}).
 		Return(false, nil).
 		Maybe()
 
@@ -1405,6 +2307,415 @@ func (s *CrawlerTestSuite) TestCrawlSameReferenceDifferentName() {
 	s.assertExpectations()
 }
 
+func (s *CrawlerTestSuite) TestCrawlDagType() {
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "bafyreifuu2zzh3vxdpgi5qlsewfkktkvz46cm5h6sxrlpmi7tvfrzf5y6e",
+		},
+	}
+
+	linkID := "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp"
+
+	node := map[string]interface{}{
+		"title": "Hello",
+		"count": float64(3),
+		"tags":  []interface{}{"a", "b"},
+		"nested": map[string]interface{}{
+			"link":  map[string]interface{}{"/": linkID},
+			"empty": nil,
+		},
+	}
+
+	s.assertNotExists(r.Resource.ID)
+
+	s.protocol.
+		On("Stat", mock.Anything, r).
+		Run(func(args mock.Arguments) {
+			r := args.Get(1).(*t.AnnotatedResource)
+			r.Stat = t.Stat{
+				Type: t.DagType,
+				Size: 128,
+			}
+		}).
+		Return(nil).
+		Once()
+
+	s.protocol.
+		On("GetDag", mock.Anything, r).
+		Return(node, nil).
+		Once()
+
+	s.hashQ.
+		On("Publish", mock.Anything, &t.AnnotatedResource{
+			Resource: &t.Resource{
+				Protocol: t.IPFSProtocol,
+				ID:       linkID,
+			},
+			Source: t.DagSource,
+			Reference: t.Reference{
+				Parent: r.Resource,
+				Name:   "nested/link",
+			},
+		}, mock.Anything).
+		Return(nil).
+		Once()
+
+	s.dagIdx.
+		On("Index", mock.Anything, r.Resource.ID, mock.MatchedBy(func(d *indexTypes.Dag) bool {
+			return s.Equal([]indexTypes.DagField{
+				{Path: "count", Value: "3"},
+				{Path: "tags/0", Value: "a"},
+				{Path: "tags/1", Value: "b"},
+				{Path: "title", Value: "Hello"},
+			}, d.Fields) &&
+				s.Equal(indexTypes.Links{
+					{Hash: linkID, Name: "nested/link", Type: indexTypes.UnknownLinkType},
+				}, d.Links) &&
+				s.Equal(uint64(128), d.Size)
+		})).
+		Return(nil).
+		Once()
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlLargeDag() {
+	s.cfg = DefaultConfig()
+	s.cfg.MaxDagFields = 2
+	s.c = New(s.cfg, s.indexes, s.queues, s.protocol, []extractor.Extractor{s.extractor1}, s.instr)
+
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "bafyreifuu2zzh3vxdpgi5qlsewfkktkvz46cm5h6sxrlpmi7tvfrzf5y6e",
+		},
+	}
+
+	linkIDs := []string{
+		"QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		"QmcBLKyRHjbGeLnjnmj74FFJpGJDz4YxFqUDYqMU7Mny1p",
+		"QmUNLLsPACCz1vLxQVkXqqLX5R1X345qqfHbsf67hvA3Nn",
+	}
+
+	links := make([]interface{}, len(linkIDs))
+	for i, id := range linkIDs {
+		links[i] = map[string]interface{}{"/": id}
+	}
+
+	node := map[string]interface{}{
+		"links": links,
+	}
+
+	s.assertNotExists(r.Resource.ID)
+
+	s.protocol.
+		On("Stat", mock.Anything, r).
+		Run(func(args mock.Arguments) {
+			r := args.Get(1).(*t.AnnotatedResource)
+			r.Stat = t.Stat{
+				Type: t.DagType,
+				Size: 128,
+			}
+		}).
+		Return(nil).
+		Once()
+
+	s.protocol.
+		On("GetDag", mock.Anything, r).
+		Return(node, nil).
+		Once()
+
+	// Only indexed links are queued.
+	for i, id := range linkIDs[:2] {
+		s.hashQ.
+			On("Publish", mock.Anything, &t.AnnotatedResource{
+				Resource: &t.Resource{
+					Protocol: t.IPFSProtocol,
+					ID:       id,
+				},
+				Source: t.DagSource,
+				Reference: t.Reference{
+					Parent: r.Resource,
+					Name:   fmt.Sprintf("links/%d", i),
+				},
+			}, mock.Anything).
+			Return(nil).
+			Once()
+	}
+
+	s.dagIdx.
+		On("Index", mock.Anything, r.Resource.ID, mock.MatchedBy(func(d *indexTypes.Dag) bool {
+			return s.Len(d.Links, 2)
+		})).
+		Return(nil).
+		Once()
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+	s.hashQ.AssertNumberOfCalls(s.T(), "Publish", 2)
+}
+
+func (s *CrawlerTestSuite) TestCrawlNewName() {
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPNSProtocol,
+			ID:       "docs.ipfs.tech",
+		},
+	}
+
+	target := &t.Resource{
+		Protocol: t.IPFSProtocol,
+		ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+	}
+
+	s.protocol.
+		On("Resolve", mock.Anything, r).
+		Return(target, nil).
+		Once()
+
+	s.nameIdx.
+		On("Get", mock.Anything, r.ID, mock.Anything, []string{"target", "history"}).
+		Return(false, nil).
+		Once()
+
+	s.nameIdx.
+		On("Index", mock.Anything, r.ID, mock.MatchedBy(func(n *indexTypes.Name) bool {
+			return s.Equal(r.ID, n.Name) &&
+				s.Equal(target.ID, n.Target) &&
+				s.Len(n.History, 1) &&
+				s.Equal(target.ID, n.History[0].Target) &&
+				s.WithinDuration(n.FirstSeen, time.Now(), time.Second)
+		})).
+		Return(nil).
+		Once()
+
+	s.hashQ.
+		On("Publish", mock.Anything, &t.AnnotatedResource{
+			Resource: target,
+			Source:   t.NameSource,
+		}, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlChangedName() {
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPNSProtocol,
+			ID:       "k51qzi5uqu5dlvj2baxnqndepeb86cbk3ng7n3i46uzyxzyqj2xjonzllnv0v8",
+		},
+	}
+
+	oldTarget := "QmcBLKyRHjbGeLnjnmj74FFJpGJDz4YxFqUDYqMU7Mny1p"
+	target := &t.Resource{
+		Protocol: t.IPFSProtocol,
+		ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+	}
+
+	s.protocol.
+		On("Resolve", mock.Anything, r).
+		Return(target, nil).
+		Once()
+
+	s.nameIdx.
+		On("Get", mock.Anything, r.ID, mock.Anything, []string{"target", "history"}).
+		Run(func(args mock.Arguments) {
+			n := args.Get(2).(*indexTypes.Name)
+			n.Target = oldTarget
+			n.History = []indexTypes.Resolution{
+				{Target: oldTarget, Time: time.Now().Add(-24 * time.Hour)},
+			}
+		}).
+		Return(true, nil).
+		Once()
+
+	s.nameIdx.
+		On("Update", mock.Anything, r.ID, mock.MatchedBy(func(u *indexTypes.NameUpdate) bool {
+			return s.Equal(target.ID, u.Target) &&
+				s.Len(u.History, 2) &&
+				s.Equal(oldTarget, u.History[0].Target) &&
+				s.Equal(target.ID, u.History[1].Target) &&
+				s.WithinDuration(*u.LastSeen, time.Now(), time.Second)
+		})).
+		Return(nil).
+		Once()
+
+	s.hashQ.
+		On("Publish", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlChangedNameHistoryLimit() {
+	s.cfg = DefaultConfig()
+	s.cfg.MaxNameHistory = 2
+	s.c = New(s.cfg, s.indexes, s.queues, s.protocol, []extractor.Extractor{s.extractor1}, s.instr)
+
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPNSProtocol,
+			ID:       "k51qzi5uqu5dlvj2baxnqndepeb86cbk3ng7n3i46uzyxzyqj2xjonzllnv0v8",
+		},
+	}
+
+	oldestTarget := "QmUNLLsPACCz1vLxQVkXqqLX5R1X345qqfHbsf67hvA3Nn"
+	oldTarget := "QmcBLKyRHjbGeLnjnmj74FFJpGJDz4YxFqUDYqMU7Mny1p"
+	target := &t.Resource{
+		Protocol: t.IPFSProtocol,
+		ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+	}
+
+	s.protocol.
+		On("Resolve", mock.Anything, r).
+		Return(target, nil).
+		Once()
+
+	s.nameIdx.
+		On("Get", mock.Anything, r.ID, mock.Anything, []string{"target", "history"}).
+		Run(func(args mock.Arguments) {
+			n := args.Get(2).(*indexTypes.Name)
+			n.Target = oldTarget
+			n.History = []indexTypes.Resolution{
+				{Target: oldestTarget, Time: time.Now().Add(-48 * time.Hour)},
+				{Target: oldTarget, Time: time.Now().Add(-24 * time.Hour)},
+			}
+		}).
+		Return(true, nil).
+		Once()
+
+	// The oldest resolution is dropped.
+	s.nameIdx.
+		On("Update", mock.Anything, r.ID, mock.MatchedBy(func(u *indexTypes.NameUpdate) bool {
+			return s.Len(u.History, 2) &&
+				s.Equal(oldTarget, u.History[0].Target) &&
+				s.Equal(target.ID, u.History[1].Target)
+		})).
+		Return(nil).
+		Once()
+
+	s.hashQ.
+		On("Publish", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlUnchangedName() {
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPNSProtocol,
+			ID:       "docs.ipfs.tech",
+		},
+	}
+
+	target := &t.Resource{
+		Protocol: t.IPFSProtocol,
+		ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+	}
+
+	s.protocol.
+		On("Resolve", mock.Anything, r).
+		Return(target, nil).
+		Once()
+
+	s.nameIdx.
+		On("Get", mock.Anything, r.ID, mock.Anything, []string{"target", "history"}).
+		Run(func(args mock.Arguments) {
+			n := args.Get(2).(*indexTypes.Name)
+			n.Target = target.ID
+		}).
+		Return(true, nil).
+		Once()
+
+	s.nameIdx.
+		On("Update", mock.Anything, r.ID, mock.MatchedBy(func(u *indexTypes.NameUpdate) bool {
+			return s.Empty(u.Target) &&
+				s.Empty(u.History) &&
+				s.NotNil(u.LastSeen)
+		})).
+		Return(nil).
+		Once()
+
+	s.hashQ.
+		On("Publish", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+}
+
+func (s *CrawlerTestSuite) TestCrawlInvalidName() {
+	// Prepare resource
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPNSProtocol,
+			ID:       "invalid.example.com",
+		},
+	}
+
+	resolveErr := fmt.Errorf("%w: not a CID", t.ErrInvalidResource)
+
+	s.protocol.
+		On("Resolve", mock.Anything, r).
+		Return(nil, resolveErr).
+		Once()
+
+	s.invalidIdx.
+		On("Index", mock.Anything, r.ID, &indexTypes.Invalid{
+			Error: resolveErr.Error(),
+		}).
+		Return(nil).
+		Once()
+
+	// Crawl
+	err := s.c.Crawl(s.ctx, r)
+
+	// Test result, side effects
+	s.NoError(err)
+	s.assertExpectations()
+}
+
 func TestCrawlerTestSuite(t *testing.T) {
 	suite.Run(t, new(CrawlerTestSuite))
 }
@@ -0,0 +1,113 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// crawlName resolves a mutable name, indexes its (historic) targets and queues the current target, returning the outcome.
+func (c *Crawler) crawlName(ctx context.Context, r *t.AnnotatedResource) (string, error) {
+	ctx, span := c.Tracer.Start(ctx, "crawler.crawlName")
+	defer span.End()
+
+	target, err := c.resolve(ctx, r)
+	if err != nil {
+		if errors.Is(err, t.ErrInvalidResource) {
+			c.log.InfoCtx(ctx, "Indexing invalid name", "cid", r.ID, "err", err)
+			span.RecordError(err)
+
+			return outcomeInvalid, c.indexInvalid(ctx, r, err)
+		}
+
+		return "", err
+	}
+
+	span.AddEvent("resolved", trace.WithAttributes(attribute.String("target", target.ID)))
+
+	outcome, err := c.indexName(ctx, r, target)
+	if err != nil {
+		return "", err
+	}
+
+	return outcome, c.queueTarget(ctx, target)
+}
+
+func (c *Crawler) resolve(ctx context.Context, r *t.AnnotatedResource) (*t.Resource, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Load().ResolveTimeout)
+	defer cancel()
+
+	return c.protocol.Resolve(ctx, r)
+}
+
+// indexName indexes a new name or updates last-seen for an existing name, appending to its history
+// when its target has changed, returning the outcome.
+func (c *Crawler) indexName(ctx context.Context, r *t.AnnotatedResource, target *t.Resource) (string, error) {
+	now := time.Now().UTC()
+
+	// Strip milliseconds to cater to legacy ES index format.
+	now = now.Truncate(time.Second)
+
+	existing := new(indexTypes.Name)
+
+	found, err := c.indexes.Names.Get(ctx, r.ID, existing, "target", "history")
+	if err != nil {
+		return "", err
+	}
+
+	resolution := indexTypes.Resolution{
+		Target: target.ID,
+		Time:   now,
+	}
+
+	if !found {
+		c.log.InfoCtx(ctx, "Indexing new name", "cid", r.ID, "target", target.ID)
+
+		return outcomeNew, c.indexes.Names.Index(ctx, r.ID, &indexTypes.Name{
+			Name:      r.ID,
+			FirstSeen: now,
+			LastSeen:  now,
+			Target:    target.ID,
+			History:   []indexTypes.Resolution{resolution},
+		})
+	}
+
+	update := &indexTypes.NameUpdate{
+		LastSeen: &now,
+	}
+
+	if existing.Target != target.ID {
+		c.log.InfoCtx(ctx, "Name changed target", "cid", r.ID, "from", existing.Target, "to", target.ID)
+
+		update.Target = target.ID
+		update.History = truncateHistory(append(existing.History, resolution), c.config.Load().MaxNameHistory)
+	}
+
+	return outcomeExisting, c.indexes.Names.Update(ctx, r.ID, update)
+}
+
+// truncateHistory returns at most the last max resolutions of history.
+func truncateHistory(history []indexTypes.Resolution, max uint) []indexTypes.Resolution {
+	if uint(len(history)) > max {
+		return history[uint(len(history))-max:]
+	}
+
+	return history
+}
+
+// queueTarget queues the target of a name for crawling.
+func (c *Crawler) queueTarget(ctx context.Context, target *t.Resource) error {
+	r := &t.AnnotatedResource{
+		Resource: target,
+		Source:   t.NameSource,
+	}
+
+	// Names are explicitly published and likely to be available; queue with high priority.
+	return c.queues.Hashes.Publish(ctx, r, 8)
+}
@@ -15,11 +15,11 @@ type existingItem struct {
 }
 
 func (c *Crawler) getExistingItem(ctx context.Context, r *t.AnnotatedResource) (*existingItem, error) {
-	indexes := []index.Index{c.indexes.Files, c.indexes.Directories, c.indexes.Invalids, c.indexes.Partials}
+	indexes := []index.Index{c.indexes.Files, c.indexes.Directories, c.indexes.Invalids, c.indexes.Partials, c.indexes.Dags}
 
 	update := &index_types.Update{}
 
-	index, err := index.MultiGet(ctx, indexes, r.ID, update, "references", "last-seen")
+	index, err := index.MultiGet(ctx, indexes, r.ID, update, "references", "last-seen", "providers")
 	if err != nil {
 		return nil, err
 	}
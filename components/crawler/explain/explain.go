@@ -0,0 +1,101 @@
+// Package explain wraps the components of a Crawler to write a trace of its decisions, optionally preventing any
+// writes to indexes and queues (dry run).
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// maxValueLength is the maximum number of bytes shown for values in the trace.
+const maxValueLength = 1024
+
+// Explainer writes a trace of the operations of the crawler components it wraps.
+type Explainer struct {
+	w           io.Writer
+	dryRun      bool
+	partialSize uint64
+
+	mu        sync.Mutex
+	writes    int
+	publishes int
+}
+
+// New returns an Explainer writing to w. When dryRun is true, index writes and queue publishes are only
+// traced; otherwise they're passed on to the wrapped components. partialSize is the size of resources
+// which are considered partials.
+func New(w io.Writer, dryRun bool, partialSize uint64) *Explainer {
+	return &Explainer{
+		w:           w,
+		dryRun:      dryRun,
+		partialSize: partialSize,
+	}
+}
+
+// printf writes a line to the trace; it is safe for concurrent use.
+func (e *Explainer) printf(format string, v ...interface{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	fmt.Fprintf(e.w, format+"\n", v...)
+}
+
+// would prefixes a description of a write with "would" during dry runs.
+func (e *Explainer) would(action string) string {
+	if e.dryRun {
+		return "would " + action
+	}
+
+	return action
+}
+
+func (e *Explainer) countWrite() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.writes++
+}
+
+func (e *Explainer) countPublish() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.publishes++
+}
+
+// Summary returns a summary of the index writes and queue publishes traced.
+func (e *Explainer) Summary() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.dryRun {
+		return fmt.Sprintf("Would have made %d index writes and published %d messages.", e.writes, e.publishes)
+	}
+
+	return fmt.Sprintf("Made %d index writes and published %d messages.", e.writes, e.publishes)
+}
+
+// value returns a JSON representation of v, truncated to maxValueLength.
+func value(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+
+	if len(b) > maxValueLength {
+		return fmt.Sprintf("%s... (%d bytes)", b[:maxValueLength], len(b))
+	}
+
+	return string(b)
+}
+
+// result returns a description of an error, or "ok" for nil errors.
+func result(err error) string {
+	if err != nil {
+		return "error: " + err.Error()
+	}
+
+	return "ok"
+}
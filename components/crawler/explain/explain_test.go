@@ -0,0 +1,201 @@
+package explain
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/components/crawler"
+	"github.com/ipfs-search/ipfs-search/components/extractor"
+	"github.com/ipfs-search/ipfs-search/components/index"
+	"github.com/ipfs-search/ipfs-search/components/index/cache"
+	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+	"github.com/ipfs-search/ipfs-search/components/protocol"
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+const testCID = "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp"
+
+var existingFields = []string{"references", "last-seen", "providers"}
+
+type ExplainTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+	out *bytes.Buffer
+
+	files, dirs, invalids, partials, dags *index.Mock
+
+	protocol  *protocol.Mock
+	extractor *extractor.Mock
+	hashes    *queue.Mock
+}
+
+func (s *ExplainTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.out = &bytes.Buffer{}
+
+	s.files, s.dirs, s.invalids, s.partials, s.dags = &index.Mock{}, &index.Mock{}, &index.Mock{}, &index.Mock{}, &index.Mock{}
+	s.protocol = &protocol.Mock{}
+	s.extractor = &extractor.Mock{}
+	s.hashes = &queue.Mock{}
+
+	for _, m := range []*mock.Mock{
+		&s.files.Mock, &s.dirs.Mock, &s.invalids.Mock, &s.partials.Mock, &s.dags.Mock,
+		&s.protocol.Mock, &s.extractor.Mock, &s.hashes.Mock,
+	} {
+		m.Test(s.T())
+	}
+}
+
+func (s *ExplainTestSuite) TearDownTest() {
+	mock.AssertExpectationsForObjects(s.T(),
+		s.files, s.dirs, s.invalids, s.partials, s.dags,
+		s.protocol, s.extractor, s.hashes,
+	)
+}
+
+func (s *ExplainTestSuite) crawler(e *Explainer) *crawler.Crawler {
+	indexes := &crawler.Indexes{
+		Files:       s.files,
+		Directories: s.dirs,
+		Invalids:    s.invalids,
+		Partials:    s.partials,
+		Dags:        s.dags,
+	}
+
+	queues := &crawler.Queues{Hashes: s.hashes}
+
+	return crawler.New(
+		crawler.DefaultConfig(),
+		e.Indexes(indexes),
+		e.Queues(queues),
+		e.Protocol(s.protocol),
+		e.Extractors([]extractor.Extractor{s.extractor}),
+		instr.New(),
+	)
+}
+
+func (s *ExplainTestSuite) expectNotExists() {
+	for _, m := range []*index.Mock{s.files, s.dirs, s.invalids, s.partials, s.dags} {
+		m.On("Get", mock.Anything, testCID, mock.Anything, existingFields).Return(false, nil).Once()
+	}
+}
+
+func (s *ExplainTestSuite) resource() *t.AnnotatedResource {
+	return &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       testCID,
+		},
+	}
+}
+
+func (s *ExplainTestSuite) TestDryRunFile() {
+	r := s.resource()
+
+	s.expectNotExists()
+
+	s.protocol.On("Stat", mock.Anything, r).Run(func(args mock.Arguments) {
+		r := args.Get(1).(*t.AnnotatedResource)
+		r.Type = t.FileType
+		r.Size = 15
+	}).Return(nil).Once()
+
+	s.extractor.On("Extract", mock.Anything, r, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(2).(*indexTypes.File).Content = "testContent"
+	}).Return(nil).Once()
+
+	// No Index calls are expected on the files index.
+	err := s.crawler(New(s.out, true, 262144)).Crawl(s.ctx, r)
+	s.NoError(err)
+
+	s.Contains(s.out.String(), "get files "+testCID+" references,last-seen,providers: not found")
+	s.Contains(s.out.String(), "size 15, type file")
+	s.Contains(s.out.String(), `"content":"testContent"`)
+	s.Contains(s.out.String(), "would index files "+testCID)
+}
+
+func (s *ExplainTestSuite) TestPartial() {
+	r := s.resource()
+
+	s.expectNotExists()
+
+	s.protocol.On("Stat", mock.Anything, r).Run(func(args mock.Arguments) {
+		r := args.Get(1).(*t.AnnotatedResource)
+		r.Type = t.PartialType
+		r.Size = 262144
+	}).Return(nil).Once()
+
+	e := New(s.out, true, 262144)
+
+	err := s.crawler(e).Crawl(s.ctx, r)
+	s.NoError(err)
+
+	s.Contains(s.out.String(), "(partial size 262144): size 262144, partial")
+	s.Contains(s.out.String(), "would index partials "+testCID)
+	s.Equal("Would have made 1 index writes and published 0 messages.", e.Summary())
+}
+
+func (s *ExplainTestSuite) TestFileTooLarge() {
+	r := s.resource()
+
+	s.expectNotExists()
+
+	s.protocol.On("Stat", mock.Anything, r).Run(func(args mock.Arguments) {
+		args.Get(1).(*t.AnnotatedResource).Type = t.FileType
+	}).Return(nil).Once()
+
+	s.extractor.On("Extract", mock.Anything, r, mock.Anything).Return(extractor.ErrFileTooLarge).Once()
+
+	err := s.crawler(New(s.out, true, 262144)).Crawl(s.ctx, r)
+	s.NoError(err)
+
+	s.Contains(s.out.String(), extractor.ErrFileTooLarge.Error()+"; to be indexed as invalid")
+	s.Contains(s.out.String(), "would index invalids "+testCID)
+}
+
+func (s *ExplainTestSuite) TestPassOn() {
+	e := New(s.out, false, 262144)
+
+	s.files.On("Update", mock.Anything, testCID, mock.Anything).Return(nil).Once()
+	s.hashes.On("Publish", mock.Anything, mock.Anything, uint8(1)).Return(nil).Once()
+
+	s.NoError(e.Index("files", s.files).Update(s.ctx, testCID, nil))
+	s.NoError(e.Queues(&crawler.Queues{Hashes: s.hashes}).Hashes.Publish(s.ctx, nil, 1))
+
+	s.Contains(s.out.String(), "update files "+testCID+": null, ok")
+	s.Contains(s.out.String(), "publish to hashes with priority 1: null, ok")
+	s.Equal("Made 1 index writes and published 1 messages.", e.Summary())
+}
+
+func (s *ExplainTestSuite) TestDryRunCache() {
+	c := cache.New(s.files, s.dirs, indexTypes.Update{}, instr.New())
+	e := New(s.out, true, 262144)
+
+	// Caching a hit in the backing index is a write, which is prevented.
+	s.dirs.On("Get", mock.Anything, testCID, mock.Anything, mock.Anything).Return(false, nil).Once()
+	s.files.On("Get", mock.Anything, testCID, mock.Anything, mock.Anything).Return(true, nil).Once()
+
+	found, err := e.Index("files", c).Get(s.ctx, testCID, new(indexTypes.Update))
+	s.NoError(err)
+	s.True(found)
+
+	s.Contains(s.out.String(), "would index files cache "+testCID)
+}
+
+func (s *ExplainTestSuite) TestDryRunNilQueues() {
+	e := New(s.out, true, 262144)
+
+	s.NoError(e.Queues(nil).Files.Publish(s.ctx, nil, 9))
+	s.Contains(s.out.String(), "would publish to files with priority 9")
+}
+
+func TestExplainTestSuite(t *testing.T) {
+	suite.Run(t, new(ExplainTestSuite))
+}
@@ -0,0 +1,60 @@
+package explain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ipfs-search/ipfs-search/components/extractor"
+	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// explainedExtractor traces the output of an extractor.
+type explainedExtractor struct {
+	extractor extractor.Extractor
+	e         *Explainer
+	name      string
+}
+
+// Extract traces extracting metadata, writing the extracted properties of files.
+func (x *explainedExtractor) Extract(ctx context.Context, r *t.AnnotatedResource, metadata interface{}) error {
+	err := x.extractor.Extract(ctx, r, metadata)
+
+	switch {
+	case errors.Is(err, extractor.ErrFileTooLarge):
+		x.e.printf("extract %s with %s: %s; to be indexed as invalid", r, x.name, result(err))
+	case err != nil:
+		x.e.printf("extract %s with %s: %s", r, x.name, result(err))
+	default:
+		if f, ok := metadata.(*indexTypes.File); ok {
+			metadata = f.Extraction()
+		}
+
+		x.e.printf("extract %s with %s: %s", r, x.name, value(metadata))
+	}
+
+	return err
+}
+
+// Version returns the version of the wrapped extractor, retaining caching of its results.
+func (x *explainedExtractor) Version() string {
+	if v, ok := x.extractor.(extractor.Versioned); ok {
+		return v.Version()
+	}
+
+	return ""
+}
+
+// Extractors wraps extractors to trace their output; extraction is never prevented.
+func (e *Explainer) Extractors(extractors []extractor.Extractor) []extractor.Extractor {
+	wrapped := make([]extractor.Extractor, len(extractors))
+
+	for i, x := range extractors {
+		name := strings.TrimPrefix(fmt.Sprintf("%T", x), "*")
+		wrapped[i] = &explainedExtractor{x, e, name}
+	}
+
+	return wrapped
+}
@@ -0,0 +1,112 @@
+package explain
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ipfs-search/ipfs-search/components/crawler"
+	"github.com/ipfs-search/ipfs-search/components/index"
+	"github.com/ipfs-search/ipfs-search/components/index/cache"
+)
+
+// explainedIndex traces operations on an index, only passing on writes when not in a dry run.
+type explainedIndex struct {
+	index index.Index
+	e     *Explainer
+	name  string
+}
+
+// String returns the name of the index, for convenient logging.
+func (i *explainedIndex) String() string {
+	return i.name
+}
+
+func (i *explainedIndex) write(ctx context.Context, action, id string, properties interface{}, f func() error) error {
+	i.e.countWrite()
+
+	var err error
+	if !i.e.dryRun {
+		err = f()
+	}
+
+	i.e.printf("%s %s %s: %s, %s", i.e.would(action), i.name, id, value(properties), result(err))
+
+	return err
+}
+
+// Index traces indexing a document's properties, identified by id.
+func (i *explainedIndex) Index(ctx context.Context, id string, properties interface{}) error {
+	return i.write(ctx, "index", id, properties, func() error {
+		return i.index.Index(ctx, id, properties)
+	})
+}
+
+// Update traces updating a document's properties, given id.
+func (i *explainedIndex) Update(ctx context.Context, id string, properties interface{}) error {
+	return i.write(ctx, "update", id, properties, func() error {
+		return i.index.Update(ctx, id, properties)
+	})
+}
+
+// Delete traces deleting an item from the index.
+func (i *explainedIndex) Delete(ctx context.Context, id string) error {
+	return i.write(ctx, "delete", id, nil, func() error {
+		return i.index.Delete(ctx, id)
+	})
+}
+
+// Get traces getting `fields` from the document with `id`.
+func (i *explainedIndex) Get(ctx context.Context, id string, dst interface{}, fields ...string) (bool, error) {
+	found, err := i.index.Get(ctx, id, dst, fields...)
+
+	switch {
+	case ctx.Err() != nil:
+		// Canceled, e.g. by MultiGet when found in another index.
+		i.e.printf("get %s %s %s: canceled", i.name, id, strings.Join(fields, ","))
+	case err != nil:
+		i.e.printf("get %s %s %s: %s", i.name, id, strings.Join(fields, ","), result(err))
+	case found:
+		i.e.printf("get %s %s %s: found %s", i.name, id, strings.Join(fields, ","), value(dst))
+	default:
+		i.e.printf("get %s %s %s: not found", i.name, id, strings.Join(fields, ","))
+	}
+
+	return found, err
+}
+
+// Compile-time assurance that implementation satisfies interface.
+var _ index.Index = &explainedIndex{}
+
+// Index wraps i, which is used as the named index by the crawler, to trace its operations. For cached indexes,
+// the backing and the caching index are traced separately, preventing cache writes during dry runs as well.
+func (e *Explainer) Index(name string, i index.Index) index.Index {
+	if i == nil {
+		return nil
+	}
+
+	if c, ok := i.(*cache.Index); ok {
+		return c.Wrap(func(wrapped index.Index) index.Index {
+			if wrapped == c.Caching() {
+				return &explainedIndex{wrapped, e, name + " cache"}
+			}
+
+			return &explainedIndex{wrapped, e, name}
+		})
+	}
+
+	return &explainedIndex{i, e, name}
+}
+
+// Indexes wraps the crawler's indexes to trace their operations.
+func (e *Explainer) Indexes(ix *crawler.Indexes) *crawler.Indexes {
+	return &crawler.Indexes{
+		Files:       e.Index("files", ix.Files),
+		Directories: e.Index("directories", ix.Directories),
+		Invalids:    e.Index("invalids", ix.Invalids),
+		Partials:    e.Index("partials", ix.Partials),
+		Names:       e.Index("names", ix.Names),
+		Dags:        e.Index("dags", ix.Dags),
+		Checkpoints: e.Index("checkpoints", ix.Checkpoints),
+		Extractions: e.Index("extractions", ix.Extractions),
+	}
+}
@@ -0,0 +1,86 @@
+package explain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ipfs-search/ipfs-search/components/protocol"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// explainedProtocol traces calls to a protocol.
+type explainedProtocol struct {
+	protocol.Protocol
+	e *Explainer
+}
+
+// stat explains the outcome of Stat.
+func (e *Explainer) stat(r *t.AnnotatedResource, err error) string {
+	if err != nil {
+		if errors.Is(err, t.ErrInvalidResource) {
+			return result(err) + "; invalid resource, to be indexed as such"
+		}
+
+		return result(err)
+	}
+
+	switch r.Type {
+	case t.PartialType:
+		return "partial; its size equals the partial size and it is not referenced from a directory"
+	case t.UnsupportedType:
+		return "unsupported type, to be indexed as invalid"
+	default:
+		return "type " + r.Type.String()
+	}
+}
+
+// Stat traces determining the type and size of a resource, explaining partials and invalid resources.
+func (p *explainedProtocol) Stat(ctx context.Context, r *t.AnnotatedResource) error {
+	err := p.Protocol.Stat(ctx, r)
+
+	p.e.printf("stat %s (partial size %d): size %d, %s", r, p.e.partialSize, r.Size, p.e.stat(r, err))
+
+	return err
+}
+
+// Ls traces listing a directory; the entries are traced as they are published.
+func (p *explainedProtocol) Ls(ctx context.Context, r *t.AnnotatedResource, out chan<- *t.AnnotatedResource) error {
+	p.e.printf("ls %s", r)
+
+	err := p.Protocol.Ls(ctx, r, out)
+
+	p.e.printf("ls %s: %s", r, result(err))
+
+	return err
+}
+
+// Resolve traces resolving a name.
+func (p *explainedProtocol) Resolve(ctx context.Context, r *t.AnnotatedResource) (*t.Resource, error) {
+	resolved, err := p.Protocol.Resolve(ctx, r)
+
+	if err == nil {
+		p.e.printf("resolve %s: %s", r, resolved)
+	} else {
+		p.e.printf("resolve %s: %s", r, result(err))
+	}
+
+	return resolved, err
+}
+
+// GetDag traces getting a DAG node.
+func (p *explainedProtocol) GetDag(ctx context.Context, r *t.AnnotatedResource) (interface{}, error) {
+	node, err := p.Protocol.GetDag(ctx, r)
+
+	if err == nil {
+		p.e.printf("get dag %s: %s", r, value(node))
+	} else {
+		p.e.printf("get dag %s: %s", r, result(err))
+	}
+
+	return node, err
+}
+
+// Protocol wraps p to trace calls to it; protocol calls are never prevented.
+func (e *Explainer) Protocol(p protocol.Protocol) protocol.Protocol {
+	return &explainedProtocol{p, e}
+}
@@ -0,0 +1,51 @@
+package explain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ipfs-search/ipfs-search/components/crawler"
+	"github.com/ipfs-search/ipfs-search/components/queue"
+)
+
+// errNoConsume is returned when consuming from explained queues, as only publishing is traced.
+var errNoConsume = errors.New("cannot consume from explained queue")
+
+// explainedQueue traces messages published to a queue, only passing them on when not in a dry run.
+type explainedQueue struct {
+	queue.Publisher
+	e    *Explainer
+	name string
+}
+
+// Publish traces publishing a message.
+func (q *explainedQueue) Publish(ctx context.Context, pub interface{}, priority uint8) error {
+	q.e.countPublish()
+
+	var err error
+	if !q.e.dryRun {
+		err = q.Publisher.Publish(ctx, pub, priority)
+	}
+
+	q.e.printf("%s to %s with priority %d: %s, %s", q.e.would("publish"), q.name, priority, value(pub), result(err))
+
+	return err
+}
+
+// Consume is not supported.
+func (q *explainedQueue) Consume(context.Context) (<-chan queue.Delivery, error) {
+	return nil, errNoConsume
+}
+
+// Queues wraps the crawler's queues to trace publishing to them. During dry runs, qs may be nil.
+func (e *Explainer) Queues(qs *crawler.Queues) *crawler.Queues {
+	if qs == nil {
+		qs = new(crawler.Queues)
+	}
+
+	return &crawler.Queues{
+		Files:       &explainedQueue{qs.Files, e, "files"},
+		Directories: &explainedQueue{qs.Directories, e, "directories"},
+		Hashes:      &explainedQueue{qs.Hashes, e, "hashes"},
+	}
+}
@@ -0,0 +1,117 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ipfs-search/ipfs-search/components/extractor"
+	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// getExtractionVersion returns the combined version of the extractors, or an empty string
+// when any of them is not versioned and extraction results can hence not be cached.
+func getExtractionVersion(extractors []extractor.Extractor) string {
+	versions := make([]string, len(extractors))
+
+	for i, e := range extractors {
+		v, ok := e.(extractor.Versioned)
+		if !ok || v.Version() == "" {
+			return ""
+		}
+
+		versions[i] = v.Version()
+	}
+
+	return strings.Join(versions, ",")
+}
+
+// extractorName returns the name of an extractor's type, e.g. "tika.Extractor", for metrics.
+func extractorName(e extractor.Extractor) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", e), "*")
+}
+
+// extractionResult classifies the result of extraction, for metrics.
+func extractionResult(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, extractor.ErrFileTooLarge):
+		return "too_large"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, t.ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, t.ErrUnexpectedResponse):
+		return "unexpected_response"
+	case errors.Is(err, t.ErrRequest):
+		return "request_error"
+	default:
+		return "error"
+	}
+}
+
+// extract extracts properties with e, recording its latency and result.
+func (c *Crawler) extract(ctx context.Context, e extractor.Extractor, r *t.AnnotatedResource, properties *indexTypes.File) error {
+	start := time.Now()
+
+	err := e.Extract(ctx, r, properties)
+
+	c.Metrics.ExtractorDuration.Record(ctx, milliseconds(time.Since(start)),
+		attribute.String("extractor", extractorName(e)),
+		attribute.String("result", extractionResult(err)),
+	)
+
+	return err
+}
+
+// extractionKey returns the key for cached extraction results of a resource and whether these may be cached.
+func (c *Crawler) extractionKey(r *t.AnnotatedResource) (string, bool) {
+	if c.indexes.Extractions == nil || c.extractionVersion == "" {
+		return "", false
+	}
+
+	return r.ID + ":" + c.extractionVersion, true
+}
+
+// getCachedExtraction sets earlier extraction results on f, returning true when these were found.
+// Failure to retrieve cached results is not fatal; extraction will simply be repeated.
+func (c *Crawler) getCachedExtraction(ctx context.Context, key string, f *indexTypes.File) bool {
+	span := trace.SpanFromContext(ctx)
+
+	extraction := new(indexTypes.Extraction)
+
+	found, err := c.indexes.Extractions.Get(ctx, key, extraction, indexTypes.ExtractionFields...)
+	if err != nil {
+		c.log.ErrorCtx(ctx, "Error getting cached extraction", "key", key, "err", err)
+		span.RecordError(err)
+
+		return false
+	}
+
+	if found {
+		span.AddEvent("extraction-cache-hit", trace.WithAttributes(attribute.String("key", key)))
+		f.SetExtraction(extraction)
+	}
+
+	return found
+}
+
+// cacheExtraction stores the extraction results from f.
+// Failure to cache is not fatal for indexing the resource.
+func (c *Crawler) cacheExtraction(ctx context.Context, key string, f *indexTypes.File) {
+	span := trace.SpanFromContext(ctx)
+
+	if err := c.indexes.Extractions.Index(ctx, key, f.Extraction()); err != nil {
+		c.log.ErrorCtx(ctx, "Error caching extraction", "key", key, "err", err)
+		span.RecordError(err)
+	}
+}
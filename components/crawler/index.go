@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -33,11 +32,22 @@ func makeDocument(r *t.AnnotatedResource) indexTypes.Document {
 		}
 	}
 
+	var providers []indexTypes.Provider
+	if r.Provider != nil && r.Provider.PeerID != "" {
+		providers = []indexTypes.Provider{
+			{
+				PeerID:   r.Provider.PeerID,
+				LastSeen: r.Provider.Date.UTC().Truncate(time.Second),
+			},
+		}
+	}
+
 	// Common Document properties
 	return indexTypes.Document{
 		FirstSeen:  now,
 		LastSeen:   now,
 		References: references,
+		Providers:  providers,
 		Size:       r.Size,
 	}
 }
@@ -58,14 +68,36 @@ func (c *Crawler) getFileProperties(ctx context.Context, r *t.AnnotatedResource)
 		Document: makeDocument(r),
 	}
 
+	key, cacheable := c.extractionKey(r)
+	if cacheable && c.getCachedExtraction(ctx, key, properties) {
+		return properties, nil
+	}
+
+	// Only cache results when all extractors succeeded.
+	failed := false
+
 	// Note; this assumes to be sequential to allow for dependencies amongst extractors.
 	for _, e := range c.extractors {
-		err = e.Extract(ctx, r, properties)
+		err = c.extract(ctx, e, r, properties)
 		if errors.Is(err, extractor.ErrFileTooLarge) {
 			// Interpret files which are too large as invalid resources; prevent repeated attempts.
 			span.RecordError(err)
 			return nil, fmt.Errorf("%w: %v", t.ErrInvalidResource, err)
 		}
+
+		if errors.Is(err, t.ErrCircuitOpen) {
+			// Don't index without the extractor's results, nor wait for the others.
+			span.RecordError(err)
+			return nil, err
+		}
+
+		if err != nil {
+			failed = true
+		}
+	}
+
+	if cacheable && !failed {
+		c.cacheExtraction(ctx, key, properties)
 	}
 
 	return properties, err
@@ -80,6 +112,15 @@ func (c *Crawler) getDirectoryProperties(ctx context.Context, r *t.AnnotatedReso
 	return properties, err
 }
 
+func (c *Crawler) getDagProperties(ctx context.Context, r *t.AnnotatedResource) (interface{}, error) {
+	properties := &indexTypes.Dag{
+		Document: makeDocument(r),
+	}
+	err := c.crawlDag(ctx, r, properties)
+
+	return properties, err
+}
+
 func (c *Crawler) getProperties(ctx context.Context, r *t.AnnotatedResource) (index.Index, interface{}, error) {
 	var err error
 
@@ -96,6 +137,11 @@ func (c *Crawler) getProperties(ctx context.Context, r *t.AnnotatedResource) (in
 
 		return c.indexes.Directories, d, err
 
+	case t.DagType:
+		d, err := c.getDagProperties(ctx, r)
+
+		return c.indexes.Dags, d, err
+
 	case t.UnsupportedType:
 		// Index unsupported items as invalid.
 		err = t.ErrUnsupportedType
@@ -115,7 +161,8 @@ func (c *Crawler) getProperties(ctx context.Context, r *t.AnnotatedResource) (in
 	}
 }
 
-func (c *Crawler) index(ctx context.Context, r *t.AnnotatedResource) error {
+// index indexes a new resource, returning the outcome.
+func (c *Crawler) index(ctx context.Context, r *t.AnnotatedResource) (string, error) {
 	ctx, span := c.Tracer.Start(ctx, "crawler.index",
 		trace.WithAttributes(attribute.Stringer("type", r.Type)),
 	)
@@ -125,14 +172,19 @@ func (c *Crawler) index(ctx context.Context, r *t.AnnotatedResource) error {
 
 	if err != nil {
 		if errors.Is(err, t.ErrInvalidResource) {
-			log.Printf("Indexing invalid '%v', err: %v", r, err)
+			c.log.InfoCtx(ctx, "Indexing invalid resource", "cid", r.ID, "err", err)
 			span.RecordError(err)
-			return c.indexInvalid(ctx, r, err)
+			return outcomeInvalid, c.indexInvalid(ctx, r, err)
 		}
 
-		return err
+		return "", err
+	}
+
+	outcome := outcomeNew
+	if r.Type == t.PartialType {
+		outcome = outcomePartial
 	}
 
 	// Index the result
-	return index.Index(ctx, r.ID, properties)
+	return outcome, index.Index(ctx, r.ID, properties)
 }
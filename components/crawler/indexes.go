@@ -10,4 +10,8 @@ type Indexes struct {
 	Directories index.Index
 	Invalids    index.Index
 	Partials    index.Index
+	Names       index.Index
+	Dags        index.Index
+	Checkpoints index.Index
+	Extractions index.Index // Optional cache of extraction results.
 }
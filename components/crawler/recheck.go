@@ -0,0 +1,52 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	index_types "github.com/ipfs-search/ipfs-search/components/index/types"
+)
+
+// recheck verifies the availability of an existing item, updating last-seen when it is available and
+// marking it as unreachable otherwise.
+func (c *Crawler) recheck(ctx context.Context, i *existingItem) error {
+	ctx, span := c.Tracer.Start(ctx, "crawler.recheck")
+	defer span.End()
+
+	// Strip milliseconds to cater to legacy ES index format.
+	now := time.Now().Truncate(time.Second)
+
+	update := &index_types.Recheck{
+		LastChecked: now,
+	}
+
+	statCtx, cancel := context.WithTimeout(ctx, c.config.Load().StatTimeout)
+	defer cancel()
+
+	err := c.protocol.Stat(statCtx, i.AnnotatedResource)
+
+	switch {
+	case err == nil:
+		update.LastSeen = &now
+
+	case errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil:
+		c.log.InfoCtx(ctx, "Marking unreachable item", "cid", i.ID)
+		update.Unreachable = true
+
+	default:
+		span.RecordError(err)
+		return err
+	}
+
+	span.AddEvent("Updating",
+		trace.WithAttributes(
+			attribute.String("reason", "recheck"),
+			attribute.Bool("unreachable", update.Unreachable),
+		))
+
+	return i.Index.Update(ctx, i.AnnotatedResource.ID, update)
+}
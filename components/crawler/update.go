@@ -3,7 +3,6 @@ package crawler
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -33,14 +32,58 @@ func appendReference(refs index_types.References, r *t.Reference) (index_types.R
 	}), true
 }
 
+// addProvider records a providing peer, refreshing its last-seen when it is older than minAge.
+// When more than max peers are known, the least recently seen peer is evicted.
+func addProvider(providers index_types.Providers, p *t.ProviderRecord, max uint, minAge time.Duration) (index_types.Providers, bool) {
+	if p == nil || p.PeerID == "" {
+		return providers, false
+	}
+
+	// Strip milliseconds to cater to legacy ES index format.
+	seen := p.Date.UTC().Truncate(time.Second)
+
+	for i, indexed := range providers {
+		if indexed.PeerID == p.PeerID {
+			if seen.Sub(indexed.LastSeen) <= minAge {
+				// Known provider, recently seen; not updating
+				return providers, false
+			}
+
+			updated := make(index_types.Providers, len(providers))
+			copy(updated, providers)
+			updated[i].LastSeen = seen
+
+			return updated, true
+		}
+	}
+
+	providers = append(providers, index_types.Provider{
+		PeerID:   p.PeerID,
+		LastSeen: seen,
+	})
+
+	if max > 0 && uint(len(providers)) > max {
+		oldest := 0
+		for i := range providers {
+			if providers[i].LastSeen.Before(providers[oldest].LastSeen) {
+				oldest = i
+			}
+		}
+
+		providers = append(providers[:oldest], providers[oldest+1:]...)
+	}
+
+	return providers, true
+}
+
 // updateExisting updates known existing items.
 func (c *Crawler) updateExisting(ctx context.Context, i *existingItem) error {
 	ctx, span := c.Tracer.Start(ctx, "crawler.updateExisting")
 	defer span.End()
 
 	switch i.Source {
-	case t.DirectorySource:
-		// Item referenced from a directory, consider updating references (but not last-seen).
+	case t.DirectorySource, t.DagSource:
+		// Item referenced from a directory or DAG, consider updating references (but not last-seen).
 		refs, refsUpdated := appendReference(i.References, &i.AnnotatedResource.Reference)
 
 		if refsUpdated {
@@ -55,9 +98,9 @@ func (c *Crawler) updateExisting(ctx context.Context, i *existingItem) error {
 			})
 		}
 
-	case t.SnifferSource, t.UnknownSource:
+	case t.SnifferSource, t.NameSource, t.UnknownSource:
 		// TODO: Remove UnknownSource after sniffer is updated and queue is flushed.
-		// Item sniffed, conditionally update last-seen.
+		// Item sniffed or resolved from a name, conditionally update last-seen.
 		now := time.Now()
 
 		// Strip milliseconds to cater to legacy ES index format.
@@ -66,24 +109,41 @@ func (c *Crawler) updateExisting(ctx context.Context, i *existingItem) error {
 
 		var isRecent bool
 		if i.LastSeen == nil {
-			log.Printf("LastSeen is nil, overriding isRecent.")
+			c.log.DebugCtx(ctx, "LastSeen is nil, overriding isRecent", "cid", i.ID)
 			isRecent = true
 		} else {
-			isRecent = now.Sub(*i.LastSeen) > c.config.MinUpdateAge
+			isRecent = now.Sub(*i.LastSeen) > c.config.Load().MinUpdateAge
 		}
 
-		if isRecent {
+		providers, providersUpdated := addProvider(i.Providers, i.AnnotatedResource.Provider,
+			c.config.Load().MaxProviders, c.config.Load().MinUpdateAge)
+
+		if isRecent || providersUpdated {
 			span.AddEvent("Updating",
 				trace.WithAttributes(
-					attribute.String("reason", "is-recent")))
+					attribute.Bool("is-recent", isRecent),
+					attribute.Bool("provider-updated", providersUpdated)))
 			// TODO: This causes a panic when LastSeen is nil.
 			// attribute.Stringer("last-seen", i.LastSeen),
 
-			return i.Index.Update(ctx, i.AnnotatedResource.ID, &index_types.Update{
-				LastSeen: &now,
-			})
+			// Being sniffed or resolved proves availability; clear unreachable.
+			update := &index_types.Seen{
+				Update: index_types.Update{
+					LastSeen: &now,
+				},
+			}
+
+			if providersUpdated {
+				update.Providers = providers
+			}
+
+			return i.Index.Update(ctx, i.AnnotatedResource.ID, update)
 		}
 
+	case t.RecrawlSource:
+		// Item scheduled for re-crawling, verify it is still available.
+		return c.recheck(ctx, i)
+
 	case t.ManualSource, t.UserSource:
 		// Do not update based on manual or user input.
 
@@ -105,7 +165,7 @@ func (c *Crawler) deletePartial(ctx context.Context, i *existingItem) error {
 // processPartial processes partials found in index; previously recognized as an unreferenced partial
 func (c *Crawler) processPartial(ctx context.Context, i *existingItem) (bool, error) {
 	if i.Reference.Parent == nil {
-		log.Printf("Quick-skipping unreferenced partial %v", i)
+		c.log.DebugCtx(ctx, "Quick-skipping unreferenced partial", "cid", i.ID)
 
 		// Skip unreferenced partial
 		return true, nil
@@ -11,3 +11,9 @@ import (
 type Extractor interface {
 	Extract(ctx context.Context, resource *t.AnnotatedResource, metadata interface{}) error
 }
+
+// Versioned is implemented by extractors of which the results may be cached. The version should identify the
+// extractor and change whenever its output changes, invalidating earlier results. An empty version disables caching.
+type Versioned interface {
+	Version() string
+}
@@ -0,0 +1,36 @@
+package native
+
+import (
+	"time"
+
+	"github.com/c2h5oh/datasize"
+)
+
+// Values for Config.Fallback.
+const (
+	FallbackTika = "tika" // Fall back to the Tika extractor for unsupported formats.
+	FallbackNone = "none" // Don't extract metadata for unsupported formats.
+)
+
+// Config specifies the configuration for the native extractor.
+type Config struct {
+	MimeTypes      []string          // MIME types to extract natively, others are passed on to the fallback.
+	RequestTimeout time.Duration     // Timeout for retrieving files from the gateway.
+	MaxFileSize    datasize.ByteSize // Don't attempt native extraction for files over this size.
+	Fallback       string            // Extractor to fall back to for other formats; FallbackTika or FallbackNone.
+}
+
+// DefaultConfig returns the default configuration for the native extractor.
+func DefaultConfig() *Config {
+	return &Config{
+		MimeTypes: []string{
+			"text/plain",
+			"text/markdown",
+			"text/html",
+			"application/json",
+		},
+		RequestTimeout: 60 * time.Duration(time.Second),
+		MaxFileSize:    10 * datasize.MB,
+		Fallback:       FallbackTika,
+	}
+}
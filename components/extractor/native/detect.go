@@ -0,0 +1,60 @@
+package native
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// sniffLen is the maximum number of bytes considered by http.DetectContentType.
+const sniffLen = 512
+
+// extensionTypes are used for formats which can't be told apart from plain text by sniffing.
+var extensionTypes = map[string]string{
+	".md":       "text/markdown",
+	".markdown": "text/markdown",
+	".json":     "application/json",
+}
+
+func typeByExtension(r *t.AnnotatedResource) string {
+	ext := strings.ToLower(path.Ext(r.Reference.Name))
+	if ext == "" {
+		return ""
+	}
+
+	if mimeType, ok := extensionTypes[ext]; ok {
+		return mimeType
+	}
+
+	return mime.TypeByExtension(ext)
+}
+
+// detectContentType returns the Content-Type for a resource, based on sniffing its first bytes.
+// For plain text, the extension of the reference name (if any) is used to refine the type.
+func detectContentType(r *t.AnnotatedResource, data []byte) string {
+	contentType := http.DetectContentType(data)
+
+	if mediaType(contentType) == "text/plain" {
+		if extType := typeByExtension(r); extType != "" && extType != contentType {
+			if _, params, err := mime.ParseMediaType(contentType); err == nil {
+				params["charset"] = "utf-8"
+				return mime.FormatMediaType(mediaType(extType), params)
+			}
+		}
+	}
+
+	return contentType
+}
+
+// mediaType returns the media type, without parameters, of a Content-Type.
+func mediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType
+	}
+
+	return mediaType
+}
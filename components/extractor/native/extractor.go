@@ -0,0 +1,198 @@
+// Package native extracts metadata and content from common, simple, formats without external dependencies.
+package native
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+
+	"github.com/ipfs-search/ipfs-search/components/extractor"
+	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+	"github.com/ipfs-search/ipfs-search/components/protocol"
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+	"github.com/ipfs-search/ipfs-search/utils"
+)
+
+// parsedBy is set as X-Parsed-By in the metadata of natively extracted files, as Tika does.
+const parsedBy = "ipfs-search native extractor"
+
+// Extractor extracts content and metadata for simple formats, passing other formats on to a fallback.
+type Extractor struct {
+	config   atomic.Pointer[Config]
+	getter   utils.HTTPBodyGetter
+	protocol protocol.Protocol
+	fallback extractor.Extractor
+	parsers  map[string]parser
+
+	*instr.Instrumentation
+	log *slog.Logger
+}
+
+func (e *Extractor) doFallback(ctx context.Context, r *t.AnnotatedResource, m interface{}) error {
+	if e.fallback == nil {
+		return nil
+	}
+
+	return e.fallback.Extract(ctx, r, m)
+}
+
+// Extract content and metadata from a (potentially) referenced resource, updating
+// the File or returning an error. Named files are only retrieved when the extension of their name matches a
+// configured format. For unnamed files, the format is sniffed from their first bytes, closing the request early
+// for other formats. Other files, files whose content does not match and files which are too large are passed
+// on to the fallback, if any.
+func (e *Extractor) Extract(ctx context.Context, r *t.AnnotatedResource, m interface{}) error {
+	ctx, span := e.Tracer.Start(ctx, "extractor.native.Extract")
+	defer span.End()
+
+	if r.Size > uint64(e.config.Load().MaxFileSize) {
+		// Too large for native extraction, leave it to the fallback.
+		span.AddEvent("fallback", trace.WithAttributes(attribute.String("reason", "file-size")))
+		return e.doFallback(ctx, r, m)
+	}
+
+	if r.Reference.Name != "" {
+		if _, ok := e.parsers[mediaType(typeByExtension(r))]; !ok {
+			// Not a native format by its name; leave it to the fallback without retrieving the file.
+			span.AddEvent("fallback", trace.WithAttributes(attribute.String("reason", "file-name")))
+			return e.doFallback(ctx, r, m)
+		}
+	}
+
+	file := m.(*indexTypes.File) // Panics if we're not a File.
+
+	parsed, err := e.extract(ctx, r, file)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if !parsed {
+		span.AddEvent("fallback", trace.WithAttributes(attribute.String("reason", "content-type")))
+		return e.doFallback(ctx, r, m)
+	}
+
+	e.log.DebugCtx(ctx, "Got native metadata", "cid", r.ID)
+	return nil
+}
+
+// extract retrieves the resource, returning false when its sniffed format is not configured for native extraction.
+func (e *Extractor) extract(ctx context.Context, r *t.AnnotatedResource, f *indexTypes.File) (bool, error) {
+	span := trace.SpanFromContext(ctx)
+
+	// Timeout if extraction hasn't fully completed within this time.
+	ctx, cancel := context.WithTimeout(ctx, e.config.Load().RequestTimeout)
+	defer cancel()
+
+	gwURL, err := e.protocol.GatewayURL(r)
+	if err != nil {
+		return false, err
+	}
+
+	body, err := e.getter.GetBody(ctx, gwURL, 200)
+	if err != nil {
+		return false, err
+	}
+	defer body.Close()
+
+	reader := bufio.NewReaderSize(body, sniffLen)
+
+	head, err := reader.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("%w: %v", t.ErrRequest, err)
+	}
+
+	contentType := detectContentType(r, head)
+	span.SetAttributes(attribute.String("content-type", contentType))
+
+	parse, ok := e.parsers[mediaType(contentType)]
+	if !ok {
+		// Closing the body aborts retrieval after the sniffed bytes.
+		return false, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, int64(e.config.Load().MaxFileSize)))
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", t.ErrRequest, err)
+	}
+
+	if f.Metadata == nil {
+		f.Metadata = make(indexTypes.Metadata)
+	}
+
+	f.Metadata["Content-Type"] = []interface{}{contentType}
+	f.Metadata["X-Parsed-By"] = []interface{}{parsedBy}
+
+	if err := parse(data, f); err != nil {
+		return false, fmt.Errorf("%w: %v", t.ErrInvalidResource, err)
+	}
+
+	if language, ok := detectLanguage(f.Content); ok {
+		f.Language = language
+	}
+
+	return true, nil
+}
+
+// version identifies the results of this extractor; bump when the output changes.
+const version = "native-1"
+
+// Version returns the version of the extractor, including that of its fallback, used as a key for cached results.
+// Returns an empty version, disabling caching, when the fallback is not versioned.
+func (e *Extractor) Version() string {
+	if e.fallback == nil {
+		return version
+	}
+
+	fallback, ok := e.fallback.(extractor.Versioned)
+	if !ok || fallback.Version() == "" {
+		return ""
+	}
+
+	return version + "+" + fallback.Version()
+}
+
+// SetConfig replaces the extractor's size limit and timeout, applying to subsequent extractions. The MIME types
+// and fallback extractor are fixed by New.
+func (e *Extractor) SetConfig(config *Config) {
+	e.config.Store(config)
+}
+
+// New returns a new native extractor, falling back to the fallback extractor for formats which
+// are not configured, or files over the maximum size. Fallback may be nil.
+func New(config *Config, getter utils.HTTPBodyGetter, protocol protocol.Protocol, fallback extractor.Extractor, instr *instr.Instrumentation) extractor.Extractor {
+	enabled := make(map[string]parser, len(config.MimeTypes))
+
+	for _, mimeType := range config.MimeTypes {
+		p, ok := parsers[mimeType]
+		if !ok {
+			panic(fmt.Sprintf("unsupported MIME type for native extractor: %s", mimeType))
+		}
+
+		enabled[mimeType] = p
+	}
+
+	e := &Extractor{
+		getter:          getter,
+		protocol:        protocol,
+		fallback:        fallback,
+		parsers:         enabled,
+		Instrumentation: instr,
+		log:             instr.Component("extractor").With("extractor", "native"),
+	}
+
+	e.config.Store(config)
+
+	return e
+}
+
+// Compile-time assurance that implementation satisfies interface.
+var _ extractor.Extractor = &Extractor{}
+var _ extractor.Versioned = &Extractor{}
@@ -0,0 +1,325 @@
+package native
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dankinder/httpmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/components/extractor"
+	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+	"github.com/ipfs-search/ipfs-search/components/protocol"
+
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+	"github.com/ipfs-search/ipfs-search/utils"
+)
+
+const testCID = "QmehHHRh1a7u66r7fugebp6f6wGNMGCa7eho9cgjwhAcm2"
+
+type NativeTestSuite struct {
+	suite.Suite
+
+	ctx    context.Context
+	e      extractor.Extractor
+	getter utils.HTTPBodyGetter
+
+	cfg      *Config
+	protocol *protocol.Mock
+	fallback *extractor.Mock
+
+	mockGWHandler *httpmock.MockHandler
+	mockGWServer  *httpmock.Server
+}
+
+func (s *NativeTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	s.mockGWHandler = &httpmock.MockHandler{}
+	s.mockGWServer = httpmock.NewServer(s.mockGWHandler)
+
+	s.cfg = DefaultConfig()
+	s.protocol = &protocol.Mock{}
+	s.fallback = &extractor.Mock{}
+
+	i := instr.New()
+	s.getter = utils.NewHTTPBodyGetter(http.DefaultClient, i)
+
+	s.e = New(s.cfg, s.getter, s.protocol, s.fallback, i)
+}
+
+func (s *NativeTestSuite) TearDownTest() {
+	s.mockGWServer.Close()
+}
+
+func (s *NativeTestSuite) makeResource(name string, size uint64) *t.AnnotatedResource {
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       testCID,
+		},
+		Stat: t.Stat{
+			Size: size,
+		},
+	}
+
+	if name != "" {
+		r.Reference = t.Reference{
+			Parent: &t.Resource{
+				Protocol: t.IPFSProtocol,
+				ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+			},
+			Name: name,
+		}
+	}
+
+	return r
+}
+
+func (s *NativeTestSuite) serve(r *t.AnnotatedResource, body []byte) {
+	s.protocol.
+		On("GatewayURL", r).
+		Return(s.mockGWServer.URL()+"/ipfs/"+r.ID, nil).
+		Once()
+
+	s.mockGWHandler.
+		On("Handle", "GET", "/ipfs/"+r.ID, mock.Anything).
+		Return(httpmock.Response{
+			Body: body,
+		}).
+		Once()
+}
+
+func (s *NativeTestSuite) TestExtractText() {
+	body := []byte("The quick brown fox jumps over the lazy dog, again and again, while the farmer watches from the porch.")
+	r := s.makeResource("fox.txt", uint64(len(body)))
+
+	s.serve(r, body)
+
+	f := &indexTypes.File{}
+
+	err := s.e.Extract(s.ctx, r, f)
+
+	s.NoError(err)
+	s.mockGWHandler.AssertExpectations(s.T())
+	s.fallback.AssertNotCalled(s.T(), "Extract", mock.Anything, mock.Anything, mock.Anything)
+
+	s.Equal(string(body), f.Content)
+	s.Equal([]interface{}{"text/plain; charset=utf-8"}, f.Metadata["Content-Type"])
+	s.Equal([]interface{}{parsedBy}, f.Metadata["X-Parsed-By"])
+	s.Equal("en", f.Language.Language)
+}
+
+func (s *NativeTestSuite) TestExtractMarkdown() {
+	body := []byte("# Title\n\nSome *markdown* text.")
+	r := s.makeResource("README.md", uint64(len(body)))
+
+	s.serve(r, body)
+
+	f := &indexTypes.File{}
+
+	err := s.e.Extract(s.ctx, r, f)
+
+	s.NoError(err)
+	s.Equal(string(body), f.Content)
+	s.Equal([]interface{}{"text/markdown; charset=utf-8"}, f.Metadata["Content-Type"])
+}
+
+func (s *NativeTestSuite) TestExtractHTML() {
+	body := []byte(`<!DOCTYPE html>
+<html>
+<head>
+  <title>How Filecoin Supports Video Storage</title>
+  <style>body { color: red; }</style>
+  <script>var x = "not content";</script>
+</head>
+<body>
+  <p>The Filecoin Space Race is now live!</p>
+  <a href="https://proto.school/#/tutorials?course=filecoin">Learn More</a>
+  <a href="/relative">Relative</a>
+</body>
+</html>`)
+	r := s.makeResource("index.html", uint64(len(body)))
+
+	s.serve(r, body)
+
+	f := &indexTypes.File{}
+
+	err := s.e.Extract(s.ctx, r, f)
+
+	s.NoError(err)
+	s.Equal("The Filecoin Space Race is now live! Learn More Relative", f.Content)
+	s.Equal([]interface{}{"How Filecoin Supports Video Storage"}, f.Metadata["title"])
+	s.Equal([]interface{}{"text/html; charset=utf-8"}, f.Metadata["Content-Type"])
+	s.Equal([]string{"https://proto.school/#/tutorials?course=filecoin"}, f.URLs)
+}
+
+func (s *NativeTestSuite) TestFallbackFileName() {
+	for _, name := range []string{"image.png", "noextension"} {
+		r := s.makeResource(name, 400)
+
+		f := &indexTypes.File{}
+
+		s.fallback.
+			On("Extract", mock.Anything, r, f).
+			Return(nil).
+			Once()
+
+		err := s.e.Extract(s.ctx, r, f)
+
+		s.NoError(err)
+		s.Empty(f.Metadata)
+	}
+
+	// Files are not retrieved.
+	s.fallback.AssertExpectations(s.T())
+	s.protocol.AssertNotCalled(s.T(), "GatewayURL", mock.Anything)
+	s.mockGWHandler.AssertNotCalled(s.T(), "Handle", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *NativeTestSuite) TestExtractUnnamed() {
+	body := []byte("The quick brown fox jumps over the lazy dog, again and again, while the farmer watches from the porch.")
+	r := s.makeResource("", uint64(len(body)))
+
+	s.serve(r, body)
+
+	f := &indexTypes.File{}
+
+	err := s.e.Extract(s.ctx, r, f)
+
+	s.NoError(err)
+	s.fallback.AssertNotCalled(s.T(), "Extract", mock.Anything, mock.Anything, mock.Anything)
+	s.Equal(string(body), f.Content)
+	s.Equal([]interface{}{"text/plain; charset=utf-8"}, f.Metadata["Content-Type"])
+}
+
+func (s *NativeTestSuite) TestFallbackUnnamed() {
+	// PNG header
+	body := []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+	r := s.makeResource("", uint64(len(body)))
+
+	s.serve(r, body)
+
+	f := &indexTypes.File{}
+
+	s.fallback.
+		On("Extract", mock.Anything, r, f).
+		Return(nil).
+		Once()
+
+	err := s.e.Extract(s.ctx, r, f)
+
+	s.NoError(err)
+	s.fallback.AssertExpectations(s.T())
+	s.Empty(f.Metadata)
+}
+
+func (s *NativeTestSuite) TestFallbackContentType() {
+	// PNG header
+	body := []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+	r := s.makeResource("image.txt", uint64(len(body)))
+
+	s.serve(r, body)
+
+	f := &indexTypes.File{}
+
+	s.fallback.
+		On("Extract", mock.Anything, r, f).
+		Return(nil).
+		Once()
+
+	err := s.e.Extract(s.ctx, r, f)
+
+	s.NoError(err)
+	s.fallback.AssertExpectations(s.T())
+	s.Empty(f.Metadata)
+}
+
+func (s *NativeTestSuite) TestFallbackFileSize() {
+	r := s.makeResource("", uint64(s.cfg.MaxFileSize+1))
+
+	f := &indexTypes.File{}
+
+	s.fallback.
+		On("Extract", mock.Anything, r, f).
+		Return(nil).
+		Once()
+
+	err := s.e.Extract(s.ctx, r, f)
+
+	s.NoError(err)
+	s.fallback.AssertExpectations(s.T())
+	s.mockGWHandler.AssertNotCalled(s.T(), "Handle", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *NativeTestSuite) TestNoFallback() {
+	s.e = New(s.cfg, s.getter, s.protocol, nil, instr.New())
+
+	body := []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+	r := s.makeResource("image.txt", uint64(len(body)))
+
+	s.serve(r, body)
+
+	f := &indexTypes.File{}
+
+	err := s.e.Extract(s.ctx, r, f)
+
+	s.NoError(err)
+	s.Empty(f.Content)
+}
+
+func (s *NativeTestSuite) TestUpstreamError() {
+	r := s.makeResource("file.txt", 400)
+
+	s.protocol.
+		On("GatewayURL", r).
+		Return(s.mockGWServer.URL()+"/ipfs/"+r.ID, nil).
+		Once()
+
+	s.mockGWHandler.
+		On("Handle", "GET", "/ipfs/"+r.ID, mock.Anything).
+		Return(httpmock.Response{
+			Status: 500,
+		}).
+		Once()
+
+	f := &indexTypes.File{}
+
+	err := s.e.Extract(s.ctx, r, f)
+
+	s.ErrorIs(err, t.ErrUnexpectedResponse)
+}
+
+func (s *NativeTestSuite) TestUnsupportedMimeType() {
+	s.cfg.MimeTypes = []string{"application/pdf"}
+
+	s.Panics(func() { New(s.cfg, s.getter, s.protocol, nil, instr.New()) })
+}
+
+type versionedFallback struct {
+	*extractor.Mock
+}
+
+func (versionedFallback) Version() string {
+	return "fallback-1"
+}
+
+func (s *NativeTestSuite) TestVersion() {
+	i := instr.New()
+
+	// Unversioned fallback; results can't be cached.
+	s.Equal("", s.e.(extractor.Versioned).Version())
+
+	e := New(s.cfg, s.getter, s.protocol, nil, i)
+	s.Equal("native-1", e.(extractor.Versioned).Version())
+
+	e = New(s.cfg, s.getter, s.protocol, versionedFallback{s.fallback}, i)
+	s.Equal("native-1+fallback-1", e.(extractor.Versioned).Version())
+}
+
+func TestNativeTestSuite(t *testing.T) {
+	suite.Run(t, new(NativeTestSuite))
+}
@@ -0,0 +1,47 @@
+package native
+
+import (
+	"strings"
+
+	"github.com/abadojack/whatlanggo"
+
+	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+)
+
+// languageSampleLen is the maximum number of bytes of text used for language detection.
+const languageSampleLen = 16384
+
+// confidence returns a confidence level for a detection score, similar to those used by Tika.
+func confidence(info whatlanggo.Info) string {
+	switch {
+	case info.IsReliable():
+		return "HIGH"
+	case info.Confidence > 0.5:
+		return "MEDIUM"
+	case info.Confidence > 0:
+		return "LOW"
+	default:
+		return "NONE"
+	}
+}
+
+// detectLanguage returns the detected language of text, and false when it couldn't be detected.
+func detectLanguage(text string) (indexTypes.Language, bool) {
+	if len(text) > languageSampleLen {
+		// Cutting might split a multibyte character; drop it.
+		text = strings.ToValidUTF8(text[:languageSampleLen], "")
+	}
+
+	info := whatlanggo.Detect(text)
+
+	code := info.Lang.Iso6391()
+	if code == "" {
+		return indexTypes.Language{}, false
+	}
+
+	return indexTypes.Language{
+		Confidence: confidence(info),
+		Language:   code,
+		RawScore:   info.Confidence,
+	}, true
+}
@@ -0,0 +1,127 @@
+package native
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+
+	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+)
+
+// parser fills File properties from the contents of a file.
+type parser func(data []byte, f *indexTypes.File) error
+
+// parsers for supported media types.
+var parsers = map[string]parser{
+	"text/plain":       parseText,
+	"text/markdown":    parseText,
+	"application/json": parseText,
+	"text/html":        parseHTML,
+}
+
+func toValidString(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+
+	return strings.ToValidUTF8(string(data), "")
+}
+
+func parseText(data []byte, f *indexTypes.File) error {
+	f.Content = toValidString(data)
+
+	return nil
+}
+
+func getAttr(t html.Token, key string) string {
+	for _, a := range t.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// parseHTML extracts the text content, title and (absolute) links from HTML.
+func parseHTML(data []byte, f *indexTypes.File) error {
+	var (
+		content strings.Builder
+		title   strings.Builder
+		skip    int
+		inTitle bool
+		z       = html.NewTokenizer(bytes.NewReader(data))
+	)
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if err := z.Err(); err != io.EOF {
+				return err
+			}
+
+			f.Content = strings.TrimSpace(content.String())
+
+			if t := strings.TrimSpace(title.String()); t != "" {
+				f.Metadata["title"] = []interface{}{t}
+			}
+
+			return nil
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+
+			switch t.Data {
+			case "script", "style", "noscript", "template":
+				if t.Type == html.StartTagToken {
+					skip++
+				}
+			case "title":
+				inTitle = t.Type == html.StartTagToken
+			case "a":
+				if href := getAttr(t, "href"); isURL(href) {
+					f.URLs = append(f.URLs, href)
+				}
+			}
+
+		case html.EndTagToken:
+			t := z.Token()
+
+			switch t.Data {
+			case "script", "style", "noscript", "template":
+				if skip > 0 {
+					skip--
+				}
+			case "title":
+				inTitle = false
+			}
+
+		case html.TextToken:
+			if skip > 0 {
+				continue
+			}
+
+			text := strings.TrimSpace(toValidString(z.Text()))
+			if text == "" {
+				continue
+			}
+
+			if inTitle {
+				title.WriteString(text)
+				continue
+			}
+
+			if content.Len() > 0 {
+				content.WriteByte(' ')
+			}
+			content.WriteString(text)
+		}
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"regexp"
+	"sync/atomic"
+
+	"golang.org/x/exp/slog"
 
 	"github.com/ipfs-search/ipfs-search/components/extractor"
 	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
@@ -16,14 +18,15 @@ import (
 
 // Extractor extracts metadata using the nsfw-server.
 type Extractor struct {
-	config *Config
+	config atomic.Pointer[Config]
 	getter utils.HTTPBodyGetter
 
 	*instr.Instrumentation
+	log *slog.Logger
 }
 
 func (e *Extractor) getExtractURL(r *t.AnnotatedResource) string {
-	return fmt.Sprintf("%s/classify/%s", e.config.NSFWServerURL, r.ID)
+	return fmt.Sprintf("%s/classify/%s", e.config.Load().NSFWServerURL, r.ID)
 }
 
 func matchOne(haystack string, needles []*regexp.Regexp) bool {
@@ -61,7 +64,7 @@ var compatibleMimes = []*regexp.Regexp{
 }
 
 func isCompatible(r *t.AnnotatedResource, f *indexTypes.File) bool {
-	// Check compatible protocol
+	// nsfw-server retrieves resources by CID from IPFS; other protocols are not compatible.
 	if r.Protocol != t.IPFSProtocol {
 		return false
 	}
@@ -82,12 +85,12 @@ func (e *Extractor) Extract(ctx context.Context, r *t.AnnotatedResource, m inter
 	ctx, span := e.Tracer.Start(ctx, "extractor.nsfw_server.Extract")
 	defer span.End()
 
-	if err := extractor.ValidateMaxSize(ctx, r, e.config.MaxFileSize); err != nil {
+	if err := extractor.ValidateMaxSize(ctx, r, e.config.Load().MaxFileSize); err != nil {
 		return err
 	}
 
 	// Timeout if extraction hasn't fully completed within this time.
-	ctx, cancel := context.WithTimeout(ctx, e.config.RequestTimeout)
+	ctx, cancel := context.WithTimeout(ctx, e.config.Load().RequestTimeout)
 	defer cancel()
 
 	file := m.(*indexTypes.File) // Panics if we're not a File.
@@ -113,18 +116,36 @@ func (e *Extractor) Extract(ctx context.Context, r *t.AnnotatedResource, m inter
 	// Success, update NSFW data.
 	file.NSFW = &nsfwData
 
-	log.Printf("Got nsfw metadata metadata for '%v'", r)
+	e.log.DebugCtx(ctx, "Got NSFW metadata", "cid", r.ID)
 	return nil
 }
 
+// version identifies the results of this extractor; bump when the output changes.
+const version = "nsfw-1"
+
+// Version returns the version of the extractor, used as a key for cached results.
+func (e *Extractor) Version() string {
+	return version
+}
+
+// SetConfig replaces the extractor's configuration, applying to subsequent extractions.
+func (e *Extractor) SetConfig(config *Config) {
+	e.config.Store(config)
+}
+
 // New returns a new nsfw-server extractor.
 func New(config *Config, getter utils.HTTPBodyGetter, instr *instr.Instrumentation) extractor.Extractor {
-	return &Extractor{
-		config,
-		getter,
-		instr,
+	e := &Extractor{
+		getter:          getter,
+		Instrumentation: instr,
+		log:             instr.Component("extractor").With("extractor", "nsfw"),
 	}
+
+	e.config.Store(config)
+
+	return e
 }
 
 // Compile-time assurance that implementation satisfies interface.
 var _ extractor.Extractor = &Extractor{}
+var _ extractor.Versioned = &Extractor{}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/url"
+	"sync/atomic"
+
+	"golang.org/x/exp/slog"
 
 	"github.com/ipfs-search/ipfs-search/components/extractor"
 	"github.com/ipfs-search/ipfs-search/components/protocol"
@@ -16,16 +18,21 @@ import (
 
 // Extractor extracts metadata using the ipfs-tika server.
 type Extractor struct {
-	config   *Config
+	config   atomic.Pointer[Config]
 	getter   utils.HTTPBodyGetter
 	protocol protocol.Protocol
 
 	*instr.Instrumentation
+	log *slog.Logger
 }
 
-func (e *Extractor) getExtractURL(r *t.AnnotatedResource) string {
-	gwURL := e.protocol.GatewayURL(r)
-	return fmt.Sprintf("%s/extract?url=%s", e.config.TikaExtractorURL, url.QueryEscape(gwURL))
+func (e *Extractor) getExtractURL(r *t.AnnotatedResource) (string, error) {
+	gwURL, err := e.protocol.GatewayURL(r)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/extract?url=%s", e.config.Load().TikaExtractorURL, url.QueryEscape(gwURL)), nil
 }
 
 // Extract metadata from a (potentially) referenced resource, updating
@@ -34,15 +41,20 @@ func (e *Extractor) Extract(ctx context.Context, r *t.AnnotatedResource, m inter
 	ctx, span := e.Tracer.Start(ctx, "extractor.tika.Extract")
 	defer span.End()
 
-	if err := extractor.ValidateMaxSize(ctx, r, e.config.MaxFileSize); err != nil {
+	if err := extractor.ValidateMaxSize(ctx, r, e.config.Load().MaxFileSize); err != nil {
 		return err
 	}
 
 	// Timeout if extraction hasn't fully completed within this time.
-	ctx, cancel := context.WithTimeout(ctx, e.config.RequestTimeout)
+	ctx, cancel := context.WithTimeout(ctx, e.config.Load().RequestTimeout)
 	defer cancel()
 
-	body, err := e.getter.GetBody(ctx, e.getExtractURL(r), 200)
+	extractURL, err := e.getExtractURL(r)
+	if err != nil {
+		return err
+	}
+
+	body, err := e.getter.GetBody(ctx, extractURL, 200)
 	if err != nil {
 		return err
 	}
@@ -55,20 +67,38 @@ func (e *Extractor) Extract(ctx context.Context, r *t.AnnotatedResource, m inter
 		return err
 	}
 
-	log.Printf("Got tika metadata metadata for '%v'", r)
+	e.log.DebugCtx(ctx, "Got Tika metadata", "cid", r.ID)
 
 	return nil
 }
 
+// version identifies the results of this extractor; bump when the output changes.
+const version = "tika-1"
+
+// Version returns the version of the extractor, used as a key for cached results.
+func (e *Extractor) Version() string {
+	return version
+}
+
+// SetConfig replaces the extractor's configuration, applying to subsequent extractions.
+func (e *Extractor) SetConfig(config *Config) {
+	e.config.Store(config)
+}
+
 // New returns a new Tika extractor.
 func New(config *Config, getter utils.HTTPBodyGetter, protocol protocol.Protocol, instr *instr.Instrumentation) extractor.Extractor {
-	return &Extractor{
-		config,
-		getter,
-		protocol,
-		instr,
+	e := &Extractor{
+		getter:          getter,
+		protocol:        protocol,
+		Instrumentation: instr,
+		log:             instr.Component("extractor").With("extractor", "tika"),
 	}
+
+	e.config.Store(config)
+
+	return e
 }
 
 // Compile-time assurance that implementation satisfies interface.
 var _ extractor.Extractor = &Extractor{}
+var _ extractor.Versioned = &Extractor{}
@@ -101,7 +101,7 @@ func (s *TikaTestSuite) TestExtract() {
 
     s.protocol.
         On("GatewayURL", r).
-        Return(gwURL).
+        Return(gwURL, nil).
         Once()
 
     s.mockAPIHandler.
@@ -166,7 +166,7 @@ func (s *TikaTestSuite) TestExtractUpstreamError() {
 
     s.protocol.
         On("GatewayURL", r).
-        Return(gwURL).
+        Return(gwURL, nil).
         Once()
 
     // Closing server early, generates a request error.
@@ -200,7 +200,7 @@ func (s *TikaTestSuite) TestURLEscape() {
 
     s.protocol.
         On("GatewayURL", r).
-        Return(gwURL).
+        Return(gwURL, nil).
         Once()
 
     s.mockAPIHandler.
@@ -232,7 +232,7 @@ func (s *TikaTestSuite) TestTika500() {
 
     s.protocol.
         On("GatewayURL", r).
-        Return(gwURL).
+        Return(gwURL, nil).
         Once()
 
     s.mockAPIHandler.
@@ -269,7 +269,7 @@ func (s *TikaTestSuite) TestExtractInvalidJSON() {
 
     s.protocol.
         On("GatewayURL", r).
-        Return(gwURL).
+        Return(gwURL, nil).
         Once()
 
     s.mockAPIHandler.
@@ -0,0 +1,61 @@
+// Package bolt implements an embedded, on-disk, key/value Index for crawling without external dependencies. It
+// does not support full-text search.
+package bolt
+
+import (
+	"context"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ipfs-search/ipfs-search/components/index"
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+// Client represents a bolt database, holding one bucket per index.
+type Client struct {
+	cfg *ClientConfig
+	*instr.Instrumentation
+
+	db *bolt.DB
+}
+
+// NewClient instantiates a new bolt client.
+func NewClient(cfg *ClientConfig, i *instr.Instrumentation) (*Client, error) {
+	if cfg == nil {
+		panic("NewClient ClientConfig cannot be nil.")
+	}
+
+	if cfg.Path == "" {
+		panic("No bolt database path specified.")
+	}
+
+	if i == nil {
+		panic("NewClient Instrumentation cannot be nil.")
+	}
+
+	return &Client{
+		cfg:             cfg,
+		Instrumentation: i,
+	}, nil
+}
+
+// Start opens the database.
+func (c *Client) Start(ctx context.Context) error {
+	var err error
+
+	c.db, err = bolt.Open(c.cfg.Path, 0600, &bolt.Options{
+		Timeout: c.cfg.LockTimeout,
+	})
+
+	return err
+}
+
+// NewIndex returns a new index with the given name.
+func (c *Client) NewIndex(name string) index.Index {
+	return New(c, &Config{Name: name})
+}
+
+// Close closes the database.
+func (c *Client) Close(ctx context.Context) error {
+	return c.db.Close()
+}
@@ -0,0 +1,24 @@
+package bolt
+
+import (
+	"time"
+)
+
+// ClientConfig contains configuration for the bolt client.
+type ClientConfig struct {
+	Path        string        // Path of the database file; created when it doesn't exist.
+	LockTimeout time.Duration // Time to wait for a lock on the database file, held by other processes.
+}
+
+// Config holds configuration for a bolt index.
+type Config struct {
+	Name string // Name of the index, used as the name of its bucket.
+}
+
+// DefaultClientConfig returns the default configuration for the bolt client.
+func DefaultClientConfig() *ClientConfig {
+	return &ClientConfig{
+		Path:        "ipfs-search.db",
+		LockTimeout: 10 * time.Second,
+	}
+}
@@ -0,0 +1,200 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ipfs-search/ipfs-search/components/index"
+)
+
+// Index stores properties as JSON documents in a bolt bucket.
+type Index struct {
+	cfg *Config
+	c   *Client
+}
+
+// New returns a new index.
+func New(client *Client, cfg *Config) index.Index {
+	if client == nil {
+		panic("client cannot be nil")
+	}
+
+	if cfg == nil {
+		panic("cfg cannot be nil")
+	}
+
+	if cfg.Name == "" {
+		panic("Name cannot be empty")
+	}
+
+	return &Index{
+		c:   client,
+		cfg: cfg,
+	}
+}
+
+// String returns the name of the index, for convenient logging.
+func (i *Index) String() string {
+	return i.cfg.Name
+}
+
+// document represents a stored document by its top-level fields.
+type document map[string]json.RawMessage
+
+func toDocument(properties interface{}) (document, error) {
+	data, err := json.Marshal(properties)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := make(document)
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// write stores properties in the bucket for the index; when merge is true,
+// they are merged with the (top-level) fields of an existing document.
+func (i *Index) write(id string, properties interface{}, merge bool) error {
+	doc, err := toDocument(properties)
+	if err != nil {
+		return err
+	}
+
+	return i.c.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(i.cfg.Name))
+		if err != nil {
+			return err
+		}
+
+		key := []byte(id)
+
+		if existing := b.Get(key); merge && existing != nil {
+			merged := make(document)
+			if err := json.Unmarshal(existing, &merged); err != nil {
+				return err
+			}
+
+			for field, value := range doc {
+				merged[field] = value
+			}
+
+			doc = merged
+		}
+
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+
+		return b.Put(key, data)
+	})
+}
+
+// Index a document's properties, identified by id, replacing any existing document.
+func (i *Index) Index(ctx context.Context, id string, properties interface{}) error {
+	_, span := i.c.Tracer.Start(ctx, "index.bolt.Index")
+	defer span.End()
+
+	return i.write(id, properties, false)
+}
+
+// Update a document's properties, given id. Like partial updates in OpenSearch, top-level fields
+// are replaced while other fields are retained; unlike OpenSearch, missing documents are created.
+func (i *Index) Update(ctx context.Context, id string, properties interface{}) error {
+	_, span := i.c.Tracer.Start(ctx, "index.bolt.Update")
+	defer span.End()
+
+	return i.write(id, properties, true)
+}
+
+// Delete item from index
+func (i *Index) Delete(ctx context.Context, id string) error {
+	_, span := i.c.Tracer.Start(ctx, "index.bolt.Delete")
+	defer span.End()
+
+	return i.c.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(i.cfg.Name))
+		if b == nil {
+			return nil
+		}
+
+		return b.Delete([]byte(id))
+	})
+}
+
+// Get retreives `fields` from document with `id` from the index, or all fields when none are specified, returning:
+// - (true, decoding_error) if found (decoding error set when errors in json)
+// - (false, nil) when not found
+// - (false, error) otherwise
+func (i *Index) Get(ctx context.Context, id string, dst interface{}, fields ...string) (bool, error) {
+	_, span := i.c.Tracer.Start(ctx, "index.bolt.Get")
+	defer span.End()
+
+	var data []byte
+
+	err := i.c.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(i.cfg.Name))
+		if b == nil {
+			return nil
+		}
+
+		if v := b.Get([]byte(id)); v != nil {
+			// Values are only valid during the transaction.
+			data = make([]byte, len(v))
+			copy(data, v)
+		}
+
+		return nil
+	})
+
+	if err != nil || data == nil {
+		return false, err
+	}
+
+	if len(fields) > 0 {
+		doc := make(document)
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return true, err
+		}
+
+		selected := make(document, len(fields))
+		for _, field := range fields {
+			if value, ok := doc[field]; ok {
+				selected[field] = value
+			}
+		}
+
+		if data, err = json.Marshal(selected); err != nil {
+			return true, err
+		}
+	}
+
+	return true, json.Unmarshal(data, dst)
+}
+
+// Count returns the number of documents in the index.
+func (i *Index) Count(ctx context.Context) (int64, error) {
+	_, span := i.c.Tracer.Start(ctx, "index.bolt.Count")
+	defer span.End()
+
+	var n int64
+
+	err := i.c.db.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket([]byte(i.cfg.Name)); b != nil {
+			n = int64(b.Stats().KeyN)
+		}
+
+		return nil
+	})
+
+	return n, err
+}
+
+// Compile-time assurance that implementation satisfies interface.
+var _ index.Index = &Index{}
+var _ index.Counter = &Index{}
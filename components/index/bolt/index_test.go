@@ -0,0 +1,149 @@
+package bolt
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/components/index/types"
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+const testID = "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp"
+
+type BoltTestSuite struct {
+	suite.Suite
+	ctx context.Context
+	now time.Time
+
+	c *Client
+	i *Index
+}
+
+func (s *BoltTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.now = time.Now().Truncate(time.Second).UTC()
+
+	cfg := DefaultClientConfig()
+	cfg.Path = filepath.Join(s.T().TempDir(), "test.db")
+
+	var err error
+	s.c, err = NewClient(cfg, instr.New())
+	s.Require().NoError(err)
+	s.Require().NoError(s.c.Start(s.ctx))
+
+	s.i = s.c.NewIndex("files").(*Index)
+}
+
+func (s *BoltTestSuite) TearDownTest() {
+	s.NoError(s.c.Close(s.ctx))
+}
+
+func (s *BoltTestSuite) testDocument() *types.Document {
+	return &types.Document{
+		FirstSeen: s.now,
+		LastSeen:  s.now,
+		References: types.References{
+			{ParentHash: "QmafrLBfzRLV4XSH1XcaMMeaXEUhDJjmtDfsYU95TrWG87", Name: "file.txt"},
+		},
+		Size: 15,
+	}
+}
+
+func (s *BoltTestSuite) TestGetNotFound() {
+	// Bucket doesn't exist yet.
+	found, err := s.i.Get(s.ctx, testID, new(types.Document))
+	s.NoError(err)
+	s.False(found)
+
+	s.NoError(s.i.Index(s.ctx, "other", s.testDocument()))
+
+	found, err = s.i.Get(s.ctx, testID, new(types.Document))
+	s.NoError(err)
+	s.False(found)
+}
+
+func (s *BoltTestSuite) TestIndexGet() {
+	doc := s.testDocument()
+	s.NoError(s.i.Index(s.ctx, testID, doc))
+
+	dst := new(types.Document)
+	found, err := s.i.Get(s.ctx, testID, dst)
+	s.NoError(err)
+	s.True(found)
+	s.Equal(doc, dst)
+}
+
+func (s *BoltTestSuite) TestGetFields() {
+	s.NoError(s.i.Index(s.ctx, testID, s.testDocument()))
+
+	dst := new(types.Update)
+	found, err := s.i.Get(s.ctx, testID, dst, "last-seen")
+	s.NoError(err)
+	s.True(found)
+
+	s.Equal(s.now, *dst.LastSeen)
+	s.Empty(dst.References)
+}
+
+func (s *BoltTestSuite) TestUpdate() {
+	s.NoError(s.i.Index(s.ctx, testID, s.testDocument()))
+
+	later := s.now.Add(time.Hour)
+	s.NoError(s.i.Update(s.ctx, testID, &types.Update{LastSeen: &later}))
+
+	dst := new(types.Document)
+	found, err := s.i.Get(s.ctx, testID, dst)
+	s.NoError(err)
+	s.True(found)
+
+	// Updated fields are replaced, others retained.
+	s.Equal(later, dst.LastSeen)
+	s.Equal(s.now, dst.FirstSeen)
+	s.Equal(uint64(15), dst.Size)
+	s.Len(dst.References, 1)
+}
+
+func (s *BoltTestSuite) TestDelete() {
+	// Deleting from a non-existing bucket is a no-op.
+	s.NoError(s.i.Delete(s.ctx, testID))
+
+	s.NoError(s.i.Index(s.ctx, testID, s.testDocument()))
+	s.NoError(s.i.Delete(s.ctx, testID))
+
+	found, err := s.i.Get(s.ctx, testID, new(types.Document))
+	s.NoError(err)
+	s.False(found)
+}
+
+func (s *BoltTestSuite) TestIndexesSeparated() {
+	other := s.c.NewIndex("directories")
+
+	s.NoError(s.i.Index(s.ctx, testID, s.testDocument()))
+
+	found, err := other.Get(s.ctx, testID, new(types.Document))
+	s.NoError(err)
+	s.False(found)
+}
+
+func (s *BoltTestSuite) TestCount() {
+	// Bucket doesn't exist yet.
+	n, err := s.i.Count(s.ctx)
+	s.NoError(err)
+	s.Zero(n)
+
+	s.NoError(s.i.Index(s.ctx, testID, s.testDocument()))
+	s.NoError(s.i.Index(s.ctx, "other", s.testDocument()))
+	s.NoError(s.c.NewIndex("directories").Index(s.ctx, testID, s.testDocument()))
+
+	n, err = s.i.Count(s.ctx)
+	s.NoError(err)
+	s.Equal(int64(2), n)
+}
+
+func TestBoltTestSuite(t *testing.T) {
+	suite.Run(t, new(BoltTestSuite))
+}
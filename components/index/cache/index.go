@@ -3,15 +3,14 @@ package cache
 import (
 	"context"
 	"fmt"
-	"log"
 	"reflect"
 
+	"golang.org/x/exp/slog"
+
 	"github.com/ipfs-search/ipfs-search/components/index"
 	"github.com/ipfs-search/ipfs-search/instr"
 )
 
-const debug bool = false
-
 // Index wraps a backing index and caches it using another index.
 type Index struct {
 	backingIndex index.Index
@@ -19,6 +18,7 @@ type Index struct {
 	cachingType  reflect.Type
 
 	*instr.Instrumentation
+	log *slog.Logger
 }
 
 // New returns a new index.
@@ -38,6 +38,7 @@ func New(backing index.Index, caching index.Index, cachingType interface{}, inst
 		cachingIndex:    caching,
 		cachingType:     t,
 		Instrumentation: instr,
+		log:             instr.Component("cache"),
 	}
 
 	return index
@@ -48,6 +49,25 @@ func (i *Index) String() string {
 	return fmt.Sprintf("'%s' through '%s'", i.backingIndex, i.cachingIndex)
 }
 
+// Backing returns the backing index, which is the source of truth.
+func (i *Index) Backing() index.Index {
+	return i.backingIndex
+}
+
+// Caching returns the caching index.
+func (i *Index) Caching() index.Index {
+	return i.cachingIndex
+}
+
+// Wrap returns a copy of the index with its backing and caching indexes wrapped by f, e.g. to observe them.
+func (i *Index) Wrap(f func(index.Index) index.Index) *Index {
+	wrapped := *i
+	wrapped.backingIndex = f(i.backingIndex)
+	wrapped.cachingIndex = f(i.cachingIndex)
+
+	return &wrapped
+}
+
 func matchDstKind(src, dst reflect.Value) reflect.Value {
 	dKind, sKind := dst.Kind(), src.Kind()
 
@@ -95,11 +115,6 @@ func (i *Index) makeCachingProperties(props interface{}) interface{} {
 		setFieldVal(src, dst, dstField)
 	}
 
-	// if debug {
-	// 	log.Printf("makeCachingProperties - src: %s: %v", src.Type(), src)
-	// 	log.Printf("makeCachingProperties - dst: %s: %v", dst.Type(), dst)
-	// }
-
 	return dstPtr.Interface()
 }
 
@@ -125,9 +140,7 @@ type indexWrite func(context.Context, string, interface{}) error
 func (i *Index) cacheWrite(ctx context.Context, id string, properties interface{}, f indexWrite) error {
 	cachingProperties := i.makeCachingProperties(properties)
 
-	if debug {
-		log.Printf("cache %s: write %s", i, id)
-	}
+	i.log.DebugCtx(ctx, "Cache write", "index", instr.Sprint(i.cachingIndex), "id", id)
 
 	if err := f(ctx, id, cachingProperties); err != nil {
 		return ErrCache{err, fmt.Sprintf("cache error in writing %+v to %s: %s", cachingProperties, id, err.Error())}
@@ -174,9 +187,7 @@ func (i *Index) Delete(ctx context.Context, id string) error {
 	ctx, span := i.Tracer.Start(ctx, "index.cache.Delete")
 	defer span.End()
 
-	if debug {
-		log.Printf("cache %s: delete %s", i, id)
-	}
+	i.log.DebugCtx(ctx, "Cache delete", "index", instr.Sprint(i.cachingIndex), "id", id)
 
 	// Delete cache first; maintain consistency as our backing index is the source of truth.
 	if err := i.cachingIndex.Delete(ctx, id); err != nil {
@@ -206,16 +217,12 @@ func (i *Index) Get(ctx context.Context, id string, dst interface{}, fields ...s
 	)
 
 	if found, err = i.cacheGet(ctx, id, dst, fields...); found {
-		// if debug {
-		log.Printf("cache %s: hit %s", i.cachingIndex, id)
-		// }
+		i.log.DebugCtx(ctx, "Cache hit", "index", instr.Sprint(i.cachingIndex), "id", id)
 
 		return found, err
 	}
 
-	if debug {
-		log.Printf("cache %s: miss %s", i.cachingIndex, id)
-	}
+	i.log.DebugCtx(ctx, "Cache miss", "index", instr.Sprint(i.cachingIndex), "id", id)
 
 	var backingErr error
 	if found, backingErr = i.backingIndex.Get(ctx, id, dst, fields...); backingErr != nil {
@@ -223,22 +230,32 @@ func (i *Index) Get(ctx context.Context, id string, dst interface{}, fields ...s
 		err = backingErr
 	}
 
-	if found {
-		if debug {
-			log.Printf("backing %s: hit %s", i.backingIndex, id)
-		}
+	if !found {
+		i.log.DebugCtx(ctx, "Backing miss", "index", instr.Sprint(i.backingIndex), "id", id)
 
-		if indexErr := i.cacheWrite(ctx, id, dst, i.cachingIndex.Index); indexErr != nil {
-			err = indexErr
-		}
+		return found, err
 	}
 
-	if debug {
-		log.Printf("backing %s: miss %s", i.backingIndex, id)
+	i.log.DebugCtx(ctx, "Backing hit", "index", instr.Sprint(i.backingIndex), "id", id)
+
+	if indexErr := i.cacheWrite(ctx, id, dst, i.cachingIndex.Index); indexErr != nil {
+		err = indexErr
 	}
 
 	return found, err
 }
 
+// Count returns the number of documents in the backing index.
+// Returns ErrNotCounter when the backing index cannot count its documents.
+func (i *Index) Count(ctx context.Context) (int64, error) {
+	c, ok := i.backingIndex.(index.Counter)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", index.ErrNotCounter, i.backingIndex)
+	}
+
+	return c.Count(ctx)
+}
+
 // Compile-time assurance that implementation satisfies interface.
 var _ index.Index = &Index{}
+var _ index.Counter = &Index{}
@@ -64,6 +64,7 @@ func (s *CacheTestSuite) SetupTest() {
 		cachingIndex:    s.cachingIndex,
 		cachingType:     reflect.TypeOf(cacheStruct{}),
 		Instrumentation: s.instr,
+		log:             s.instr.Component("cache"),
 	}
 }
 
@@ -260,6 +261,29 @@ func (s *CacheTestSuite) AfterTest() {
 	s.backingIndex.AssertExpectations(s.T())
 }
 
+func (s *CacheTestSuite) TestBackingCaching() {
+	s.Equal(s.backingIndex, s.i.Backing())
+	s.Equal(s.cachingIndex, s.i.Caching())
+}
+
+func (s *CacheTestSuite) TestWrap() {
+	wrapper := &index.Mock{}
+
+	wrapped := s.i.Wrap(func(i index.Index) index.Index {
+		s.Contains([]index.Index{s.backingIndex, s.cachingIndex}, i)
+		return wrapper
+	})
+
+	s.Equal(wrapper, wrapped.Backing())
+	s.Equal(wrapper, wrapped.Caching())
+	s.Equal(s.cachingIndex, s.i.Caching())
+}
+
+func (s *CacheTestSuite) TestCountNotCounter() {
+	_, err := s.i.Count(s.ctx)
+	s.ErrorIs(err, index.ErrNotCounter)
+}
+
 func TestCacheTestSuite(t *testing.T) {
 	suite.Run(t, new(CacheTestSuite))
 }
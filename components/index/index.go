@@ -3,8 +3,12 @@ package index
 
 import (
 	"context"
+	"errors"
 )
 
+// ErrNotCounter is returned when counting documents is not supported by an index.
+var ErrNotCounter = errors.New("index does not support counting")
+
 // Index represents an index which stores and retrieves document properties.
 type Index interface {
 	Index(ctx context.Context, id string, properties interface{}) error
@@ -12,3 +16,8 @@ type Index interface {
 	Get(ctx context.Context, id string, dst interface{}, fields ...string) (bool, error)
 	Delete(ctx context.Context, id string) error
 }
+
+// Counter is implemented by indexes which can count the documents they contain.
+type Counter interface {
+	Count(ctx context.Context) (int64, error)
+}
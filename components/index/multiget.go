@@ -3,12 +3,12 @@ package index
 import (
 	"context"
 	"errors"
-	"log"
 
+	"golang.org/x/exp/slog"
 	"golang.org/x/sync/errgroup"
-)
 
-const debug bool = false
+	"github.com/ipfs-search/ipfs-search/instr"
+)
 
 func contextDone(ctx context.Context, err error) bool {
 	ctxErr := ctx.Err()
@@ -27,14 +27,14 @@ func MultiGet(ctx context.Context, indexes []Index, id string, dst interface{},
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel() // cancel when we are finished
 
+	log := slog.Default().With(instr.ComponentKey, "index")
+
 	g, groupCtx := errgroup.WithContext(ctx)
 	for _, i := range indexes {
 		i := i // https://go.dev/doc/faq#closures_and_goroutines
 
 		g.Go(func() error {
-			if debug {
-				log.Printf("MultiGet %s index %s", id, i)
-			}
+			log.DebugCtx(groupCtx, "MultiGet", "id", id, "index", instr.Sprint(i))
 
 			found, err := i.Get(groupCtx, id, dst, fields...)
 
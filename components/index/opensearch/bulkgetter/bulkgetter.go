@@ -3,18 +3,20 @@ package bulkgetter
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/opensearch-project/opensearch-go/v2"
-)
+	"golang.org/x/exp/slog"
 
-const debug bool = false
+	"github.com/ipfs-search/ipfs-search/instr"
+)
 
 // BulkGetter allows batching/bulk gets.
 type BulkGetter struct {
 	cfg   Config
 	queue chan reqresp
+	*instr.Instrumentation
+	log *slog.Logger
 }
 
 // New returns a new BulkGetter, setting sensible defaults for the configuration.
@@ -31,9 +33,13 @@ func New(cfg Config) *BulkGetter {
 		cfg.BatchTimeout = 100 * time.Millisecond
 	}
 
+	i := instr.New()
+
 	bg := BulkGetter{
-		cfg:   cfg,
-		queue: make(chan reqresp, 5*cfg.BatchSize),
+		cfg:             cfg,
+		queue:           make(chan reqresp, 5*cfg.BatchSize),
+		Instrumentation: i,
+		log:             i.Component("bulkgetter"),
 	}
 
 	return &bg
@@ -52,13 +58,13 @@ func (bg *BulkGetter) Get(ctx context.Context, req *GetRequest, dst interface{})
 func (bg *BulkGetter) Work(ctx context.Context) error {
 	var err error
 
-	log.Println("Starting worker for BulkGetter.")
+	bg.log.Info("Starting worker for BulkGetter")
 
 	for err == nil {
 		err = bg.processBatch(ctx)
 	}
 
-	log.Printf("BulkGetter worker exiting, error: %s", err)
+	bg.log.Info("BulkGetter worker exiting", "err", err)
 
 	return err
 }
@@ -74,38 +80,32 @@ func (bg *BulkGetter) processBatch(ctx context.Context) error {
 		return err
 	}
 
+	if n := len(b.rrs); n > 0 {
+		bg.Metrics.BulkGetterBatches.Record(ctx, int64(n))
+	}
+
 	return b.execute()
 }
 
 func (bg *BulkGetter) populateBatch(ctx context.Context, queue <-chan reqresp) (*bulkRequest, error) {
-	if debug {
-		log.Println("bulkgetter: populating BulkGetter batch.")
-	}
+	bg.log.DebugCtx(ctx, "Populating batch")
 
-	b := newBulkRequest(ctx, bg.cfg.Client, bg.cfg.BatchSize)
+	b := newBulkRequest(ctx, bg.cfg.Client, bg.cfg.BatchSize, bg.log)
 
 	for i := 0; i < bg.cfg.BatchSize; i++ {
 		select {
 		case <-ctx.Done():
-			if debug {
-				log.Printf("bulkgetter: context closed in populateBatch")
-			}
+			bg.log.DebugCtx(ctx, "Context closed in populateBatch")
 			return b, ctx.Err()
 		case <-time.After(bg.cfg.BatchTimeout):
-			if debug {
-				log.Printf("bulkgetter: Batch timeout, %d elements", len(b.rrs))
-			}
+			bg.log.DebugCtx(ctx, "Batch timeout", "requests", len(b.rrs))
 
 			return b, nil
 		case rr := <-queue:
-			if debug {
-				log.Printf("bulkgetter: Batch add, %d elements", len(b.rrs))
-			}
+			bg.log.DebugCtx(ctx, "Batch add", "requests", len(b.rrs))
 
 			if err := b.add(rr); err != nil {
-				if debug {
-					log.Printf("bulkgetter: error %s", err.Error())
-				}
+				bg.log.DebugCtx(ctx, "Error adding to batch", "err", err)
 				return b, err
 			}
 		}
@@ -7,11 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"sync"
 
 	"github.com/opensearch-project/opensearch-go/v2"
 	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+	"golang.org/x/exp/slog"
 )
 
 // ErrHTTP represents non-404 errors in HTTP requests.
@@ -25,9 +25,10 @@ type bulkRequest struct {
 	rrs         reqrespmap
 	decodeMutex sync.Mutex
 	aliases     map[string]string
+	log         *slog.Logger
 }
 
-func newBulkRequest(ctx context.Context, client *opensearch.Client, size int) *bulkRequest {
+func newBulkRequest(ctx context.Context, client *opensearch.Client, size int, log *slog.Logger) *bulkRequest {
 	if ctx == nil {
 		panic("required context is nil")
 	}
@@ -37,6 +38,7 @@ func newBulkRequest(ctx context.Context, client *opensearch.Client, size int) *b
 		client:  client,
 		rrs:     make(reqrespmap, size),
 		aliases: make(map[string]string),
+		log:     log,
 	}
 }
 
@@ -146,10 +148,7 @@ func (r *bulkRequest) sendResponse(key string, found bool, err error) {
 		panic(fmt.Sprintf("Invalid value for response channel for reqresp %v", rr))
 	}
 
-	if debug {
-		log.Printf("bulkrequest: Sending response for %v", &rr)
-		defer log.Printf("bulkrequest: Done sending response")
-	}
+	r.log.DebugCtx(rr.ctx, "Sending response", "index", rr.req.Index, "id", rr.req.DocumentID, "found", found)
 
 	rr.resp <- GetResponse{found, err}
 	close(rr.resp)
@@ -206,11 +205,6 @@ func (r *bulkRequest) getRequest() *opensearchapi.MgetRequest {
 }
 
 func decodeResponse(res *opensearchapi.Response) ([]responseDoc, error) {
-	if debug {
-		log.Printf("bulkrequest: Decoding response to bulk GET")
-		defer log.Printf("bulkrequest: Done decoding response to bulk GET")
-	}
-
 	response := struct {
 		Docs []responseDoc `json:"docs"`
 	}{}
@@ -240,9 +234,7 @@ func (r *bulkRequest) processResponseDoc(d *responseDoc, key string) (bool, erro
 	}
 
 	if err := rr.ctx.Err(); err != nil {
-		if debug {
-			log.Printf("bulkrequest: Not writing response from bulk get, request context cancelled.")
-		}
+		r.log.DebugCtx(r.ctx, "Not writing response from bulk get, request context canceled", "index", rr.req.Index, "id", rr.req.DocumentID)
 
 		return false, err
 
@@ -262,11 +254,6 @@ func (r *bulkRequest) processResponseDoc(d *responseDoc, key string) (bool, erro
 }
 
 func (r *bulkRequest) processResponse(res *opensearchapi.Response) error {
-	if debug {
-		log.Printf("bulkrequest: processing response to bulk GET")
-		defer log.Printf("bulkrequest: done processing response to bulk GET")
-	}
-
 	var err error
 
 	if res.StatusCode == 200 {
@@ -276,9 +263,7 @@ func (r *bulkRequest) processResponse(res *opensearchapi.Response) error {
 			return err
 		}
 
-		if debug {
-			log.Printf("bulkrequest: Processing %d returned documents", len(docs))
-		}
+		r.log.DebugCtx(r.ctx, "Processing response to bulk get", "documents", len(docs))
 
 		for _, d := range docs {
 			key := r.keyFromResponseDoc(&d)
@@ -305,10 +290,8 @@ func (r *bulkRequest) removeCanceled() {
 
 	for key, rr := range r.rrs {
 		if err := rr.ctx.Err(); err != nil {
-			if debug {
-				log.Printf("bulkrequest: request canceled, removing %v", &rr)
-				removed++
-			}
+			r.log.DebugCtx(r.ctx, "Request canceled, removing", "index", rr.req.Index, "id", rr.req.DocumentID)
+			removed++
 
 			// Send response, cleaning up resources.
 			r.sendResponse(key, false, err)
@@ -318,8 +301,8 @@ func (r *bulkRequest) removeCanceled() {
 		}
 	}
 
-	if debug && removed > 0 {
-		log.Printf("bulkrequest: removed %d canceled requests", removed)
+	if removed > 0 {
+		r.log.DebugCtx(r.ctx, "Removed canceled requests", "requests", removed)
 	}
 }
 
@@ -327,16 +310,12 @@ func (r *bulkRequest) execute() error {
 	r.removeCanceled()
 
 	if len(r.rrs) == 0 {
-		if debug {
-			log.Printf("bulkrequest: empty request map, not sending request")
-		}
+		r.log.DebugCtx(r.ctx, "Empty request map, not sending request")
 
 		return nil
 	}
 
-	if debug {
-		log.Printf("bulkrequest: performing bulk GET, %d elements", len(r.rrs))
-	}
+	r.log.DebugCtx(r.ctx, "Performing bulk get", "requests", len(r.rrs))
 
 	res, err := r.getRequest().Do(r.ctx, r.client)
 	if err != nil {
@@ -14,6 +14,8 @@ import (
 	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/instr"
 )
 
 type BulkRequestTestSuite struct {
@@ -122,7 +124,7 @@ func (s *BulkRequestTestSuite) TestGetRequest() {
 	s.expectResolveAlias("test1", "test1")
 	s.expectResolveAlias("test2", "test2")
 
-	br := newBulkRequest(s.ctx, s.client, 2)
+	br := newBulkRequest(s.ctx, s.client, 2, instr.New().Logger)
 
 	err := br.add(s.reqresp1)
 	s.NoError(err)
@@ -163,7 +165,7 @@ func (s *BulkRequestTestSuite) TestProcessResponseFound() {
 	s.expectResolveAlias("test1", "test1")
 	s.expectResolveAlias("test2", "test2")
 
-	br := newBulkRequest(s.ctx, s.client, 2)
+	br := newBulkRequest(s.ctx, s.client, 2, instr.New().Logger)
 
 	err := br.add(s.reqresp1)
 	s.NoError(err)
@@ -218,7 +220,7 @@ func (s *BulkRequestTestSuite) TestProcessResponseFound() {
 func (s *BulkRequestTestSuite) TestResolveIndex() {
 	s.expectResolveAlias("test1", "actual_index")
 
-	br := newBulkRequest(s.ctx, s.client, 1)
+	br := newBulkRequest(s.ctx, s.client, 1, instr.New().Logger)
 	s.NoError(br.add(s.reqresp1))
 
 	respStr := `{
@@ -2,19 +2,24 @@ package opensearch
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/jpillora/backoff"
 	opensearch "github.com/opensearch-project/opensearch-go/v2"
 	opensearchtransport "github.com/opensearch-project/opensearch-go/v2/opensearchtransport"
 	opensearchutil "github.com/opensearch-project/opensearch-go/v2/opensearchutil"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
 
 	"github.com/ipfs-search/ipfs-search/components/index"
 	"github.com/ipfs-search/ipfs-search/components/index/opensearch/bulkgetter"
 	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
 )
 
 // Client for search index.
@@ -24,6 +29,7 @@ type Client struct {
 	bulkGetter   bulkgetter.AsyncGetter
 
 	*instr.Instrumentation
+	log *slog.Logger
 }
 
 // ClientConfig configures search index.
@@ -74,16 +80,49 @@ func NewClient(cfg *ClientConfig, i *instr.Instrumentation) (*Client, error) {
 		bulkIndexer:     bi,
 		bulkGetter:      bg,
 		Instrumentation: i,
+		log:             i.Component("opensearch"),
 	}, nil
 }
 
-// Work starts (and closes) a client worker.
+// Work starts a client worker, closing the client when ctx is closed. It should be restarted when it returns an
+// error before that.
 func (c *Client) Work(ctx context.Context) error {
-	// Flush indexing buffers on context close.
+	err := c.bulkGetter.Work(ctx)
+
+	if ctx.Err() != nil {
+		// Only close once; the bulk indexer cannot be used after it has been closed.
+		c.closeBulkIndexer()
+	}
+
+	return err
+}
+
+// closeBulkIndexer flushes indexing buffers on context close, logging the totals of the bulk indexer.
+func (c *Client) closeBulkIndexer() {
 	// Use background context because current context is already closed.
-	defer c.bulkIndexer.Close(context.Background())
+	if err := c.bulkIndexer.Close(context.Background()); err != nil {
+		c.log.Error("Error flushing index buffer", "err", err)
+	}
 
-	return c.bulkGetter.Work(ctx)
+	stats := c.bulkIndexer.Stats()
+	c.log.Info("Closed bulk indexer", "flushed", stats.NumFlushed, "failed", stats.NumFailed, "requests", stats.NumRequests)
+}
+
+// Ping returns an error when OpenSearch cannot be reached or responds with an error.
+func (c *Client) Ping(ctx context.Context) error {
+	ping := c.searchClient.Ping
+
+	res, err := ping(ping.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("%w: %v", t.ErrRequest, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("%w: %s", t.ErrUnexpectedResponse, res.Status())
+	}
+
+	return nil
 }
 
 // NewIndex returns a new index given with given name.
@@ -126,7 +165,28 @@ func getSearchClient(cfg *ClientConfig, i *instr.Instrumentation) (*opensearch.C
 	return opensearch.NewClient(clientConfig)
 }
 
+// flushKey is the context key for the number of documents in a bulk indexer flush.
+type flushKey struct{}
+
+// countFlushed counts a document in the flush of the bulk indexer with ctx.
+func countFlushed(ctx context.Context) {
+	if n, ok := ctx.Value(flushKey{}).(*int64); ok {
+		atomic.AddInt64(n, 1)
+	}
+}
+
+// flushed returns the number of documents in the flush of the bulk indexer with ctx.
+func flushed(ctx context.Context) int64 {
+	if n, ok := ctx.Value(flushKey{}).(*int64); ok {
+		return atomic.LoadInt64(n)
+	}
+
+	return 0
+}
+
 func getBulkIndexer(client *opensearch.Client, cfg *ClientConfig, i *instr.Instrumentation) (opensearchutil.BulkIndexer, error) {
+	logger := i.Component("opensearch")
+
 	iCfg := opensearchutil.BulkIndexerConfig{
 		Client:        client,
 		NumWorkers:    cfg.BulkIndexerWorkers,
@@ -134,18 +194,23 @@ func getBulkIndexer(client *opensearch.Client, cfg *ClientConfig, i *instr.Instr
 		FlushInterval: cfg.BulkIndexerFlushTimeout,
 		OnFlushStart: func(ctx context.Context) context.Context {
 			newCtx, _ := i.Tracer.Start(ctx, "index.opensearch.BulkIndexerFlush")
-			return newCtx
+			return context.WithValue(newCtx, flushKey{}, new(int64))
 		},
 		OnError: func(ctx context.Context, err error) {
 			span := trace.SpanFromContext(ctx)
 			span.RecordError(err)
-			log.Printf("Error flushing index buffer: %s", err)
+			i.Metrics.BulkIndexerErrors.Add(ctx, 1, attribute.String("kind", "flush"))
+			logger.ErrorCtx(ctx, "Error flushing index buffer", "err", err)
 		},
 		OnFlushEnd: func(ctx context.Context) {
 			span := trace.SpanFromContext(ctx)
-			log.Println("Flushed index buffer")
+			n := flushed(ctx)
+			logger.DebugCtx(ctx, "Flushed index buffer", "documents", n)
+
+			if n > 0 {
+				i.Metrics.BulkIndexerFlushes.Record(ctx, n)
+			}
 
-			// log.Printf("ES stats: %+v", )
 			span.End()
 		},
 	}
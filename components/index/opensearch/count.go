@@ -0,0 +1,72 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ipfs-search/ipfs-search/components/index"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// Count returns the number of documents in the index.
+func (i *Index) Count(ctx context.Context) (int64, error) {
+	ctx, span := i.c.Tracer.Start(ctx, "index.opensearch.Count")
+	defer span.End()
+
+	count := i.c.searchClient.Count
+	res, err := count(
+		count.WithContext(ctx),
+		count.WithIndex(i.cfg.Name),
+	)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("%w: %v", t.ErrRequest, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		err := fmt.Errorf("%w: %s", t.ErrUnexpectedResponse, res.Status())
+		span.RecordError(err)
+		return 0, err
+	}
+
+	var response struct {
+		Count int64 `json:"count"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("%w: %v", t.ErrUnexpectedResponse, err)
+	}
+
+	return response.Count, nil
+}
+
+// Sample returns the ID's of up to n randomly selected documents in the index.
+func (i *Index) Sample(ctx context.Context, n int) ([]string, error) {
+	body := map[string]interface{}{
+		"size":    n,
+		"_source": false,
+		"query": map[string]interface{}{
+			"function_score": map[string]interface{}{
+				"random_score": struct{}{},
+			},
+		},
+	}
+
+	result, err := i.c.Search(ctx, []string{i.cfg.Name}, body)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(result.Hits))
+	for j, hit := range result.Hits {
+		ids[j] = hit.ID
+	}
+
+	return ids, nil
+}
+
+// Compile-time assurance that implementation satisfies interface.
+var _ index.Counter = &Index{}
@@ -6,17 +6,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 
 	opensearchutil "github.com/opensearch-project/opensearch-go/v2/opensearchutil"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 
 	"github.com/ipfs-search/ipfs-search/components/index"
 	"github.com/ipfs-search/ipfs-search/components/index/opensearch/bulkgetter"
 )
 
-const debug bool = false
-
 // Index wraps an OpenSearch index to store documents
 type Index struct {
 	cfg *Config
@@ -90,6 +88,13 @@ func (i *Index) index(
 		Body:       body,
 		DocumentID: id,
 		Version:    nil,
+		OnSuccess: func(
+			ctx context.Context,
+			item opensearchutil.BulkIndexerItem,
+			res opensearchutil.BulkIndexerResponseItem,
+		) {
+			countFlushed(ctx)
+		},
 		OnFailure: func(
 			ctx context.Context,
 			item opensearchutil.BulkIndexerItem,
@@ -99,9 +104,11 @@ func (i *Index) index(
 				err = fmt.Errorf("Error flushing: %+v (%s)", res, id)
 			}
 
-			span.RecordError(err)
-			log.Println(err)
+			countFlushed(ctx)
+			i.c.Metrics.BulkIndexerErrors.Add(ctx, 1, attribute.String("kind", "document"))
 
+			span.RecordError(err)
+			i.c.log.ErrorCtx(ctx, "Error indexing document", "index", i.cfg.Name, "id", id, "err", err)
 		},
 	}
 
@@ -148,14 +155,11 @@ func (i *Index) Get(ctx context.Context, id string, dst interface{}, fields ...s
 
 	resp := <-i.c.bulkGetter.Get(ctx, &req, dst)
 
-	if debug {
-		if resp.Found {
-			log.Printf("opensearch: found %s in %s", id, i)
-		} else {
-			if resp.Error != nil {
-				log.Printf("opensearch: error getting %s in %s: %v", id, i, resp.Error)
-			}
-		}
+	switch {
+	case resp.Found:
+		i.c.log.DebugCtx(ctx, "Found document", "index", i.cfg.Name, "id", id)
+	case resp.Error != nil:
+		i.c.log.DebugCtx(ctx, "Error getting document", "index", i.cfg.Name, "id", id, "err", resp.Error)
 	}
 
 	return resp.Found, resp.Error
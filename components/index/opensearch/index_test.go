@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/ipfs-search/ipfs-search/components/index/opensearch/bulkgetter"
+	t "github.com/ipfs-search/ipfs-search/types"
 )
 
 type IndexTestSuite struct {
@@ -375,6 +376,191 @@ func (s *IndexTestSuite) TestGetNotFound() {
 	s.mockAsyncGetter.AssertExpectations(s.T())
 }
 
+func (s *IndexTestSuite) TestSearch() {
+	response := []byte(`{
+	  "took": 5,
+	  "timed_out": false,
+	  "hits": {
+	    "total": {"value": 12, "relation": "eq"},
+	    "max_score": 1.5,
+	    "hits": [
+	      {"_index": "files", "_id": "objId", "_score": 1.5, "_source": {"field1": "hoi"}}
+	    ]
+	  }
+	}`)
+
+	s.mockAPIHandler.
+		On("Handle", "POST", "/files,directories/_search", []byte(`{"size":1}`)).
+		Return(httpmock.Response{
+			Body:   response,
+			Header: s.responseHeader,
+		}).
+		Once()
+
+	result, err := s.mockClient.Search(s.ctx, []string{"files", "directories"}, map[string]int{"size": 1})
+	s.NoError(err)
+
+	s.Equal(uint(12), result.Total)
+	s.Equal(1.5, result.MaxScore)
+	s.Len(result.Hits, 1)
+	s.Equal("objId", result.Hits[0].ID)
+	s.Equal("files", result.Hits[0].Index)
+	s.JSONEq(`{"field1": "hoi"}`, string(result.Hits[0].Source))
+
+	s.mockAPIHandler.AssertExpectations(s.T())
+}
+
+func (s *IndexTestSuite) TestSearchError() {
+	s.mockAPIHandler.
+		On("Handle", "POST", "/files/_search", mock.Anything).
+		Return(httpmock.Response{
+			Status: 400,
+			Body:   []byte(`{"error": "bad query"}`),
+			Header: s.responseHeader,
+		}).
+		Once()
+
+	_, err := s.mockClient.Search(s.ctx, []string{"files"}, struct{}{})
+	s.ErrorIs(err, t.ErrUnexpectedResponse)
+	s.ErrorIs(err, ErrBadRequest)
+
+	s.mockAPIHandler.AssertExpectations(s.T())
+}
+
+func (s *IndexTestSuite) TestSearchMisconfigured() {
+	for _, status := range []int{401, 403, 404} {
+		s.mockAPIHandler.
+			On("Handle", "POST", "/files/_search", mock.Anything).
+			Return(httpmock.Response{
+				Status: status,
+				Body:   []byte(`{"error": "misconfigured"}`),
+				Header: s.responseHeader,
+			}).
+			Once()
+
+		_, err := s.mockClient.Search(s.ctx, []string{"files"}, struct{}{})
+		s.ErrorIs(err, t.ErrUnexpectedResponse, status)
+		s.NotErrorIs(err, ErrBadRequest, status)
+	}
+
+	s.mockAPIHandler.AssertExpectations(s.T())
+}
+
+func (s *IndexTestSuite) TestCount() {
+	s.mockAPIHandler.
+		On("Handle", "POST", "/files/_count", mock.Anything).
+		Return(httpmock.Response{
+			Body:   []byte(`{"count": 42, "_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0}}`),
+			Header: s.responseHeader,
+		}).
+		Once()
+
+	idx := New(s.mockClient, &Config{Name: "files"}).(*Index)
+
+	n, err := idx.Count(s.ctx)
+	s.NoError(err)
+	s.Equal(int64(42), n)
+
+	s.mockAPIHandler.AssertExpectations(s.T())
+}
+
+func (s *IndexTestSuite) TestCountError() {
+	s.mockAPIHandler.
+		On("Handle", "POST", "/files/_count", mock.Anything).
+		Return(httpmock.Response{
+			Status: 404,
+			Body:   []byte(`{"error": "no such index"}`),
+			Header: s.responseHeader,
+		}).
+		Once()
+
+	idx := New(s.mockClient, &Config{Name: "files"}).(*Index)
+
+	_, err := idx.Count(s.ctx)
+	s.ErrorIs(err, t.ErrUnexpectedResponse)
+
+	s.mockAPIHandler.AssertExpectations(s.T())
+}
+
+func (s *IndexTestSuite) TestSample() {
+	response := []byte(`{
+	  "hits": {
+	    "total": {"value": 2, "relation": "eq"},
+	    "max_score": 1.0,
+	    "hits": [
+	      {"_index": "files", "_id": "a", "_score": 0.8},
+	      {"_index": "files", "_id": "b", "_score": 0.3}
+	    ]
+	  }
+	}`)
+
+	s.mockAPIHandler.
+		On("Handle", "POST", "/files/_search", []byte(`{"_source":false,"query":{"function_score":{"random_score":{}}},"size":2}`)).
+		Return(httpmock.Response{
+			Body:   response,
+			Header: s.responseHeader,
+		}).
+		Once()
+
+	idx := New(s.mockClient, &Config{Name: "files"}).(*Index)
+
+	ids, err := idx.Sample(s.ctx, 2)
+	s.NoError(err)
+	s.Equal([]string{"a", "b"}, ids)
+
+	s.mockAPIHandler.AssertExpectations(s.T())
+}
+
+func (s *IndexTestSuite) TestScroll() {
+	page1 := []byte(`{
+	  "_scroll_id": "scroll1",
+	  "hits": {"total": {"value": 2}, "hits": [{"_index": "files", "_id": "obj1"}]}
+	}`)
+	page2 := []byte(`{
+	  "_scroll_id": "scroll1",
+	  "hits": {"total": {"value": 2}, "hits": [{"_index": "files", "_id": "obj2"}]}
+	}`)
+	page3 := []byte(`{
+	  "_scroll_id": "scroll1",
+	  "hits": {"total": {"value": 2}, "hits": []}
+	}`)
+
+	s.mockAPIHandler.
+		On("Handle", "POST", "/files/_search?scroll=60000ms", []byte(`{}`)).
+		Return(httpmock.Response{Body: page1, Header: s.responseHeader}).
+		Once()
+
+	s.mockAPIHandler.
+		On("Handle", "POST", "/_search/scroll?scroll=60000ms", []byte(`{"scroll_id":"scroll1"}`)).
+		Return(httpmock.Response{Body: page2, Header: s.responseHeader}).
+		Once()
+
+	s.mockAPIHandler.
+		On("Handle", "POST", "/_search/scroll?scroll=60000ms", []byte(`{"scroll_id":"scroll1"}`)).
+		Return(httpmock.Response{Body: page3, Header: s.responseHeader}).
+		Once()
+
+	s.mockAPIHandler.
+		On("Handle", "DELETE", "/_search/scroll/scroll1", mock.Anything).
+		Return(httpmock.Response{Body: []byte(`{"succeeded": true}`), Header: s.responseHeader}).
+		Once()
+
+	var ids []string
+
+	err := s.mockClient.Scroll(s.ctx, []string{"files"}, struct{}{}, time.Minute, func(hits []SearchHit) error {
+		for _, h := range hits {
+			ids = append(ids, h.ID)
+		}
+
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal([]string{"obj1", "obj2"}, ids)
+
+	s.mockAPIHandler.AssertExpectations(s.T())
+}
+
 func TestIndexTestSuite(t *testing.T) {
 	suite.Run(t, new(IndexTestSuite))
 }
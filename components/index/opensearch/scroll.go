@@ -0,0 +1,100 @@
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// ScrollFunc processes a page of hits from Scroll.
+type ScrollFunc func([]SearchHit) error
+
+// Scroll executes a search with a query DSL request body over one or more indexes, calling f for every
+// page of hits until all hits have been processed or f returns an error. The size of pages is
+// determined by the `size` in the request body. The search context is kept alive for keepAlive
+// in between pages.
+func (c *Client) Scroll(ctx context.Context, indexes []string, body interface{}, keepAlive time.Duration, f ScrollFunc) error {
+	ctx, span := c.Tracer.Start(ctx, "index.opensearch.Scroll")
+	defer span.End()
+
+	reqBody, err := getBody(body)
+	if err != nil {
+		return err
+	}
+
+	search := c.searchClient.Search
+	res, err := search(
+		search.WithContext(ctx),
+		search.WithIndex(indexes...),
+		search.WithBody(reqBody),
+		search.WithScroll(keepAlive),
+	)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("%w: %v", t.ErrRequest, err)
+	}
+
+	result, err := decodeSearchResponse(res, span)
+	res.Body.Close()
+
+	if err != nil {
+		return err
+	}
+
+	defer c.clearScroll(result.scrollID)
+
+	for len(result.Hits) > 0 {
+		if err := f(result.Hits); err != nil {
+			return err
+		}
+
+		// Pass scroll ID in the body, as it might be too large for the URL.
+		scrollBody, err := getBody(map[string]string{"scroll_id": result.scrollID})
+		if err != nil {
+			return err
+		}
+
+		scroll := c.searchClient.Scroll
+		res, err := scroll(
+			scroll.WithContext(ctx),
+			scroll.WithBody(scrollBody),
+			scroll.WithScroll(keepAlive),
+		)
+		if err != nil {
+			span.RecordError(err)
+			return fmt.Errorf("%w: %v", t.ErrRequest, err)
+		}
+
+		result, err = decodeSearchResponse(res, span)
+		res.Body.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// clearScroll releases the resources of a scroll, which would otherwise be kept until the scroll expires.
+func (c *Client) clearScroll(scrollID string) {
+	if scrollID == "" {
+		return
+	}
+
+	clear := c.searchClient.ClearScroll
+
+	// Use background context; scrolls should be cleared even when the context is closed.
+	res, err := clear(
+		clear.WithContext(context.Background()),
+		clear.WithScrollID(scrollID),
+	)
+	if err != nil {
+		c.log.Error("Error clearing scroll", "err", err)
+		return
+	}
+
+	res.Body.Close()
+}
@@ -0,0 +1,102 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+	"go.opentelemetry.io/otel/trace"
+
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// ErrBadRequest is returned when a search is rejected by OpenSearch, e.g. for a malformed query.
+var ErrBadRequest = t.WrappedError{Err: t.ErrUnexpectedResponse, Msg: "bad request"}
+
+// SearchHit represents a single document matching a search.
+type SearchHit struct {
+	Index  string          `json:"_index"`
+	ID     string          `json:"_id"`
+	Score  float64         `json:"_score"`
+	Source json.RawMessage `json:"_source"`
+}
+
+// SearchResult represents the (paginated) result of a search.
+type SearchResult struct {
+	Total    uint
+	MaxScore float64
+	Hits     []SearchHit
+
+	scrollID string
+}
+
+// Search executes a search with a query DSL request body over one or more indexes.
+func (c *Client) Search(ctx context.Context, indexes []string, body interface{}) (*SearchResult, error) {
+	ctx, span := c.Tracer.Start(ctx, "index.opensearch.Search")
+	defer span.End()
+
+	reqBody, err := getBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	search := c.searchClient.Search
+	res, err := search(
+		search.WithContext(ctx),
+		search.WithIndex(indexes...),
+		search.WithBody(reqBody),
+	)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("%w: %v", t.ErrRequest, err)
+	}
+	defer res.Body.Close()
+
+	return decodeSearchResponse(res, span)
+}
+
+// decodeSearchResponse decodes responses to search and scroll requests.
+func decodeSearchResponse(res *opensearchapi.Response, span trace.Span) (*SearchResult, error) {
+	if res.IsError() {
+		// Other client errors, e.g. for missing indexes or credentials, are misconfigurations rather than bad queries.
+		cause := error(t.ErrUnexpectedResponse)
+		if res.StatusCode == http.StatusBadRequest {
+			cause = ErrBadRequest
+		}
+
+		err := fmt.Errorf("%w: %s", cause, res.Status())
+		span.RecordError(err)
+		return nil, err
+	}
+
+	response := struct {
+		ScrollID string `json:"_scroll_id"`
+		Hits     struct {
+			Total struct {
+				Value uint `json:"value"`
+			} `json:"total"`
+			MaxScore *float64    `json:"max_score"`
+			Hits     []SearchHit `json:"hits"`
+		} `json:"hits"`
+	}{}
+
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		err := fmt.Errorf("%w: %v", t.ErrUnexpectedResponse, err)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	result := &SearchResult{
+		Total:    response.Hits.Total.Value,
+		Hits:     response.Hits.Hits,
+		scrollID: response.ScrollID,
+	}
+
+	if response.Hits.MaxScore != nil {
+		result.MaxScore = *response.Hits.MaxScore
+	}
+
+	return result, nil
+}
@@ -2,10 +2,10 @@ package redis
 
 import (
 	"context"
-	"log"
 	"strings"
 
 	radix "github.com/mediocregopher/radix/v4"
+	"golang.org/x/exp/slog"
 
 	"github.com/ipfs-search/ipfs-search/components/index"
 	"github.com/ipfs-search/ipfs-search/instr"
@@ -23,6 +23,7 @@ type Client struct {
 	*instr.Instrumentation
 
 	radixClient radix.MultiClient
+	log         *slog.Logger
 }
 
 // NewClient instantiates a new Redis client.
@@ -42,6 +43,7 @@ func NewClient(cfg *ClientConfig, i *instr.Instrumentation) (*Client, error) {
 	return &Client{
 		cfg:             cfg,
 		Instrumentation: i,
+		log:             i.Component("redis"),
 	}, nil
 }
 
@@ -58,7 +60,7 @@ func (c *Client) Start(ctx context.Context) error {
 	var err error
 	if c.radixClient, err = (radix.ClusterConfig{}).New(ctx, c.cfg.Addrs); err != nil {
 		if isClusterNotSupportedError(err) && len(c.cfg.Addrs) == 1 {
-			log.Printf("Redis not a cluster, attempting single connection.")
+			c.log.Info("Redis not a cluster, attempting single connection")
 			singleClient, err := (radix.PoolConfig{}).New(ctx, "tcp", c.cfg.Addrs[0])
 			if err != nil {
 				return err
@@ -90,6 +92,11 @@ func (c *Client) NewIndex(name, prefix string, existsIndex bool) index.Index {
 	)
 }
 
+// Ping returns an error when Redis cannot be reached.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.radixClient.Do(ctx, radix.Cmd(nil, "PING"))
+}
+
 // Close closes the Redis client connection.
 func (c *Client) Close(ctx context.Context) error {
 	return c.radixClient.Close()
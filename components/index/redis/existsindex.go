@@ -2,7 +2,6 @@ package redis
 
 import (
 	"context"
-	"log"
 
 	"github.com/ipfs-search/ipfs-search/components/index"
 
@@ -36,9 +35,7 @@ func NewExistsIndex(client *Client, cfg *Config) index.Index {
 }
 
 func (i *ExistsIndex) set(ctx context.Context, id string, properties interface{}) error {
-	if debug {
-		log.Printf("redis exists %s: add %s to %s", i, id, i.key)
-	}
+	i.c.log.DebugCtx(ctx, "Add", "index", i.cfg.Name, "id", id, "key", i.key)
 
 	action := radix.Cmd(nil, "SADD", i.key, id)
 	return i.c.radixClient.Do(ctx, action)
@@ -70,9 +67,7 @@ func (i *ExistsIndex) Delete(ctx context.Context, id string) error {
 	ctx, span := i.c.Tracer.Start(ctx, "index.redis.Delete")
 	defer span.End()
 
-	if debug {
-		log.Printf("redis exists %s: delete %s from %s", i, id, i.key)
-	}
+	i.c.log.DebugCtx(ctx, "Remove", "index", i.cfg.Name, "id", id, "key", i.key)
 
 	// Non-blocking DEL-equivalent
 	action := radix.Cmd(nil, "SREM", i.key, id)
@@ -88,12 +83,22 @@ func (i *ExistsIndex) Get(ctx context.Context, id string, dst interface{}, field
 	action := radix.Cmd(&found, "SISMEMBER", i.key, id)
 	err := i.c.radixClient.Do(ctx, action)
 
-	if debug {
-		log.Printf("redis exists %s: get %s from %s, res: %v, err: %v", i, id, i.key, found, err)
-	}
+	i.c.log.DebugCtx(ctx, "Get", "index", i.cfg.Name, "id", id, "key", i.key, "found", found, "err", err)
 
 	return found, err
 }
 
+// Count returns the number of items in the index.
+func (i *ExistsIndex) Count(ctx context.Context) (int64, error) {
+	ctx, span := i.c.Tracer.Start(ctx, "index.redis.Count")
+	defer span.End()
+
+	var n int64
+	err := i.c.radixClient.Do(ctx, radix.Cmd(&n, "SCARD", i.key))
+
+	return n, err
+}
+
 // Compile-time assurance that implementation satisfies interface.
 var _ index.Index = &ExistsIndex{}
+var _ index.Counter = &ExistsIndex{}
@@ -32,6 +32,7 @@ func (s *ExistsIndexTestSuite) stubClient(fn stubFunc) *Client {
 		cfg:             &ClientConfig{},
 		Instrumentation: instr.New(),
 		radixClient:     rClient,
+		log:             instr.New().Component("redis"),
 	}
 }
 
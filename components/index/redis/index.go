@@ -2,7 +2,6 @@ package redis
 
 import (
 	"context"
-	"log"
 
 	"github.com/ipfs-search/ipfs-search/components/index"
 
@@ -10,8 +9,6 @@ import (
 	"github.com/mediocregopher/radix/v4/resp/resp3"
 )
 
-const debug bool = false
-
 // Index stores properties as JSON in Redis.
 type Index struct {
 	cfg *Config
@@ -61,9 +58,7 @@ func (i *Index) set(ctx context.Context, id string, properties interface{}) erro
 		panic("Redis cannot index without properties.")
 	}
 
-	if debug {
-		log.Printf("redis %s: writing to %s", i, key)
-	}
+	i.c.log.DebugCtx(ctx, "Write", "index", i.cfg.Name, "key", key)
 
 	args = append(args, flattened...)
 
@@ -99,9 +94,7 @@ func (i *Index) Delete(ctx context.Context, id string) error {
 
 	key := i.getKey(id)
 
-	if debug {
-		log.Printf("redis %s: delete %s", i, key)
-	}
+	i.c.log.DebugCtx(ctx, "Delete", "index", i.cfg.Name, "key", key)
 
 	// Non-blocking DEL-equivalent
 	action := radix.Cmd(nil, "UNLINK", key)
@@ -126,12 +119,45 @@ func (i *Index) Get(ctx context.Context, id string, dst interface{}, fields ...s
 	err := i.c.radixClient.Do(ctx, action)
 
 	found := !(mb.Null || mb.Empty)
-	if debug {
-		log.Printf("redis %s: get %s from %s, res: %v, err: %v", i, id, key, found, err)
-	}
+	i.c.log.DebugCtx(ctx, "Get", "index", i.cfg.Name, "key", key, "found", found, "err", err)
 
 	return err == nil && found, err
 }
 
+// Count returns the number of documents in the index, scanning the keys with its prefix on all primaries.
+// Note that this is a slow operation on large databases.
+func (i *Index) Count(ctx context.Context) (int64, error) {
+	ctx, span := i.c.Tracer.Start(ctx, "index.redis.Count")
+	defer span.End()
+
+	clients, err := i.c.radixClient.Clients()
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		n   int64
+		key string
+	)
+
+	for _, rs := range clients {
+		s := (radix.ScannerConfig{
+			Pattern: i.getKey("*"),
+			Count:   1000,
+		}).New(rs.Primary)
+
+		for s.Next(ctx, &key) {
+			n++
+		}
+
+		if err := s.Close(); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
 // Compile-time assurance that implementation satisfies interface.
 var _ index.Index = &Index{}
+var _ index.Counter = &Index{}
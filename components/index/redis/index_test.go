@@ -38,6 +38,7 @@ func (s *RedisTestSuite) stubClient(fn stubFunc) *Client {
 		cfg:             &ClientConfig{},
 		Instrumentation: instr.New(),
 		radixClient:     rClient,
+		log:             instr.New().Component("redis"),
 	}
 }
 
@@ -197,6 +198,24 @@ func (s *RedisTestSuite) TestDelete() {
 	s.NoError(err)
 }
 
+func (s *RedisTestSuite) TestCount() {
+	i := s.stubIndex(func(_ context.Context, args []string) interface{} {
+		s.Equal("SCAN", args[0])
+		s.Contains(args, indexPrefix+":*")
+
+		// Return two pages of keys.
+		if args[1] == "0" {
+			return []interface{}{"1", []string{"a", "b"}}
+		}
+
+		return []interface{}{"0", []string{"c"}}
+	})
+
+	n, err := i.Count(s.ctx)
+	s.NoError(err)
+	s.Equal(int64(3), n)
+}
+
 func (s *RedisTestSuite) TestGetFound() {
 	nBytes, _ := s.now.MarshalText()
 
@@ -0,0 +1,12 @@
+package types
+
+import (
+	"time"
+)
+
+// Checkpoint represents the progress of listing a (large) directory, allowing crawls to resume.
+type Checkpoint struct {
+	Count uint      `redis:"c"` // Number of processed entries.
+	Name  string    `redis:"n"` // Name of the last processed entry.
+	Time  time.Time `redis:"t"` // Time of processing the last entry.
+}
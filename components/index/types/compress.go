@@ -0,0 +1,40 @@
+package types
+
+import (
+	"bytes"
+
+	cbor "github.com/fxamacker/cbor/v2"
+	lz4 "github.com/pierrec/lz4/v4"
+)
+
+// marshalCompressed marshalls v into LZ4 compressed CBOR.
+func marshalCompressed(v interface{}) ([]byte, error) {
+	data, err := cbor.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed := new(bytes.Buffer)
+	writer := lz4.NewWriter(compressed)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return compressed.Bytes(), nil
+}
+
+// unmarshalCompressed unmarshalls LZ4 compressed CBOR into v.
+func unmarshalCompressed(data []byte, v interface{}) error {
+	compressed := bytes.NewBuffer(data)
+	uncompressed := new(bytes.Buffer)
+
+	reader := lz4.NewReader(compressed)
+	if _, err := reader.WriteTo(uncompressed); err != nil {
+		return err
+	}
+
+	return cbor.Unmarshal(uncompressed.Bytes(), v)
+}
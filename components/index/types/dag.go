@@ -0,0 +1,15 @@
+package types
+
+// DagField represents a flattened (leaf) value in a DAG, identified by its path.
+type DagField struct {
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+// Dag represents structured IPLD data (e.g. DAG-CBOR or DAG-JSON) in an Index.
+type Dag struct {
+	Document
+
+	Fields []DagField `json:"fields"`
+	Links  Links      `json:"links"`
+}
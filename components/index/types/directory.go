@@ -27,4 +27,12 @@ type Directory struct {
 	Document
 
 	Links Links `json:"links"`
+	Pages uint  `json:"pages,omitempty"` // Number of pages for directories split over several documents.
+}
+
+// DirectoryPage represents additional links of a directory split over several documents.
+type DirectoryPage struct {
+	Directory string `json:"directory"` // CID of the directory.
+	Page      uint   `json:"page"`      // Page number; the Directory itself is page 0.
+	Links     Links  `json:"links"`
 }
@@ -9,5 +9,6 @@ type Document struct {
 	FirstSeen  time.Time  `json:"first-seen"`
 	LastSeen   time.Time  `json:"last-seen"`
 	References References `json:"references"`
+	Providers  Providers  `json:"providers,omitempty"`
 	Size       uint64     `json:"size"`
 }
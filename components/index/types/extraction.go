@@ -0,0 +1,36 @@
+package types
+
+// Extraction represents the properties of a File resulting from metadata extraction.
+type Extraction struct {
+	Content         string   `json:"content"`
+	IpfsTikaVersion string   `json:"ipfs_tika_version"`
+	Language        Language `json:"language"`
+	Metadata        Metadata `json:"metadata"`
+	URLs            []string `json:"urls"`
+	NSFW            *NSFW    `json:"nfsw,omitempty"`
+}
+
+// ExtractionFields are the (JSON) fields of an Extraction.
+var ExtractionFields = []string{"content", "ipfs_tika_version", "language", "metadata", "urls", "nfsw"}
+
+// Extraction returns the extracted properties of a File.
+func (f *File) Extraction() *Extraction {
+	return &Extraction{
+		Content:         f.Content,
+		IpfsTikaVersion: f.IpfsTikaVersion,
+		Language:        f.Language,
+		Metadata:        f.Metadata,
+		URLs:            f.URLs,
+		NSFW:            f.NSFW,
+	}
+}
+
+// SetExtraction sets the extracted properties of a File.
+func (f *File) SetExtraction(e *Extraction) {
+	f.Content = e.Content
+	f.IpfsTikaVersion = e.IpfsTikaVersion
+	f.Language = e.Language
+	f.Metadata = e.Metadata
+	f.URLs = e.URLs
+	f.NSFW = e.NSFW
+}
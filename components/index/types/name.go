@@ -0,0 +1,27 @@
+package types
+
+import (
+	"time"
+)
+
+// Resolution represents a name resolving to a target at a given time.
+type Resolution struct {
+	Target string    `json:"target"`
+	Time   time.Time `json:"time"`
+}
+
+// Name represents a mutable name (e.g. IPNS name or DNSLink domain) in an Index.
+type Name struct {
+	Name      string       `json:"name"`
+	FirstSeen time.Time    `json:"first-seen"`
+	LastSeen  time.Time    `json:"last-seen"`
+	Target    string       `json:"target"`
+	History   []Resolution `json:"history"`
+}
+
+// NameUpdate represents the updatable part of a Name.
+type NameUpdate struct {
+	LastSeen *time.Time   `json:"last-seen,omitempty"`
+	Target   string       `json:"target,omitempty"`
+	History  []Resolution `json:"history,omitempty"`
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"time"
+)
+
+// Provider represents a peer providing a Document.
+type Provider struct {
+	_        struct{}  `cbor:",toarray"`
+	PeerID   string    `json:"peer_id"`
+	LastSeen time.Time `json:"last-seen"`
+}
+
+// Providers is a (bounded) collection of peers providing a Document.
+type Providers []Provider
+
+// MarshalBinary marshalls into LZ4 compressed CBOR.
+func (p Providers) MarshalBinary() ([]byte, error) {
+	return marshalCompressed([]Provider(p))
+}
+
+// UnmarshalBinary unmarshalls from LZ4 compressed CBOR.
+func (p *Providers) UnmarshalBinary(data []byte) error {
+	return unmarshalCompressed(data, p)
+}
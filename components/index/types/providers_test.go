@@ -0,0 +1,45 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ProvidersTestSuite struct {
+	suite.Suite
+}
+
+func (s *ProvidersTestSuite) TestMarshallUnmarshallBinary() {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	providers := Providers{
+		Provider{
+			PeerID:   "QmeTtFXm42Jb2todcKR538j6qHYxXt6suUzpF3rtT9FPSd",
+			LastSeen: now,
+		},
+		Provider{
+			PeerID:   "QmafrLBfzRLV4XSH1XcaMMeaXEUhDJjmtDfsYU95TrWG87",
+			LastSeen: now.Add(-time.Hour),
+		},
+	}
+
+	data, err := providers.MarshalBinary()
+	s.NoError(err)
+	s.NotEmpty(data)
+
+	newProviders := Providers{}
+	err = newProviders.UnmarshalBinary(data)
+	s.NoError(err)
+
+	s.Len(newProviders, len(providers))
+	for i, p := range providers {
+		s.Equal(p.PeerID, newProviders[i].PeerID)
+		s.True(p.LastSeen.Equal(newProviders[i].LastSeen))
+	}
+}
+
+func TestProvidersTestSuite(t *testing.T) {
+	suite.Run(t, new(ProvidersTestSuite))
+}
@@ -8,4 +8,48 @@ import (
 type Update struct {
 	LastSeen   *time.Time `json:"last-seen,omitempty" redis:"l,omitempty"`
 	References References `json:"references,omitempty" redis:"r,omitempty"`
+	Providers  Providers  `json:"providers,omitempty" redis:"p,omitempty"`
+}
+
+// Seen represents the properties updated when a Document is seen to be available, clearing any earlier
+// unreachable mark from a Recheck.
+type Seen struct {
+	Update
+	Unreachable bool `json:"unreachable"`
+}
+
+// Recheck represents the properties updated after verifying the availability of a Document.
+type Recheck struct {
+	Update
+	LastChecked time.Time `json:"last-checked"`
+	Unreachable bool      `json:"unreachable"`
+}
+
+// Equal returns whether u and o hold the same values, regardless of time zones and of nil versus empty collections.
+func (u *Update) Equal(o *Update) bool {
+	if (u.LastSeen == nil) != (o.LastSeen == nil) {
+		return false
+	}
+
+	if u.LastSeen != nil && !u.LastSeen.Equal(*o.LastSeen) {
+		return false
+	}
+
+	if len(u.References) != len(o.References) || len(u.Providers) != len(o.Providers) {
+		return false
+	}
+
+	for i, r := range u.References {
+		if r.ParentHash != o.References[i].ParentHash || r.Name != o.References[i].Name {
+			return false
+		}
+	}
+
+	for i, p := range u.Providers {
+		if p.PeerID != o.Providers[i].PeerID || !p.LastSeen.Equal(o.Providers[i].LastSeen) {
+			return false
+		}
+	}
+
+	return true
 }
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateEqual(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now().Truncate(time.Second)
+	utc := now.UTC()
+
+	u := &Update{
+		LastSeen:   &now,
+		References: testRefs[:2],
+		Providers:  Providers{{PeerID: "peer", LastSeen: now}},
+	}
+
+	o := &Update{
+		LastSeen:   &utc,
+		References: append(References{}, testRefs[:2]...),
+		Providers:  Providers{{PeerID: "peer", LastSeen: utc}},
+	}
+
+	assert.True(u.Equal(o))
+	assert.True((&Update{References: References{}}).Equal(&Update{}))
+
+	o.LastSeen = nil
+	assert.False(u.Equal(o))
+
+	o.LastSeen = &utc
+	o.References = testRefs[1:3]
+	assert.False(u.Equal(o))
+
+	o.References = testRefs[:2]
+	o.Providers = Providers{{PeerID: "other", LastSeen: utc}}
+	assert.False(u.Equal(o))
+}
+
+func TestSeenClearsUnreachable(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	b, err := json.Marshal(&Seen{Update: Update{LastSeen: &now}})
+	assert.NoError(t, err)
+
+	// Unreachable is written, even when false, to reset the mark from an earlier Recheck.
+	var fields map[string]interface{}
+	assert.NoError(t, json.Unmarshal(b, &fields))
+	assert.Equal(t, false, fields["unreachable"])
+	assert.Contains(t, fields, "last-seen")
+}
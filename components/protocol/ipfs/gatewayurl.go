@@ -11,8 +11,9 @@ import (
 // If a reference is available, it is used to generate the filename to facilitate content
 // type detection (e.g. /ipfs/<parent_hash>/my_file.jpg instead of /ipfs/<file_hash>/).
 func namedPath(r *t.AnnotatedResource) string {
-	if ref := r.Reference; ref.Name != "" {
-		return fmt.Sprintf("/ipfs/%s/%s", ref.Parent.ID, url.PathEscape(ref.Name))
+	// References from DAGs are paths within the parent rather than filenames.
+	if ref := r.Reference; ref.Name != "" && r.Source != t.DagSource {
+		return fmt.Sprintf("/%s/%s/%s", ref.Parent.Protocol, ref.Parent.ID, url.PathEscape(ref.Name))
 	}
 
 	return absolutePath(r)
@@ -22,12 +23,12 @@ func namedPath(r *t.AnnotatedResource) string {
 // If a reference is available, it is used to generate the filename to facilitate content
 // type detection (e.g. /ipfs/<parent_hash>/my_file.jpg instead of /ipfs/<file_hash>/).
 // Ref: http://docs.ipfs.io.ipns.localhost:8080/concepts/ipfs-gateway/#gateway-types
-func (i *IPFS) GatewayURL(r *t.AnnotatedResource) string {
+func (i *IPFS) GatewayURL(r *t.AnnotatedResource) (string, error) {
 	url, err := i.gatewayURL.Parse(namedPath(r))
 
 	if err != nil {
-		panic(fmt.Sprintf("error generating GatewayURL: %v", err))
+		return "", fmt.Errorf("error generating GatewayURL: %w", err)
 	}
 
-	return url.String()
+	return url.String(), nil
 }
@@ -40,7 +40,8 @@ func (s *GatewayURLTestSuite) TestGatewayURLReferenced() {
 		},
 	}
 
-	url := s.ipfs.GatewayURL(r)
+	url, err := s.ipfs.GatewayURL(r)
+	s.NoError(err)
 
 	s.Equal(url, gatewayURL+"/ipfs/QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp/fileName.pdf")
 }
@@ -53,7 +54,8 @@ func (s *GatewayURLTestSuite) TestGatewayURLUnreferenced() {
 		},
 	}
 
-	url := s.ipfs.GatewayURL(r)
+	url, err := s.ipfs.GatewayURL(r)
+	s.NoError(err)
 
 	s.Equal(url, gatewayURL+"/ipfs/QmcBLKyRHjbGeLnjnmj74FFJpGJDz4YxFqUDYqMU7Mny1p")
 }
@@ -73,7 +75,8 @@ func (s *GatewayURLTestSuite) TestGatewayURLUnnamedReference() {
 		},
 	}
 
-	url := s.ipfs.GatewayURL(r)
+	url, err := s.ipfs.GatewayURL(r)
+	s.NoError(err)
 
 	s.Equal(url, gatewayURL+"/ipfs/QmcBLKyRHjbGeLnjnmj74FFJpGJDz4YxFqUDYqMU7Mny1p")
 }
@@ -97,7 +100,8 @@ func (s *GatewayURLTestSuite) TestEscapeURL() {
 		},
 	}
 
-	url := s.ipfs.GatewayURL(r)
+	url, err := s.ipfs.GatewayURL(r)
+	s.NoError(err)
 
 	s.Equal(url, gatewayURL+"/ipfs/QmehSxmTPRCr85Xjgzjut6uWQihoTfqg9VVihJ892bmZCp/Killing_Yourself_to_Live:_85%25_of_a_True_Story.html")
 }
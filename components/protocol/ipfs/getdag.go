@@ -0,0 +1,46 @@
+package ipfs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// isDagCodec returns true for codecs of structured (non-UnixFS) IPLD data.
+func isDagCodec(codec uint64) bool {
+	switch codec {
+	case cid.DagCBOR, t.DagJSONCodec:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetDag returns the IPLD data model node for a DAG resource, as decoded from DAG-JSON.
+// Links are represented as {"/": "<cid>"} and bytes as {"/": {"bytes": "<base64>"}}.
+// Ref: https://docs.ipfs.tech/reference/kubo/rpc/#api-v0-dag-get
+func (i *IPFS) GetDag(ctx context.Context, r *t.AnnotatedResource) (interface{}, error) {
+	ctx, span := i.Tracer.Start(ctx, "protocol.ipfs.GetDag")
+	defer span.End()
+
+	const cmd = "dag/get"
+
+	path := absolutePath(r)
+	req := i.shell.Request(cmd, path).Option("output-codec", "dag-json")
+
+	var node interface{}
+
+	if err := req.Exec(ctx, &node); err != nil {
+		if isInvalidResourceErr(err) {
+			err = fmt.Errorf("%w: %v", t.ErrInvalidResource, err)
+		}
+
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return node, nil
+}
@@ -0,0 +1,80 @@
+package ipfs
+
+import (
+	"context"
+	"fmt"
+	"github.com/dankinder/httpmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"testing"
+
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+type GetDagTestSuite struct {
+	suite.Suite
+
+	ctx  context.Context
+	ipfs *IPFS
+
+	mockAPIHandler *httpmock.MockHandler
+	mockAPIServer  *httpmock.Server
+	responseHeader http.Header
+}
+
+func (s *GetDagTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	s.mockAPIHandler = &httpmock.MockHandler{}
+	s.mockAPIServer = httpmock.NewServer(s.mockAPIHandler)
+	s.responseHeader = http.Header{
+		"Content-Type": []string{"application/json"},
+	}
+
+	cfg := DefaultConfig()
+	cfg.APIURL = s.mockAPIServer.URL()
+
+	s.ipfs = New(cfg, http.DefaultClient, instr.New())
+}
+
+func (s *GetDagTestSuite) TearDownTest() {
+	s.mockAPIServer.Close()
+}
+
+func (s *GetDagTestSuite) TestGetDag() {
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "bafyreifuu2zzh3vxdpgi5qlsewfkktkvz46cm5h6sxrlpmi7tvfrzf5y6e",
+		},
+	}
+
+	rURL := fmt.Sprintf("/api/v0/dag/get?arg=%%2Fipfs%%2F%s&output-codec=dag-json", r.ID)
+
+	// Setup mock handler
+	s.mockAPIHandler.
+		On("Handle", "POST", rURL, mock.Anything).
+		Return(httpmock.Response{
+			Header: s.responseHeader,
+			Body:   []byte(`{"title":"Hello","link":{"/":"QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp"}}`),
+		}).
+		Once()
+
+	node, err := s.ipfs.GetDag(s.ctx, r)
+
+	s.NoError(err)
+	s.mockAPIHandler.AssertExpectations(s.T())
+
+	s.Equal(map[string]interface{}{
+		"title": "Hello",
+		"link": map[string]interface{}{
+			"/": "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+	}, node)
+}
+
+func TestGetDagTestSuite(t *testing.T) {
+	suite.Run(t, new(GetDagTestSuite))
+}
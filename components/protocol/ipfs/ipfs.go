@@ -23,9 +23,9 @@ type IPFS struct {
 	*instr.Instrumentation
 }
 
-// absolutePath returns the absolute (CID-only) path for a resource.
+// absolutePath returns the absolute (CID or name only) path for a resource, e.g. /ipfs/<cid> or /ipns/<name>.
 func absolutePath(r *t.AnnotatedResource) string {
-	return fmt.Sprintf("/ipfs/%s", r.ID)
+	return fmt.Sprintf("/%s/%s", r.Protocol, r.ID)
 }
 
 // New returns a new IPFS protocol.
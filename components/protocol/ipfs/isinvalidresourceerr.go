@@ -3,10 +3,13 @@ package ipfs
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
 	"strings"
 
 	ipfs "github.com/ipfs/go-ipfs-api"
+	"golang.org/x/exp/slog"
+
+	"github.com/ipfs-search/ipfs-search/instr"
 )
 
 var invalidErrorPrefixes = [...]string{
@@ -34,7 +37,7 @@ func isInvalidResourceErr(err error) bool {
 	ipfsErr, ok := err.(*ipfs.Error)
 
 	if !ok {
-		log.Printf("Unexpected protocol error: %T:%v", err, err)
+		slog.Default().With(instr.ComponentKey, "ipfs").Warn("Unexpected protocol error", "type", fmt.Sprintf("%T", err), "err", err)
 		return false
 	}
 
@@ -45,7 +48,7 @@ func isInvalidResourceErr(err error) bool {
 		}
 	}
 
-	log.Printf("Unexpected *ipfs.Error: %v", ipfsErr.Message)
+	slog.Default().With(instr.ComponentKey, "ipfs").Warn("Unexpected *ipfs.Error", "err", ipfsErr.Message)
 
 	return false
 }
@@ -0,0 +1,72 @@
+package ipfs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+const ipfsPathPrefix = "/ipfs/"
+
+// ErrUnresolvableTarget is returned when a name resolves to a target which is not a CID.
+var ErrUnresolvableTarget = t.WrappedError{Err: t.ErrInvalidResource, Msg: "unresolvable target"}
+
+type resolveResult struct {
+	Path string
+}
+
+// targetFromPath returns the Resource for a resolved path of the form /ipfs/<cid>.
+func targetFromPath(path string) (*t.Resource, error) {
+	id := strings.TrimPrefix(path, ipfsPathPrefix)
+	if id == path || strings.Contains(id, "/") {
+		return nil, fmt.Errorf("%w: %s", ErrUnresolvableTarget, path)
+	}
+
+	if _, err := cid.Decode(id); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrUnresolvableTarget, path, err)
+	}
+
+	return &t.Resource{
+		Protocol: t.IPFSProtocol,
+		ID:       id,
+	}, nil
+}
+
+// Resolve returns the immutable Resource an IPNS name or DNSLink domain currently points to.
+// Immutable (IPFS) resources resolve to themselves.
+// Ref: https://docs.ipfs.tech/reference/kubo/rpc/#api-v0-name-resolve
+func (i *IPFS) Resolve(ctx context.Context, r *t.AnnotatedResource) (*t.Resource, error) {
+	if r.Protocol == t.IPFSProtocol {
+		return r.Resource, nil
+	}
+
+	ctx, span := i.Tracer.Start(ctx, "protocol.ipfs.Resolve")
+	defer span.End()
+
+	const cmd = "name/resolve"
+
+	path := absolutePath(r)
+	req := i.shell.Request(cmd, path).Option("recursive", true)
+
+	result := new(resolveResult)
+
+	if err := req.Exec(ctx, result); err != nil {
+		if isInvalidResourceErr(err) {
+			err = fmt.Errorf("%w: %v", t.ErrInvalidResource, err)
+		}
+
+		span.RecordError(err)
+		return nil, err
+	}
+
+	target, err := targetFromPath(result.Path)
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	return target, err
+}
@@ -0,0 +1,118 @@
+package ipfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dankinder/httpmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"testing"
+
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+type ResolveTestSuite struct {
+	suite.Suite
+
+	ctx  context.Context
+	ipfs *IPFS
+
+	mockAPIHandler *httpmock.MockHandler
+	mockAPIServer  *httpmock.Server
+	responseHeader http.Header
+}
+
+func (s *ResolveTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	s.mockAPIHandler = &httpmock.MockHandler{}
+	s.mockAPIServer = httpmock.NewServer(s.mockAPIHandler)
+	s.responseHeader = http.Header{
+		"Content-Type": []string{"application/json"},
+	}
+
+	cfg := DefaultConfig()
+	cfg.APIURL = s.mockAPIServer.URL()
+
+	s.ipfs = New(cfg, http.DefaultClient, instr.New())
+}
+
+func (s *ResolveTestSuite) TearDownTest() {
+	s.mockAPIServer.Close()
+}
+
+func (s *ResolveTestSuite) TestResolveName() {
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPNSProtocol,
+			ID:       "docs.ipfs.tech",
+		},
+	}
+
+	rURL := fmt.Sprintf("/api/v0/name/resolve?arg=%%2Fipns%%2F%s&recursive=true", r.ID)
+
+	// Setup mock handler
+	s.mockAPIHandler.
+		On("Handle", "POST", rURL, mock.Anything).
+		Return(httpmock.Response{
+			Header: s.responseHeader,
+			Body:   []byte(`{"Path":"/ipfs/QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp"}`),
+		}).
+		Once()
+
+	target, err := s.ipfs.Resolve(s.ctx, r)
+
+	s.NoError(err)
+	s.mockAPIHandler.AssertExpectations(s.T())
+
+	s.Equal(&t.Resource{
+		Protocol: t.IPFSProtocol,
+		ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+	}, target)
+}
+
+func (s *ResolveTestSuite) TestResolveSubPath() {
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPNSProtocol,
+			ID:       "docs.ipfs.tech",
+		},
+	}
+
+	rURL := fmt.Sprintf("/api/v0/name/resolve?arg=%%2Fipns%%2F%s&recursive=true", r.ID)
+
+	// Setup mock handler
+	s.mockAPIHandler.
+		On("Handle", "POST", rURL, mock.Anything).
+		Return(httpmock.Response{
+			Header: s.responseHeader,
+			Body:   []byte(`{"Path":"/ipfs/QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp/some/path"}`),
+		}).
+		Once()
+
+	_, err := s.ipfs.Resolve(s.ctx, r)
+
+	s.True(errors.Is(err, t.ErrInvalidResource))
+	s.mockAPIHandler.AssertExpectations(s.T())
+}
+
+func (s *ResolveTestSuite) TestResolveImmutable() {
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+	}
+
+	target, err := s.ipfs.Resolve(s.ctx, r)
+
+	s.NoError(err)
+	s.Equal(r.Resource, target)
+}
+
+func TestResolveTestSuite(t *testing.T) {
+	suite.Run(t, new(ResolveTestSuite))
+}
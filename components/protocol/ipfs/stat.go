@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ipfs/go-cid"
+
 	t "github.com/ipfs-search/ipfs-search/types"
 )
 
@@ -13,6 +15,10 @@ type statResult struct {
 	CumulativeSize uint64
 }
 
+type blockStatResult struct {
+	Size uint64
+}
+
 func typeFromString(strType string) t.ResourceType {
 	switch strType {
 	case "file":
@@ -32,12 +38,46 @@ func getSize(rType t.ResourceType, result *statResult) uint64 {
 	return result.CumulativeSize
 }
 
+// statDag populates Type and Size for structured IPLD data, for which files/stat is unavailable.
+// Ref: https://docs.ipfs.tech/reference/kubo/rpc/#api-v0-block-stat
+func (i *IPFS) statDag(ctx context.Context, r *t.AnnotatedResource) error {
+	const cmd = "block/stat"
+
+	req := i.shell.Request(cmd, r.ID)
+
+	result := new(blockStatResult)
+
+	if err := req.Exec(ctx, result); err != nil {
+		if isInvalidResourceErr(err) {
+			err = fmt.Errorf("%w: %v", t.ErrInvalidResource, err)
+		}
+
+		return err
+	}
+
+	r.Stat = t.Stat{
+		Type: t.DagType,
+		Size: result.Size,
+	}
+
+	return nil
+}
+
 // Stat returns a AnnotatedResource with Type and Size populated.
 // Ref: http://docs.ipfs.io.ipns.localhost:8080/reference/http/api/#api-v0-files-stat
 func (i *IPFS) Stat(ctx context.Context, r *t.AnnotatedResource) error {
 	ctx, span := i.Tracer.Start(ctx, "protocol.ipfs.Stat")
 	defer span.End()
 
+	if c, err := cid.Decode(r.ID); err == nil && isDagCodec(c.Type()) {
+		err = i.statDag(ctx, r)
+		if err != nil {
+			span.RecordError(err)
+		}
+
+		return err
+	}
+
 	const cmd = "files/stat"
 
 	path := absolutePath(r)
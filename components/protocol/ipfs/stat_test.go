@@ -231,6 +231,36 @@ func (s *StatTestSuite) TestUnsupported() {
 	})
 }
 
+func (s *StatTestSuite) TestDag() {
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "bafyreifuu2zzh3vxdpgi5qlsewfkktkvz46cm5h6sxrlpmi7tvfrzf5y6e",
+		},
+	}
+
+	rURL := fmt.Sprintf("/api/v0/block/stat?arg=%s", r.ID)
+
+	// Setup mock handler
+	s.mockAPIHandler.
+		On("Handle", "POST", rURL, mock.Anything).
+		Return(httpmock.Response{
+			Header: s.responseHeader,
+			Body:   []byte(`{"Key":"bafyreifuu2zzh3vxdpgi5qlsewfkktkvz46cm5h6sxrlpmi7tvfrzf5y6e","Size":128}`),
+		}).
+		Once()
+
+	err := s.ipfs.Stat(s.ctx, r)
+
+	s.NoError(err)
+	s.mockAPIHandler.AssertExpectations(s.T())
+
+	s.Equal(r.Stat, t.Stat{
+		Type: t.DagType,
+		Size: 128,
+	})
+}
+
 func (s *StatTestSuite) TestInvalid() {
 	errStrs := []string{
 		"proto: required field \"Type\" not set",             // Example: QmYAqhbqNDpU7X9VW6FV5imtngQ3oBRY35zuDXduuZnyA8
@@ -13,9 +13,9 @@ type Mock struct {
 }
 
 // GatewayURL mocks the corresponding method on the Protocol interface.
-func (m *Mock) GatewayURL(r *t.AnnotatedResource) string {
+func (m *Mock) GatewayURL(r *t.AnnotatedResource) (string, error) {
 	args := m.Called(r)
-	return args.String(0)
+	return args.String(0), args.Error(1)
 }
 
 // Stat mocks the corresponding method on the Protocol interface.
@@ -30,6 +30,24 @@ func (m *Mock) Ls(ctx context.Context, r *t.AnnotatedResource, c chan<- *t.Annot
 	return args.Error(0)
 }
 
+// Resolve mocks the corresponding method on the Protocol interface.
+func (m *Mock) Resolve(ctx context.Context, r *t.AnnotatedResource) (*t.Resource, error) {
+	args := m.Called(ctx, r)
+
+	var target *t.Resource
+	if v := args.Get(0); v != nil {
+		target = v.(*t.Resource)
+	}
+
+	return target, args.Error(1)
+}
+
+// GetDag mocks the corresponding method on the Protocol interface.
+func (m *Mock) GetDag(ctx context.Context, r *t.AnnotatedResource) (interface{}, error) {
+	args := m.Called(ctx, r)
+	return args.Get(0), args.Error(1)
+}
+
 // IsInvalidResourceErr mocks the corresponding method on the Protocol interface.
 func (m *Mock) IsInvalidResourceErr(err error) bool {
 	args := m.Called(err)
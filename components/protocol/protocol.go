@@ -9,7 +9,9 @@ import (
 
 // Protocol represents the interface with one or multiple protocols. It is concurrency-safe.
 type Protocol interface {
-	GatewayURL(*t.AnnotatedResource) string
+	GatewayURL(*t.AnnotatedResource) (string, error)
 	Stat(context.Context, *t.AnnotatedResource) error
 	Ls(context.Context, *t.AnnotatedResource, chan<- *t.AnnotatedResource) error
+	Resolve(context.Context, *t.AnnotatedResource) (*t.Resource, error)
+	GetDag(context.Context, *t.AnnotatedResource) (interface{}, error)
 }
@@ -0,0 +1,110 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// ErrUnsupportedProtocol is returned when no implementation is registered for the Protocol of a resource.
+var ErrUnsupportedProtocol = t.WrappedError{Err: t.ErrInvalidResource, Msg: "unsupported protocol"}
+
+// Registry dispatches calls to the Protocol implementation registered for a resource's
+// Protocol. It implements Protocol itself and is concurrency-safe.
+type Registry struct {
+	mutex     sync.RWMutex
+	protocols map[t.Protocol]Protocol
+}
+
+// NewRegistry returns a new, empty, Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		protocols: make(map[t.Protocol]Protocol),
+	}
+}
+
+// Register registers an implementation for a Protocol.
+// The Protocol should have been registered with types.RegisterProtocol; registering an unregistered
+// Protocol or registering a Protocol twice is a programming error and panics.
+func (r *Registry) Register(p t.Protocol, impl Protocol) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !p.IsRegistered() {
+		panic(fmt.Sprintf("protocol %s not registered in types", p))
+	}
+
+	if _, ok := r.protocols[p]; ok {
+		panic(fmt.Sprintf("implementation for protocol %s already registered", p))
+	}
+
+	r.protocols[p] = impl
+}
+
+// Get returns the implementation for a Protocol, or ErrUnsupportedProtocol.
+func (r *Registry) Get(p t.Protocol) (Protocol, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	impl, ok := r.protocols[p]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProtocol, p)
+	}
+
+	return impl, nil
+}
+
+// GatewayURL returns the gateway URL from the implementation for the resource's Protocol.
+func (r *Registry) GatewayURL(resource *t.AnnotatedResource) (string, error) {
+	impl, err := r.Get(resource.Protocol)
+	if err != nil {
+		return "", err
+	}
+
+	return impl.GatewayURL(resource)
+}
+
+// Stat calls Stat on the implementation for the resource's Protocol.
+func (r *Registry) Stat(ctx context.Context, resource *t.AnnotatedResource) error {
+	impl, err := r.Get(resource.Protocol)
+	if err != nil {
+		return err
+	}
+
+	return impl.Stat(ctx, resource)
+}
+
+// Ls calls Ls on the implementation for the resource's Protocol.
+func (r *Registry) Ls(ctx context.Context, resource *t.AnnotatedResource, out chan<- *t.AnnotatedResource) error {
+	impl, err := r.Get(resource.Protocol)
+	if err != nil {
+		return err
+	}
+
+	return impl.Ls(ctx, resource, out)
+}
+
+// Resolve calls Resolve on the implementation for the resource's Protocol.
+func (r *Registry) Resolve(ctx context.Context, resource *t.AnnotatedResource) (*t.Resource, error) {
+	impl, err := r.Get(resource.Protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	return impl.Resolve(ctx, resource)
+}
+
+// GetDag calls GetDag on the implementation for the resource's Protocol.
+func (r *Registry) GetDag(ctx context.Context, resource *t.AnnotatedResource) (interface{}, error) {
+	impl, err := r.Get(resource.Protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	return impl.GetDag(ctx, resource)
+}
+
+// Compile-time assurance that implementation satisfies interface.
+var _ Protocol = &Registry{}
@@ -0,0 +1,87 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+const testProtocol t.Protocol = 255
+
+func init() {
+	t.RegisterProtocol(testProtocol, "test")
+}
+
+type RegistryTestSuite struct {
+	suite.Suite
+
+	ctx      context.Context
+	registry *Registry
+	ipfs     *Mock
+	test     *Mock
+}
+
+func (s *RegistryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+
+	s.ipfs = &Mock{}
+	s.test = &Mock{}
+
+	s.registry = NewRegistry()
+	s.registry.Register(t.IPFSProtocol, s.ipfs)
+	s.registry.Register(testProtocol, s.test)
+}
+
+func (s *RegistryTestSuite) TestDispatch() {
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: testProtocol,
+			ID:       "someID",
+		},
+	}
+
+	s.test.On("Stat", s.ctx, r).Return(nil).Once()
+	s.test.On("GatewayURL", r).Return("http://test/someID", nil).Once()
+
+	s.NoError(s.registry.Stat(s.ctx, r))
+	url, err := s.registry.GatewayURL(r)
+	s.NoError(err)
+	s.Equal("http://test/someID", url)
+
+	s.test.AssertExpectations(s.T())
+	s.ipfs.AssertNotCalled(s.T(), "Stat", mock.Anything, mock.Anything)
+}
+
+func (s *RegistryTestSuite) TestUnsupportedProtocol() {
+	r := &t.AnnotatedResource{
+		Resource: &t.Resource{
+			Protocol: 254,
+			ID:       "someID",
+		},
+	}
+
+	err := s.registry.Stat(s.ctx, r)
+
+	s.True(errors.Is(err, ErrUnsupportedProtocol))
+	s.True(errors.Is(err, t.ErrInvalidResource))
+
+	_, err = s.registry.GatewayURL(r)
+	s.True(errors.Is(err, ErrUnsupportedProtocol))
+}
+
+func (s *RegistryTestSuite) TestRegisterTwice() {
+	s.Panics(func() { s.registry.Register(t.IPFSProtocol, &Mock{}) })
+}
+
+func (s *RegistryTestSuite) TestRegisterUnregistered() {
+	s.Panics(func() { s.registry.Register(253, &Mock{}) })
+}
+
+func TestRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(RegistryTestSuite))
+}
@@ -2,6 +2,7 @@ package amqp
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -20,6 +21,53 @@ type Channel struct {
 
 // Queue creates a named queue on a given chennel
 func (c *Channel) Queue(ctx context.Context, name string) (*Queue, error) {
+	return c.declare(ctx, name, amqp.Table{
+		"x-max-priority": 9, // Enable all 9 priorities
+		"x-message-ttl":  c.MessageTTL.Milliseconds(),
+		"x-queue-mode":   "lazy", // Allow RabbitMQ to write queue to disk as fast as possible
+	})
+}
+
+// DelayQueue creates a queue per delay tier on a given channel, from which messages are moved to the target queue
+// after the tier's delay. Queues are named after name and their tier, e.g. `files.delay.1m0s`.
+func (c *Channel) DelayQueue(ctx context.Context, name string, target string, tiers []time.Duration) (*DelayQueue, error) {
+	if len(tiers) == 0 {
+		return nil, fmt.Errorf("no delay tiers for %s", name)
+	}
+
+	dq := &DelayQueue{
+		name:   name,
+		tiers:  tiers,
+		queues: make([]*Queue, len(tiers)),
+	}
+
+	for i, tier := range tiers {
+		// RabbitMQ only expires messages at the head of a queue; a queue-level TTL keeps them in order.
+		q, err := c.declare(ctx, fmt.Sprintf("%s.%s", name, tier), amqp.Table{
+			"x-max-priority":            9, // Retain priorities for the target queue
+			"x-message-ttl":             tier.Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": target,
+			"x-queue-mode":              "lazy",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		dq.queues[i] = q
+	}
+
+	return dq, nil
+}
+
+// DeadLetterQueue creates a named queue on a given channel, retaining messages until they are consumed.
+func (c *Channel) DeadLetterQueue(ctx context.Context, name string) (*Queue, error) {
+	return c.declare(ctx, name, amqp.Table{
+		"x-queue-mode": "lazy",
+	})
+}
+
+func (c *Channel) declare(ctx context.Context, name string, args amqp.Table) (*Queue, error) {
 	ctx, span := c.Tracer.Start(ctx, "queue.amqp.Channel.Queue", trace.WithAttributes(attribute.String("queue", name)))
 	defer span.End()
 
@@ -29,11 +77,7 @@ func (c *Channel) Queue(ctx context.Context, name string) (*Queue, error) {
 		false, // delete when unused
 		false, // exclusive
 		false, // no-wait
-		amqp.Table{
-			"x-max-priority": 9, // Enable all 9 priorities
-			"x-message-ttl":  c.MessageTTL.Milliseconds(),
-			"x-queue-mode":   "lazy", // Allow RabbitMQ to write queue to disk as fast as possible
-		},
+		args,
 	)
 	if err != nil {
 		span.RecordError(err)
@@ -47,6 +91,15 @@ func (c *Channel) Queue(ctx context.Context, name string) (*Queue, error) {
 	}, nil
 }
 
+// Check returns an error when the channel is closed.
+func (c *Channel) Check(ctx context.Context) error {
+	if c.ch.IsClosed() {
+		return amqp.ErrClosed
+	}
+
+	return nil
+}
+
 // Close closes a Channel
 func (c *Channel) Close() error {
 	return c.ch.Close()
@@ -2,12 +2,12 @@ package amqp
 
 import (
 	"context"
-	"log"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
 
 	"github.com/ipfs-search/ipfs-search/instr"
 )
@@ -17,6 +17,7 @@ type Connection struct {
 	config *Config
 	conn   *amqp.Connection
 	*instr.Instrumentation
+	log *slog.Logger
 }
 
 // NewConnection returns new AMQP connection
@@ -35,6 +36,7 @@ func NewConnection(ctx context.Context, cfg *Config, amqpConfig *amqp.Config, i
 		config:          cfg,
 		conn:            amqpConn,
 		Instrumentation: i,
+		log:             i.Component("amqp"),
 	}
 
 	blockChan := amqpConn.NotifyBlocked(make(chan amqp.Blocking, 1))
@@ -56,14 +58,19 @@ func NewConnection(ctx context.Context, cfg *Config, amqpConfig *amqp.Config, i
 					span.AddEvent("amqp-connection-blocked",
 						trace.WithAttributes(attribute.String("reason", b.Reason)),
 					)
-					log.Println("AMQP connection blocked")
+					c.log.WarnCtx(ctx, "AMQP connection blocked", "reason", b.Reason)
 				} else {
 					span.AddEvent("amqp-connection-unblocked")
-					log.Println("AMQP connection unblocked")
+					c.log.InfoCtx(ctx, "AMQP connection unblocked")
 				}
-			case err := <-closeChan:
+			case err, ok := <-closeChan:
+				if !ok {
+					// Closed by us.
+					return
+				}
+
 				span.RecordError(err)
-				log.Printf("AMQP connection lost, attempting reconnect in %s", cfg.ReconnectTime)
+				c.log.WarnCtx(ctx, "AMQP connection lost, attempting reconnect", "delay", cfg.ReconnectTime, "err", err)
 				time.Sleep(cfg.ReconnectTime)
 
 				amqpConn, amqpErr := amqp.Dial(cfg.URL)
@@ -74,7 +81,7 @@ func NewConnection(ctx context.Context, cfg *Config, amqpConfig *amqp.Config, i
 						panic("Repeated AMQP reconnect errors")
 					} else {
 						errCnt++
-						log.Printf("Error connecting to AMQP: %v", amqpErr)
+						c.log.ErrorCtx(ctx, "Error connecting to AMQP", "err", amqpErr)
 						span.RecordError(amqpErr)
 					}
 
@@ -120,6 +127,11 @@ func (c *Connection) channel(ctx context.Context, prefetchCount int) (*Channel,
 	}, nil
 }
 
+// NewChannel returns a new channel, for declaring multiple queues.
+func (c *Connection) NewChannel(ctx context.Context, prefetchCount int) (*Channel, error) {
+	return c.channel(ctx, prefetchCount)
+}
+
 // NewChannelQueue returns a new queue on a new channel
 func (c *Connection) NewChannelQueue(ctx context.Context, name string, prefetchCount int) (*Queue, error) {
 	ctx, span := c.Tracer.Start(ctx, "queue.amqp.NewChannelQueue", trace.WithAttributes(attribute.String("queue", name)))
@@ -133,6 +145,15 @@ func (c *Connection) NewChannelQueue(ctx context.Context, name string, prefetchC
 	return ch.Queue(ctx, name)
 }
 
+// Check returns an error when the connection is closed.
+func (c *Connection) Check(ctx context.Context) error {
+	if c.conn.IsClosed() {
+		return amqp.ErrClosed
+	}
+
+	return nil
+}
+
 func (c *Connection) String() string {
 	return c.conn.LocalAddr().String()
 }
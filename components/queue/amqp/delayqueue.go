@@ -0,0 +1,42 @@
+package amqp
+
+import (
+	"context"
+	"time"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+)
+
+// DelayQueue holds messages in a queue per delay tier, from which they are moved to the target queue after the
+// delay of their tier. As all messages in a tier's queue share its expiration, a long delay never holds back
+// shorter ones.
+type DelayQueue struct {
+	name   string
+	tiers  []time.Duration // In increasing order.
+	queues []*Queue        // By tier.
+}
+
+// String returns the name of the queue
+func (q *DelayQueue) String() string {
+	return q.name
+}
+
+// tier returns the index of the shortest tier of at least delay, or the longest tier.
+func (q *DelayQueue) tier(delay time.Duration) int {
+	for i, tier := range q.tiers {
+		if tier >= delay {
+			return i
+		}
+	}
+
+	return len(q.tiers) - 1
+}
+
+// PublishDelivery republishes a consumed delivery with the given headers to the queue of the tier for delay.
+// Delays are rounded up to the nearest tier, or down to the longest.
+func (q *DelayQueue) PublishDelivery(ctx context.Context, d queue.Delivery, headers queue.Headers, delay time.Duration) error {
+	return q.queues[q.tier(delay)].PublishDelivery(ctx, d, headers, 0)
+}
+
+// Compile-time assurance that implementation satisfies interface.
+var _ queue.DeliveryPublisher = &DelayQueue{}
@@ -0,0 +1,40 @@
+package amqp
+
+import (
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+)
+
+// delivery wraps an AMQP delivery.
+type delivery struct {
+	d amqp.Delivery
+}
+
+// Body returns the body of the delivery.
+func (d *delivery) Body() []byte {
+	return d.d.Body
+}
+
+// Headers returns the headers of the delivery.
+func (d *delivery) Headers() queue.Headers {
+	return queue.Headers(d.d.Headers)
+}
+
+// Priority returns the priority of the delivery.
+func (d *delivery) Priority() uint8 {
+	return d.d.Priority
+}
+
+// Ack acknowledges the delivery.
+func (d *delivery) Ack() error {
+	return d.d.Ack(false)
+}
+
+// Nack rejects the delivery, requeueing it when requeue is true.
+func (d *delivery) Nack(requeue bool) error {
+	return d.d.Reject(requeue)
+}
+
+// Compile-time assurance that implementation satisfies interface.
+var _ queue.Delivery = &delivery{}
@@ -2,7 +2,6 @@ package amqp
 
 import (
 	"context"
-	"log"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"go.opentelemetry.io/otel/attribute"
@@ -39,7 +38,7 @@ func (f PublisherFactory) NewPublisher(ctx context.Context) (queue.Publisher, er
 	go func() {
 		<-ctx.Done()
 		span.AddEvent("closing-amqp-context-closed")
-		log.Printf("Closing AMQP connection; context closed")
+		f.Component("amqp").Info("Closing AMQP connection; context closed", "queue", f.Queue)
 		conn.Close()
 	}()
 
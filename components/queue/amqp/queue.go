@@ -3,6 +3,8 @@ package amqp
 import (
 	"context"
 	"encoding/json"
+	"strconv"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"go.opentelemetry.io/otel/attribute"
@@ -24,6 +26,44 @@ func (q *Queue) String() string {
 	return q.name
 }
 
+// Check returns an error when the queue's channel is closed.
+func (q *Queue) Check(ctx context.Context) error {
+	return q.channel.Check(ctx)
+}
+
+// Ready returns the amount of messages ready to be delivered from the queue.
+func (q *Queue) Ready(ctx context.Context) (int, error) {
+	_, span := q.Tracer.Start(ctx, "queue.amqp.Ready")
+	defer span.End()
+
+	state, err := q.channel.ch.QueueInspect(q.name)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return state.Messages, nil
+}
+
+// SetPrefetch limits the unacknowledged deliveries sent over the queue's channel, which should only be used for
+// consuming from this queue. It applies to existing consumers as well.
+func (q *Queue) SetPrefetch(ctx context.Context, count int) error {
+	_, span := q.Tracer.Start(ctx, "queue.amqp.SetPrefetch", trace.WithAttributes(attribute.Int("count", count)))
+	defer span.End()
+
+	// Per-consumer limits only apply to new consumers; limit the channel instead.
+	err := q.channel.ch.Qos(
+		count,
+		0,    // prefetch size
+		true, // global
+	)
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	return err
+}
+
 // Publish adds a task with specified params to the Queue
 // priority: higher number, higher priority
 // TODO: Add context parameter, allow for timeouts etc
@@ -39,6 +79,7 @@ func (q *Queue) Publish(ctx context.Context, params interface{}, priority uint8)
 	body, err := json.Marshal(params)
 	if err != nil {
 		span.RecordError(err)
+		q.Metrics.QueuePublishErrors.Add(ctx, 1, attribute.String("queue", q.name))
 		return err
 	}
 
@@ -56,13 +97,70 @@ func (q *Queue) Publish(ctx context.Context, params interface{}, priority uint8)
 
 	if err != nil {
 		span.RecordError(err)
+		q.Metrics.QueuePublishErrors.Add(ctx, 1, attribute.String("queue", q.name))
+	}
+
+	return err
+}
+
+// PublishDelivery republishes the body and priority of a consumed delivery with the given headers.
+// When expiration is nonzero, the message expires after the given duration.
+func (q *Queue) PublishDelivery(ctx context.Context, d queue.Delivery, headers queue.Headers, expiration time.Duration) error {
+	ctx, span := q.Tracer.Start(ctx, "queue.amqp.PublishDelivery",
+		trace.WithAttributes(
+			attribute.String("queue", q.name),
+			attribute.Stringer("expiration", expiration)),
+	)
+	defer span.End()
+
+	msg := amqp.Publishing{
+		Headers:      amqp.Table(headers),
+		DeliveryMode: amqp.Transient,
+		ContentType:  "application/json",
+		Body:         d.Body(),
+		Priority:     d.Priority(),
+	}
+
+	if expiration > 0 {
+		msg.Expiration = strconv.FormatInt(expiration.Milliseconds(), 10)
+	}
+
+	err := q.channel.ch.Publish(
+		"",     // exchange
+		q.name, // routing key
+		true,   // mandatory
+		false,  // immediate
+		msg)
+
+	if err != nil {
+		span.RecordError(err)
+		q.Metrics.QueuePublishErrors.Add(ctx, 1, attribute.String("queue", q.name))
 	}
 
 	return err
 }
 
+// Get synchronously retrieves a single message from the queue, which is to be acknowledged.
+// Returns false when the queue is empty.
+func (q *Queue) Get(ctx context.Context) (queue.Delivery, bool, error) {
+	ctx, span := q.Tracer.Start(ctx, "queue.amqp.Get")
+	defer span.End()
+
+	d, ok, err := q.channel.ch.Get(q.name, false)
+	if err != nil {
+		span.RecordError(err)
+		return nil, false, err
+	}
+
+	if !ok {
+		return nil, false, nil
+	}
+
+	return &delivery{d}, true, nil
+}
+
 // Consume consumes messages from a queue
-func (q *Queue) Consume(ctx context.Context) (<-chan amqp.Delivery, error) {
+func (q *Queue) Consume(ctx context.Context) (<-chan queue.Delivery, error) {
 	ctx, span := q.Tracer.Start(ctx, "queue.amqp.Consume")
 	defer span.End()
 
@@ -81,8 +179,27 @@ func (q *Queue) Consume(ctx context.Context) (<-chan amqp.Delivery, error) {
 		return nil, err
 	}
 
-	return c, err
+	deliveries := make(chan queue.Delivery)
+
+	// Wrap AMQP deliveries, closing when the AMQP channel closes.
+	go func() {
+		for d := range c {
+			select {
+			case deliveries <- &delivery{d}:
+			case <-ctx.Done():
+				// Unacknowledged deliveries are returned to the queue by the broker.
+				return
+			}
+		}
+
+		close(deliveries)
+	}()
+
+	return deliveries, nil
 }
 
 // Compile-time assurance that implementation satisfies interface.
 var _ queue.Queue = &Queue{}
+var _ queue.DeliveryPublisher = &Queue{}
+var _ queue.Inspector = &Queue{}
+var _ queue.Prefetcher = &Queue{}
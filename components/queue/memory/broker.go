@@ -0,0 +1,228 @@
+// Package memory implements in-process queues with optional persistence to disk, as an alternative to AMQP for
+// single-node deployments.
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+// Broker holds named in-process queues, persisting them to disk when configured to.
+type Broker struct {
+	config *Config
+
+	mutex       sync.Mutex
+	queues      map[string]*Queue
+	delayQueues map[string]*DelayQueue
+
+	*instr.Instrumentation
+	log *slog.Logger
+}
+
+// NewBroker returns a new Broker.
+func NewBroker(config *Config, i *instr.Instrumentation) *Broker {
+	if config == nil {
+		panic("NewBroker Config cannot be nil.")
+	}
+
+	if i == nil {
+		panic("NewBroker Instrumentation cannot be nil.")
+	}
+
+	return &Broker{
+		config:          config,
+		queues:          make(map[string]*Queue),
+		delayQueues:     make(map[string]*DelayQueue),
+		Instrumentation: i,
+		log:             i.Component("queue"),
+	}
+}
+
+func (b *Broker) persistent() bool {
+	switch b.config.Persistence {
+	case PersistDisk:
+		return true
+	case PersistNone:
+		return false
+	default:
+		panic(fmt.Sprintf("unknown persistence: %s", b.config.Persistence))
+	}
+}
+
+func (b *Broker) path(name string) string {
+	return filepath.Join(b.config.Dir, name+".json")
+}
+
+// load reads the persisted messages for a queue, if any.
+func (b *Broker) load(name string) ([]*message, error) {
+	if !b.persistent() {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(b.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*message
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("reading persisted queue %s: %w", name, err)
+	}
+
+	b.log.Info("Restored messages", "queue", name, "messages", len(messages))
+
+	return messages, nil
+}
+
+// save atomically writes the messages for a queue.
+func (b *Broker) save(name string, messages []*message) error {
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return err
+	}
+
+	tmp := b.path(name) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, b.path(name))
+}
+
+func (b *Broker) queue(name string) (*Queue, error) {
+	if q, ok := b.queues[name]; ok {
+		return q, nil
+	}
+
+	messages, err := b.load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	q := newQueue(name, b.Instrumentation)
+	q.restore(messages)
+
+	b.queues[name] = q
+
+	return q, nil
+}
+
+// Queue returns the named queue, creating it when it doesn't exist.
+func (b *Broker) Queue(name string) (*Queue, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.queue(name)
+}
+
+// DelayQueue returns the named delay queue, creating it when it doesn't exist, from which messages are moved
+// to the target queue when due.
+func (b *Broker) DelayQueue(name string, target string) (*DelayQueue, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if q, ok := b.delayQueues[name]; ok {
+		return q, nil
+	}
+
+	t, err := b.queue(target)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := b.load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	q := newDelayQueue(name, t, b.Instrumentation)
+	q.restore(messages)
+
+	b.delayQueues[name] = q
+
+	return q, nil
+}
+
+// Persist writes all queues to disk.
+func (b *Broker) Persist(ctx context.Context) error {
+	_, span := b.Tracer.Start(ctx, "queue.memory.Persist")
+	defer span.End()
+
+	if !b.persistent() {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for name, q := range b.queues {
+		if err := b.save(name, q.snapshot()); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	}
+
+	for name, q := range b.delayQueues {
+		if err := b.save(name, q.snapshot()); err != nil {
+			span.RecordError(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Start creates the persistence directory and periodically persists queues until the context is closed.
+func (b *Broker) Start(ctx context.Context) error {
+	if !b.persistent() {
+		return nil
+	}
+
+	if err := os.MkdirAll(b.config.Dir, 0700); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(b.config.SnapshotInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := b.Persist(ctx); err != nil {
+					b.log.ErrorCtx(ctx, "Error persisting queues", "err", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Close stops delivering delayed messages and persists all queues.
+// Unacknowledged messages are persisted as well and will be delivered again.
+func (b *Broker) Close(ctx context.Context) error {
+	b.mutex.Lock()
+	for _, q := range b.delayQueues {
+		q.stop()
+	}
+	b.mutex.Unlock()
+
+	return b.Persist(ctx)
+}
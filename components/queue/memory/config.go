@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"time"
+)
+
+// Values for Config.Persistence.
+const (
+	PersistDisk = "disk" // Persist queues to disk periodically and on shutdown.
+	PersistNone = "none" // Don't persist queues; their contents are lost on shutdown.
+)
+
+// Config specifies the configuration for in-process queues.
+type Config struct {
+	Persistence      string        // PersistDisk or PersistNone.
+	Dir              string        // Directory holding persisted queues.
+	SnapshotInterval time.Duration // Interval between persisting queues to disk.
+}
+
+// DefaultConfig generates a default configuration for in-process queues.
+func DefaultConfig() *Config {
+	return &Config{
+		Persistence:      PersistDisk,
+		Dir:              "queues",
+		SnapshotInterval: time.Minute,
+	}
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+// DelayQueue holds messages until they are due, after which they are moved to the target queue.
+// Unlike the AMQP DelayQueue, messages are moved exactly when due, rather than after the delay of their tier.
+type DelayQueue struct {
+	name   string
+	target *Queue
+
+	mutex   sync.Mutex
+	delayed map[*message]*time.Timer
+	stopped bool
+
+	*instr.Instrumentation
+}
+
+func newDelayQueue(name string, target *Queue, i *instr.Instrumentation) *DelayQueue {
+	return &DelayQueue{
+		name:            name,
+		target:          target,
+		delayed:         make(map[*message]*time.Timer),
+		Instrumentation: i,
+	}
+}
+
+// String returns the name of the queue
+func (q *DelayQueue) String() string {
+	return q.name
+}
+
+// schedule moves a message to the target when it is due.
+func (q *DelayQueue) schedule(m *message) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.delayed[m] = time.AfterFunc(time.Until(m.Due), func() {
+		q.mutex.Lock()
+		if q.stopped {
+			// Retain for persistence.
+			q.mutex.Unlock()
+			return
+		}
+		delete(q.delayed, m)
+		q.mutex.Unlock()
+
+		q.target.push(&message{
+			Body:     m.Body,
+			Headers:  m.Headers,
+			Priority: m.Priority,
+		})
+	})
+}
+
+// stop stops moving messages to the target; they are retained for persistence.
+func (q *DelayQueue) stop() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.stopped = true
+
+	for _, timer := range q.delayed {
+		timer.Stop()
+	}
+}
+
+// snapshot returns the delayed messages.
+func (q *DelayQueue) snapshot() []*message {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	messages := make([]*message, 0, len(q.delayed))
+	for m := range q.delayed {
+		messages = append(messages, m)
+	}
+
+	return messages
+}
+
+// restore schedules persisted messages.
+func (q *DelayQueue) restore(messages []*message) {
+	for _, m := range messages {
+		q.schedule(m)
+	}
+}
+
+// Len returns the amount of delayed messages.
+func (q *DelayQueue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.delayed)
+}
+
+// PublishDelivery delays a delivery with the given headers for the expiration, after which it is published to the target.
+func (q *DelayQueue) PublishDelivery(ctx context.Context, d queue.Delivery, headers queue.Headers, expiration time.Duration) error {
+	_, span := q.Tracer.Start(ctx, "queue.memory.DelayQueue.PublishDelivery",
+		trace.WithAttributes(
+			attribute.String("queue", q.name),
+			attribute.Stringer("expiration", expiration)),
+	)
+	defer span.End()
+
+	q.schedule(&message{
+		Body:     d.Body(),
+		Headers:  headers,
+		Priority: d.Priority(),
+		Due:      time.Now().Add(expiration),
+	})
+
+	return nil
+}
+
+// Compile-time assurance that implementation satisfies interface.
+var _ queue.DeliveryPublisher = &DelayQueue{}
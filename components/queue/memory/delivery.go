@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"errors"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+)
+
+// ErrAcknowledged is returned when acknowledging or rejecting a delivery more than once.
+var ErrAcknowledged = errors.New("delivery already acknowledged")
+
+// delivery represents a message consumed from a Queue.
+type delivery struct {
+	q *Queue
+	m *message
+}
+
+// Body returns the body of the delivery.
+func (d *delivery) Body() []byte {
+	return d.m.Body
+}
+
+// Headers returns the headers of the delivery.
+func (d *delivery) Headers() queue.Headers {
+	return d.m.Headers
+}
+
+// Priority returns the priority of the delivery.
+func (d *delivery) Priority() uint8 {
+	return d.m.Priority
+}
+
+// Ack acknowledges the delivery, removing it from the queue.
+func (d *delivery) Ack() error {
+	if !d.q.settle(d.m) {
+		return ErrAcknowledged
+	}
+
+	return nil
+}
+
+// Nack rejects the delivery, returning it to the queue when requeue is true.
+func (d *delivery) Nack(requeue bool) error {
+	if !d.q.settle(d.m) {
+		return ErrAcknowledged
+	}
+
+	if requeue {
+		d.q.push(d.m)
+	}
+
+	return nil
+}
+
+// Compile-time assurance that implementation satisfies interface.
+var _ queue.Delivery = &delivery{}
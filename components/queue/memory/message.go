@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+)
+
+// message is an item in a queue, as persisted to disk.
+type message struct {
+	Seq      uint64        // Sequence number, retaining publishing order within a priority.
+	Body     []byte        // JSON encoded item.
+	Headers  queue.Headers `json:",omitempty"`
+	Priority uint8         // Higher number, higher priority.
+	Expires  time.Time     `json:",omitempty"` // Time after which the message is discarded, when nonzero.
+	Due      time.Time     `json:",omitempty"` // Time at which a delayed message moves to its target.
+}
+
+// messageHeap orders messages by descending priority, then by publishing order; it implements heap.Interface.
+type messageHeap []*message
+
+func (h messageHeap) Len() int { return len(h) }
+
+func (h messageHeap) Less(i, j int) bool {
+	if h[i].Priority != h[j].Priority {
+		return h[i].Priority > h[j].Priority
+	}
+
+	return h[i].Seq < h[j].Seq
+}
+
+func (h messageHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *messageHeap) Push(x interface{}) {
+	*h = append(*h, x.(*message))
+}
+
+func (h *messageHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	m := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+
+	return m
+}
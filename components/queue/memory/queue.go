@@ -0,0 +1,258 @@
+package memory
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+// Queue is an in-process priority queue. Consumed messages remain part of the queue until they are acknowledged.
+type Queue struct {
+	name string
+
+	mutex     sync.Mutex
+	pending   messageHeap
+	unacked   map[*message]struct{}
+	seq       uint64
+	available chan struct{}
+
+	*instr.Instrumentation
+}
+
+func newQueue(name string, i *instr.Instrumentation) *Queue {
+	return &Queue{
+		name:            name,
+		unacked:         make(map[*message]struct{}),
+		available:       make(chan struct{}, 1),
+		Instrumentation: i,
+	}
+}
+
+// String returns the name of the queue
+func (q *Queue) String() string {
+	return q.name
+}
+
+// signal notifies a waiting consumer of available messages.
+func (q *Queue) signal() {
+	select {
+	case q.available <- struct{}{}:
+	default:
+		// A consumer has already been notified.
+	}
+}
+
+// push adds a message to the queue, assigning a sequence number to new messages.
+func (q *Queue) push(m *message) {
+	q.mutex.Lock()
+
+	if m.Seq == 0 {
+		q.seq++
+		m.Seq = q.seq
+	}
+
+	heap.Push(&q.pending, m)
+
+	q.mutex.Unlock()
+
+	q.signal()
+}
+
+// tryPop returns the message with the highest priority, marking it unacknowledged, or nil when the queue is empty.
+// Expired messages are discarded.
+func (q *Queue) tryPop() *message {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := time.Now()
+
+	for q.pending.Len() > 0 {
+		m := heap.Pop(&q.pending).(*message)
+
+		if !m.Expires.IsZero() && now.After(m.Expires) {
+			continue
+		}
+
+		q.unacked[m] = struct{}{}
+
+		if q.pending.Len() > 0 {
+			// Allow other consumers to pick up remaining messages.
+			q.signal()
+		}
+
+		return m
+	}
+
+	return nil
+}
+
+// pop blocks until a message is available, returning nil when the context is closed.
+func (q *Queue) pop(ctx context.Context) *message {
+	for {
+		if m := q.tryPop(); m != nil {
+			return m
+		}
+
+		select {
+		case <-q.available:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// settle removes a message from the unacknowledged messages, returning false if it was not unacknowledged.
+func (q *Queue) settle(m *message) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if _, ok := q.unacked[m]; !ok {
+		return false
+	}
+
+	delete(q.unacked, m)
+
+	return true
+}
+
+// snapshot returns all messages in the queue, including unacknowledged ones.
+func (q *Queue) snapshot() []*message {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	messages := make([]*message, 0, len(q.pending)+len(q.unacked))
+	messages = append(messages, q.pending...)
+
+	for m := range q.unacked {
+		messages = append(messages, m)
+	}
+
+	return messages
+}
+
+// restore adds persisted messages to the queue.
+func (q *Queue) restore(messages []*message) {
+	q.mutex.Lock()
+
+	for _, m := range messages {
+		if m.Seq > q.seq {
+			q.seq = m.Seq
+		}
+
+		heap.Push(&q.pending, m)
+	}
+
+	q.mutex.Unlock()
+
+	q.signal()
+}
+
+// Len returns the amount of messages in the queue, including unacknowledged ones.
+func (q *Queue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.pending) + len(q.unacked)
+}
+
+// Ready returns the amount of messages ready to be consumed, excluding unacknowledged ones.
+func (q *Queue) Ready(ctx context.Context) (int, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.pending), nil
+}
+
+// Publish adds a task with specified params to the Queue
+// priority: higher number, higher priority
+func (q *Queue) Publish(ctx context.Context, params interface{}, priority uint8) error {
+	ctx, span := q.Tracer.Start(ctx, "queue.memory.Publish",
+		trace.WithAttributes(
+			attribute.String("queue", q.name),
+			attribute.Int("priority", int(priority))),
+	)
+	defer span.End()
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		span.RecordError(err)
+		q.Metrics.QueuePublishErrors.Add(ctx, 1, attribute.String("queue", q.name))
+		return err
+	}
+
+	q.push(&message{
+		Body:     body,
+		Priority: priority,
+	})
+
+	return nil
+}
+
+// PublishDelivery republishes the body and priority of a consumed delivery with the given headers.
+// When expiration is nonzero, the message is discarded when it has not been consumed after the given duration.
+func (q *Queue) PublishDelivery(ctx context.Context, d queue.Delivery, headers queue.Headers, expiration time.Duration) error {
+	_, span := q.Tracer.Start(ctx, "queue.memory.PublishDelivery",
+		trace.WithAttributes(
+			attribute.String("queue", q.name),
+			attribute.Stringer("expiration", expiration)),
+	)
+	defer span.End()
+
+	m := &message{
+		Body:     d.Body(),
+		Headers:  headers,
+		Priority: d.Priority(),
+	}
+
+	if expiration > 0 {
+		m.Expires = time.Now().Add(expiration)
+	}
+
+	q.push(m)
+
+	return nil
+}
+
+// Consume consumes messages from the queue until the context is closed.
+// Deliveries are sent as they are received, hence there is no prefetching.
+func (q *Queue) Consume(ctx context.Context) (<-chan queue.Delivery, error) {
+	_, span := q.Tracer.Start(ctx, "queue.memory.Consume")
+	defer span.End()
+
+	deliveries := make(chan queue.Delivery)
+
+	go func() {
+		for {
+			m := q.pop(ctx)
+			if m == nil {
+				return
+			}
+
+			select {
+			case deliveries <- &delivery{q, m}:
+			case <-ctx.Done():
+				// Not delivered; return to queue.
+				if q.settle(m) {
+					q.push(m)
+				}
+
+				return
+			}
+		}
+	}()
+
+	return deliveries, nil
+}
+
+// Compile-time assurance that implementation satisfies interface.
+var _ queue.Queue = &Queue{}
+var _ queue.DeliveryPublisher = &Queue{}
+var _ queue.Inspector = &Queue{}
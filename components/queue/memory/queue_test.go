@@ -0,0 +1,202 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+type MemoryTestSuite struct {
+	suite.Suite
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	cfg    *Config
+	b      *Broker
+}
+
+func (s *MemoryTestSuite) SetupTest() {
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 5*time.Second)
+
+	s.cfg = DefaultConfig()
+	s.cfg.Dir = s.T().TempDir()
+
+	s.b = NewBroker(s.cfg, instr.New())
+	s.Require().NoError(s.b.Start(s.ctx))
+}
+
+func (s *MemoryTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func (s *MemoryTestSuite) receive(deliveries <-chan queue.Delivery) queue.Delivery {
+	select {
+	case d := <-deliveries:
+		return d
+	case <-time.After(time.Second):
+		s.FailNow("timeout waiting for delivery")
+		return nil
+	}
+}
+
+func (s *MemoryTestSuite) TestPriority() {
+	q, err := s.b.Queue("hashes")
+	s.Require().NoError(err)
+
+	s.NoError(q.Publish(s.ctx, "low1", 1))
+	s.NoError(q.Publish(s.ctx, "high", 9))
+	s.NoError(q.Publish(s.ctx, "low2", 1))
+
+	deliveries, err := q.Consume(s.ctx)
+	s.Require().NoError(err)
+
+	for _, expected := range []string{`"high"`, `"low1"`, `"low2"`} {
+		d := s.receive(deliveries)
+		s.Equal(expected, string(d.Body()))
+		s.NoError(d.Ack())
+	}
+
+	s.Equal(0, q.Len())
+}
+
+func (s *MemoryTestSuite) TestAckNack() {
+	q, err := s.b.Queue("hashes")
+	s.Require().NoError(err)
+
+	s.NoError(q.Publish(s.ctx, "item", 5))
+
+	deliveries, err := q.Consume(s.ctx)
+	s.Require().NoError(err)
+
+	d := s.receive(deliveries)
+	s.Equal(uint8(5), d.Priority())
+	s.Equal(1, q.Len(), "unacknowledged deliveries remain in the queue")
+
+	ready, err := q.Ready(s.ctx)
+	s.NoError(err)
+	s.Equal(0, ready, "unacknowledged deliveries are not ready")
+
+	// Requeue
+	s.NoError(d.Nack(true))
+	s.ErrorIs(d.Ack(), ErrAcknowledged)
+
+	d = s.receive(deliveries)
+	s.Equal(`"item"`, string(d.Body()))
+
+	// Discard
+	s.NoError(d.Nack(false))
+	s.Equal(0, q.Len())
+}
+
+func (s *MemoryTestSuite) TestSameQueue() {
+	q1, err := s.b.Queue("hashes")
+	s.Require().NoError(err)
+
+	q2, err := s.b.Queue("hashes")
+	s.Require().NoError(err)
+
+	s.Same(q1, q2)
+}
+
+func (s *MemoryTestSuite) TestExpiration() {
+	q, err := s.b.Queue("hashes")
+	s.Require().NoError(err)
+
+	d := queue.NewMockDelivery([]byte(`"expiring"`), nil, 1)
+	s.NoError(q.PublishDelivery(s.ctx, d, nil, time.Millisecond))
+	s.NoError(q.Publish(s.ctx, "item", 0))
+
+	time.Sleep(5 * time.Millisecond)
+
+	deliveries, err := q.Consume(s.ctx)
+	s.Require().NoError(err)
+
+	s.Equal(`"item"`, string(s.receive(deliveries).Body()))
+}
+
+func (s *MemoryTestSuite) TestDelayQueue() {
+	dq, err := s.b.DelayQueue("hashes.delay", "hashes")
+	s.Require().NoError(err)
+
+	q, err := s.b.Queue("hashes")
+	s.Require().NoError(err)
+
+	d := queue.NewMockDelivery([]byte(`"delayed"`), nil, 3)
+	headers := queue.Headers{"x-attempt": int32(1)}
+	s.NoError(dq.PublishDelivery(s.ctx, d, headers, 10*time.Millisecond))
+
+	s.Equal(1, dq.Len())
+	s.Equal(0, q.Len())
+
+	deliveries, err := q.Consume(s.ctx)
+	s.Require().NoError(err)
+
+	delivered := s.receive(deliveries)
+	s.Equal(`"delayed"`, string(delivered.Body()))
+	s.Equal(uint8(3), delivered.Priority())
+	s.Equal(headers, delivered.Headers())
+	s.Equal(0, dq.Len())
+}
+
+func (s *MemoryTestSuite) TestPersistence() {
+	q, err := s.b.Queue("hashes")
+	s.Require().NoError(err)
+
+	dq, err := s.b.DelayQueue("hashes.delay", "hashes")
+	s.Require().NoError(err)
+
+	s.NoError(q.Publish(s.ctx, "pending", 1))
+	s.NoError(q.Publish(s.ctx, "unacked", 9))
+	s.NoError(dq.PublishDelivery(s.ctx, queue.NewMockDelivery([]byte(`"delayed"`), nil, 1), nil, time.Hour))
+
+	deliveries, err := q.Consume(s.ctx)
+	s.Require().NoError(err)
+	s.Equal(`"unacked"`, string(s.receive(deliveries).Body()))
+
+	s.cancel()
+	s.NoError(s.b.Close(context.Background()))
+
+	// Restore in a new broker.
+	b := NewBroker(s.cfg, instr.New())
+
+	q, err = b.Queue("hashes")
+	s.Require().NoError(err)
+	s.Equal(2, q.Len())
+
+	dq, err = b.DelayQueue("hashes.delay", "hashes")
+	s.Require().NoError(err)
+	s.Equal(1, dq.Len())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deliveries, err = q.Consume(ctx)
+	s.Require().NoError(err)
+
+	// Publishing order is retained.
+	s.Equal(`"unacked"`, string(s.receive(deliveries).Body()))
+	s.Equal(`"pending"`, string(s.receive(deliveries).Body()))
+}
+
+func (s *MemoryTestSuite) TestNoPersistence() {
+	s.cfg.Persistence = PersistNone
+
+	q, err := s.b.Queue("hashes")
+	s.Require().NoError(err)
+	s.NoError(q.Publish(s.ctx, "item", 1))
+
+	s.NoError(s.b.Close(s.ctx))
+
+	q, err = NewBroker(s.cfg, instr.New()).Queue("hashes")
+	s.Require().NoError(err)
+	s.Equal(0, q.Len())
+}
+
+func TestMemoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryTestSuite))
+}
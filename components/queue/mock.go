@@ -2,8 +2,8 @@ package queue
 
 import (
 	"context"
+	"time"
 
-	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -19,9 +19,61 @@ func (m *Mock) Publish(ctx context.Context, pub interface{}, priority uint8) err
 }
 
 // Consume mocks the corresponding method on the Queue interface.
-func (m *Mock) Consume(ctx context.Context) (<-chan amqp.Delivery, error) {
+func (m *Mock) Consume(ctx context.Context) (<-chan Delivery, error) {
 	args := m.Called(ctx)
-	return args.Get(0).(<-chan amqp.Delivery), args.Error(1)
+	return args.Get(0).(<-chan Delivery), args.Error(1)
+}
+
+// PublishDelivery mocks the corresponding method on the DeliveryPublisher interface.
+func (m *Mock) PublishDelivery(ctx context.Context, d Delivery, headers Headers, expiration time.Duration) error {
+	args := m.Called(ctx, d, headers, expiration)
+	return args.Error(0)
+}
+
+// MockDelivery mocks the Delivery interface; Ack and Nack are mocked, the other methods return
+// the values it was created with.
+type MockDelivery struct {
+	mock.Mock
+
+	body     []byte
+	headers  Headers
+	priority uint8
+}
+
+// NewMockDelivery returns a new MockDelivery.
+func NewMockDelivery(body []byte, headers Headers, priority uint8) *MockDelivery {
+	return &MockDelivery{
+		body:     body,
+		headers:  headers,
+		priority: priority,
+	}
+}
+
+// Body returns the body of the delivery.
+func (d *MockDelivery) Body() []byte {
+	return d.body
+}
+
+// Headers returns the headers of the delivery.
+func (d *MockDelivery) Headers() Headers {
+	return d.headers
+}
+
+// Priority returns the priority of the delivery.
+func (d *MockDelivery) Priority() uint8 {
+	return d.priority
+}
+
+// Ack mocks the corresponding method on the Delivery interface.
+func (d *MockDelivery) Ack() error {
+	args := d.Called()
+	return args.Error(0)
+}
+
+// Nack mocks the corresponding method on the Delivery interface.
+func (d *MockDelivery) Nack(requeue bool) error {
+	args := d.Called(requeue)
+	return args.Error(0)
 }
 
 // MockFactory mocks the Factory interface.
@@ -37,4 +89,6 @@ func (f *MockFactory) NewPublisher(ctx context.Context) (Publisher, error) {
 
 // Compile-time assurance that implementation satisfies interface.
 var _ Queue = &Mock{}
+var _ DeliveryPublisher = &Mock{}
+var _ Delivery = &MockDelivery{}
 var _ PublisherFactory = &MockFactory{}
@@ -3,10 +3,24 @@ package queue
 
 import (
 	"context"
-
-	amqp "github.com/rabbitmq/amqp091-go"
+	"time"
 )
 
+// Headers annotate a Delivery.
+type Headers map[string]interface{}
+
+// Delivery represents an item consumed from a Queue, which is to be acknowledged or rejected.
+type Delivery interface {
+	Body() []byte
+	Headers() Headers
+	Priority() uint8
+
+	// Ack acknowledges the delivery, removing it from the queue.
+	Ack() error
+	// Nack rejects the delivery, returning it to the queue when requeue is true and discarding it otherwise.
+	Nack(requeue bool) error
+}
+
 // Publisher allows publishing of sniffed items.
 type Publisher interface {
 	Publish(context.Context, interface{}, uint8) error
@@ -14,7 +28,23 @@ type Publisher interface {
 
 // Consumer allows consuming of published items.
 type Consumer interface {
-	Consume(context.Context) (<-chan amqp.Delivery, error)
+	Consume(context.Context) (<-chan Delivery, error)
+}
+
+// DeliveryPublisher allows (re)publishing of consumed deliveries with custom headers, optionally expiring
+// after a given duration.
+type DeliveryPublisher interface {
+	PublishDelivery(ctx context.Context, d Delivery, headers Headers, expiration time.Duration) error
+}
+
+// Inspector allows inspecting the amount of messages ready to be consumed, e.g. to scale consumers.
+type Inspector interface {
+	Ready(context.Context) (int, error)
+}
+
+// Prefetcher allows limiting the amount of unacknowledged deliveries sent to consumers.
+type Prefetcher interface {
+	SetPrefetch(context.Context, int) error
 }
 
 // PublisherFactory creates Publishers.
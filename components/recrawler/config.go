@@ -0,0 +1,25 @@
+package recrawler
+
+import (
+	"time"
+)
+
+// Config contains configuration for the Recrawler.
+type Config struct {
+	MaxAge          time.Duration // Documents not seen (nor checked) for longer are scheduled for re-crawling.
+	Interval        time.Duration // Time in between scheduling runs.
+	BatchSize       uint          // Amount of documents retrieved at once.
+	MaxDocuments    uint          // Maximum amount of documents scheduled per index per run.
+	ScrollKeepAlive time.Duration // Time to keep the scroll alive in between batches.
+}
+
+// DefaultConfig generates a default configuration for the Recrawler.
+func DefaultConfig() *Config {
+	return &Config{
+		MaxAge:          30 * 24 * time.Hour,
+		Interval:        6 * time.Hour,
+		BatchSize:       1000,
+		MaxDocuments:    100000,
+		ScrollKeepAlive: 5 * time.Minute,
+	}
+}
@@ -0,0 +1,184 @@
+// Package recrawler schedules re-crawling of stale documents, in order to verify their availability.
+package recrawler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+
+	"github.com/ipfs-search/ipfs-search/components/index/opensearch"
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// Lowest priority; re-crawls should not hold up new content.
+const priority = 0
+
+// errMaxDocuments is an internal error to stop scrolling when MaxDocuments has been reached.
+var errMaxDocuments = errors.New("maximum documents reached")
+
+// Scroller scrolls through the results of a search query.
+type Scroller interface {
+	Scroll(ctx context.Context, indexes []string, body interface{}, keepAlive time.Duration, f opensearch.ScrollFunc) error
+}
+
+// Target is an index to schedule re-crawls for, with the queue and the type for its resources.
+type Target struct {
+	Index string
+	Queue queue.Publisher
+	Type  t.ResourceType
+}
+
+// Recrawler schedules re-crawls for stale documents.
+type Recrawler struct {
+	config   *Config
+	scroller Scroller
+	targets  []Target
+
+	*instr.Instrumentation
+	log *slog.Logger
+}
+
+// New returns a new Recrawler.
+func New(config *Config, scroller Scroller, targets []Target, i *instr.Instrumentation) *Recrawler {
+	return &Recrawler{
+		config, scroller, targets, i,
+		i.Component("recrawler"),
+	}
+}
+
+// getQuery returns the query for documents which have been neither seen nor checked since before.
+func (r *Recrawler) getQuery(before time.Time) map[string]interface{} {
+	beforeRange := map[string]interface{}{
+		"lt":     before.UTC().Format(time.RFC3339),
+		"format": "date_time_no_millis",
+	}
+
+	return map[string]interface{}{
+		"size":    r.config.BatchSize,
+		"_source": false,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{
+						"range": map[string]interface{}{"last-seen": beforeRange},
+					},
+				},
+				"must_not": []interface{}{
+					// Recently checked; possibly found unreachable.
+					map[string]interface{}{
+						"range": map[string]interface{}{
+							"last-checked": map[string]interface{}{
+								"gte":    before.UTC().Format(time.RFC3339),
+								"format": "date_time_no_millis",
+							},
+						},
+					},
+					// Additional pages of large directories.
+					map[string]interface{}{
+						"exists": map[string]interface{}{"field": "page"},
+					},
+				},
+			},
+		},
+		// Scrolling is most efficient in index order.
+		"sort": []string{"_doc"},
+	}
+}
+
+// scheduleTarget queues stale documents in a target index, returning the amount of queued documents.
+func (r *Recrawler) scheduleTarget(ctx context.Context, target *Target, before time.Time) (uint, error) {
+	ctx, span := r.Tracer.Start(ctx, "recrawler.scheduleTarget", trace.WithAttributes(
+		attribute.String("index", target.Index),
+	))
+	defer span.End()
+
+	var cnt uint
+
+	queueHits := func(hits []opensearch.SearchHit) error {
+		for _, h := range hits {
+			if cnt >= r.config.MaxDocuments {
+				return errMaxDocuments
+			}
+
+			resource := &t.AnnotatedResource{
+				Resource: &t.Resource{
+					Protocol: t.IPFSProtocol,
+					ID:       h.ID,
+				},
+				Source: t.RecrawlSource,
+				Stat: t.Stat{
+					Type: target.Type,
+				},
+			}
+
+			if err := target.Queue.Publish(ctx, resource, priority); err != nil {
+				return err
+			}
+
+			cnt++
+		}
+
+		return nil
+	}
+
+	err := r.scroller.Scroll(ctx, []string{target.Index}, r.getQuery(before), r.config.ScrollKeepAlive, queueHits)
+	if errors.Is(err, errMaxDocuments) {
+		r.log.InfoCtx(ctx, "Reached maximum of documents, continuing next run", "index", target.Index, "max_documents", r.config.MaxDocuments)
+		err = nil
+	}
+
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	return cnt, err
+}
+
+// Schedule queues stale documents for all targets once.
+func (r *Recrawler) Schedule(ctx context.Context) error {
+	ctx, span := r.Tracer.Start(ctx, "recrawler.Schedule")
+	defer span.End()
+
+	before := time.Now().Add(-r.config.MaxAge)
+
+	for i := range r.targets {
+		target := &r.targets[i]
+
+		cnt, err := r.scheduleTarget(ctx, target, before)
+		r.log.InfoCtx(ctx, "Scheduled documents for re-crawling", "index", target.Index, "documents", cnt)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Start schedules stale documents every Interval, until the context is closed.
+func (r *Recrawler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Schedule(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			r.log.ErrorCtx(ctx, "Error scheduling re-crawls, retrying next run", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
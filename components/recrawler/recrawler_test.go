@@ -0,0 +1,162 @@
+package recrawler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/components/index/opensearch"
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+type scrollerMock struct {
+	mock.Mock
+}
+
+func (m *scrollerMock) Scroll(ctx context.Context, indexes []string, body interface{}, keepAlive time.Duration, f opensearch.ScrollFunc) error {
+	args := m.Called(ctx, indexes, body, keepAlive, f)
+	return args.Error(0)
+}
+
+// scrollHits returns a Run function calling the ScrollFunc for each page of hits.
+func scrollHits(pages ...[]opensearch.SearchHit) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		f := args.Get(4).(opensearch.ScrollFunc)
+
+		for _, p := range pages {
+			if err := f(p); err != nil {
+				return
+			}
+		}
+	}
+}
+
+type RecrawlerTestSuite struct {
+	suite.Suite
+
+	ctx      context.Context
+	cfg      *Config
+	scroller *scrollerMock
+	fileQ    *queue.Mock
+	dirQ     *queue.Mock
+	r        *Recrawler
+}
+
+func (s *RecrawlerTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.cfg = DefaultConfig()
+	s.scroller = &scrollerMock{}
+	s.fileQ, s.dirQ = &queue.Mock{}, &queue.Mock{}
+
+	targets := []Target{
+		{Index: "ipfs_files", Queue: s.fileQ, Type: t.FileType},
+		{Index: "ipfs_directories", Queue: s.dirQ, Type: t.DirectoryType},
+	}
+
+	s.r = New(s.cfg, s.scroller, targets, instr.New())
+}
+
+func (s *RecrawlerTestSuite) assertExpectations() {
+	mock.AssertExpectationsForObjects(s.T(), s.scroller, s.fileQ, s.dirQ)
+}
+
+func isRecrawl(id string, rType t.ResourceType) func(*t.AnnotatedResource) bool {
+	return func(r *t.AnnotatedResource) bool {
+		return r.ID == id && r.Protocol == t.IPFSProtocol &&
+			r.Source == t.RecrawlSource && r.Type == rType
+	}
+}
+
+func (s *RecrawlerTestSuite) TestSchedule() {
+	s.scroller.
+		On("Scroll", mock.Anything, []string{"ipfs_files"}, mock.Anything, s.cfg.ScrollKeepAlive, mock.Anything).
+		Run(scrollHits(
+			[]opensearch.SearchHit{{ID: "QmFile1"}, {ID: "QmFile2"}},
+			[]opensearch.SearchHit{{ID: "QmFile3"}},
+		)).
+		Return(nil).
+		Once()
+
+	s.scroller.
+		On("Scroll", mock.Anything, []string{"ipfs_directories"}, mock.Anything, s.cfg.ScrollKeepAlive, mock.Anything).
+		Run(scrollHits([]opensearch.SearchHit{{ID: "QmDir"}})).
+		Return(nil).
+		Once()
+
+	for _, id := range []string{"QmFile1", "QmFile2", "QmFile3"} {
+		s.fileQ.
+			On("Publish", mock.Anything, mock.MatchedBy(isRecrawl(id, t.FileType)), uint8(0)).
+			Return(nil).
+			Once()
+	}
+
+	s.dirQ.
+		On("Publish", mock.Anything, mock.MatchedBy(isRecrawl("QmDir", t.DirectoryType)), uint8(0)).
+		Return(nil).
+		Once()
+
+	s.NoError(s.r.Schedule(s.ctx))
+	s.assertExpectations()
+}
+
+func (s *RecrawlerTestSuite) TestScheduleMaxDocuments() {
+	s.cfg.MaxDocuments = 2
+
+	// Scrolling stops after MaxDocuments.
+	s.scroller.
+		On("Scroll", mock.Anything, []string{"ipfs_files"}, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			f := args.Get(4).(opensearch.ScrollFunc)
+			err := f([]opensearch.SearchHit{{ID: "QmFile1"}, {ID: "QmFile2"}, {ID: "QmFile3"}})
+			s.ErrorIs(err, errMaxDocuments)
+		}).
+		Return(errMaxDocuments).
+		Once()
+
+	s.scroller.
+		On("Scroll", mock.Anything, []string{"ipfs_directories"}, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).
+		Once()
+
+	s.fileQ.
+		On("Publish", mock.Anything, mock.Anything, uint8(0)).
+		Return(nil).
+		Twice()
+
+	s.NoError(s.r.Schedule(s.ctx))
+	s.assertExpectations()
+}
+
+func (s *RecrawlerTestSuite) TestScheduleError() {
+	scrollErr := errors.New("scroll error")
+
+	s.scroller.
+		On("Scroll", mock.Anything, []string{"ipfs_files"}, mock.Anything, mock.Anything, mock.Anything).
+		Return(scrollErr).
+		Once()
+
+	s.ErrorIs(s.r.Schedule(s.ctx), scrollErr)
+	s.assertExpectations()
+}
+
+func (s *RecrawlerTestSuite) TestQuery() {
+	before := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	q := s.r.getQuery(before)
+
+	s.Equal(s.cfg.BatchSize, q["size"])
+
+	boolQuery := q["query"].(map[string]interface{})["bool"].(map[string]interface{})
+	lastSeen := boolQuery["filter"].([]interface{})[0].(map[string]interface{})["range"].(map[string]interface{})["last-seen"]
+
+	s.Equal("2022-10-01T12:00:00Z", lastSeen.(map[string]interface{})["lt"])
+}
+
+func TestRecrawlerTestSuite(t *testing.T) {
+	suite.Run(t, new(RecrawlerTestSuite))
+}
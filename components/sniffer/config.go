@@ -2,10 +2,21 @@ package sniffer
 
 import "time"
 
+// Values for Config.DedupFilter.
+const (
+	DedupLastSeen = "lastseen" // Remember when resources were last seen, up to LastSeenPruneLen before pruning.
+	DedupBloom    = "bloom"    // Remember resources in Bloom filters of fixed size, with false positives.
+)
+
 // Config holds configuration for a Sniffer.
 type Config struct {
 	LastSeenExpiration time.Duration // Expiration time for the last-seen resources
 	LastSeenPruneLen   int           // Cleanup expired resources from the last-seen
+	LastSeenSnapshot   string        // File to persist the last-seen resources to, restored on start; disabled when empty
+	SnapshotInterval   time.Duration // Interval between persisting the last-seen resources
+	DedupFilter        string        // Filter for recently seen resources: DedupLastSeen or DedupBloom
+	BloomCapacity      uint          // Resources seen per expiration which the Bloom filter holds at its false positive rate
+	BloomFPRate        float64       // Fraction of new resources filtered as seen by the Bloom filter
 	LoggerTimeout      time.Duration // Throw timeout error when no log messages arrive
 	BufferSize         uint          // Size of the channels buffering between yielder, filter and adder
 }
@@ -15,6 +26,11 @@ func DefaultConfig() *Config {
 	return &Config{
 		LastSeenExpiration: 60 * time.Duration(time.Minute),
 		LastSeenPruneLen:   32768,
+		LastSeenSnapshot:   "",
+		SnapshotInterval:   time.Minute,
+		DedupFilter:        DedupLastSeen,
+		BloomCapacity:      4000000,
+		BloomFPRate:        0.001,
 		LoggerTimeout:      60 * time.Duration(time.Second),
 		BufferSize:         512,
 	}
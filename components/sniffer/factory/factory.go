@@ -2,7 +2,6 @@ package factory
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"net"
@@ -47,6 +46,7 @@ func getQueue(ctx context.Context, cfg *amqp.Config, i *instr.Instrumentation) a
 			DualStack: false,
 		},
 		Context: ctx,
+		Logger:  i.Component("dialer"),
 	}
 	samqpConfig := &samqp.Config{
 		Dial: dialer.Dial,
@@ -97,8 +97,13 @@ func Start(ctx context.Context, ds datastore.Batching) (context.Context, datasto
 		defer cancel()
 		defer instFlusher(ctx)
 
-		err = s.Sniff(ctx)
-		fmt.Printf("Sniffer exited: %s\n", err)
+		log := i.Component("sniffer")
+
+		if err := s.Sniff(ctx); err != nil && ctx.Err() == nil {
+			log.ErrorCtx(ctx, "Sniffer exited", "err", err)
+		} else {
+			log.InfoCtx(ctx, "Sniffer exited", "err", err)
+		}
 	}()
 
 	return ctx, ds, nil
@@ -0,0 +1,171 @@
+package providerfilters
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// bloom is a Bloom filter with k hash functions over m bits.
+type bloom struct {
+	bits  []uint64
+	m     uint64
+	k     uint64
+	count uint // Resources added.
+}
+
+func newBloom(m, k uint64) *bloom {
+	return &bloom{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// test returns whether the hashes h1 and h2 have been added, with double hashing.
+func (b *bloom) test(h1, h2 uint64) bool {
+	for i := uint64(0); i < b.k; i++ {
+		n := (h1 + i*h2) % b.m
+		if b.bits[n/64]&(1<<(n%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b *bloom) add(h1, h2 uint64) {
+	for i := uint64(0); i < b.k; i++ {
+		n := (h1 + i*h2) % b.m
+		b.bits[n/64] |= 1 << (n % 64)
+	}
+
+	b.count++
+}
+
+func (b *bloom) reset() {
+	for i := range b.bits {
+		b.bits[i] = 0
+	}
+
+	b.count = 0
+}
+
+// bloomSize returns the amount of bits and hash functions for a Bloom filter holding capacity resources at the given
+// false positive rate.
+func bloomSize(capacity uint, falsePositiveRate float64) (m, k uint64) {
+	n := float64(max(capacity, 1))
+	bitsPerResource := -math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)
+
+	m = uint64(math.Ceil(n * bitsPerResource))
+	k = uint64(math.Max(1, math.Round(bitsPerResource*math.Ln2)))
+
+	return m, k
+}
+
+// hash returns two hashes for r, based on 64-bit FNV-1a, without allocating.
+func hash(r *t.Resource) (uint64, uint64) {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+
+	h := uint64(offset)
+	h = (h ^ uint64(r.Protocol)) * prime
+
+	for i := 0; i < len(r.ID); i++ {
+		h = (h ^ uint64(r.ID[i])) * prime
+	}
+
+	// Derive the second hash with the splitmix64 finalizer; it is odd, so that probes do not repeat early.
+	h2 := h + 0x9e3779b97f4a7c15
+	h2 = (h2 ^ (h2 >> 30)) * 0xbf58476d1ce4e5b9
+	h2 = (h2 ^ (h2 >> 27)) * 0x94d049bb133111eb
+	h2 ^= h2 >> 31
+
+	return h, bits.RotateLeft64(h2, 1) | 1
+}
+
+// BloomFilter filters out recently seen Providers in fixed memory, using two generations of Bloom filters. Providers
+// are filtered for at least the expiration and at most twice as long; a fraction of new Providers, about the false
+// positive rate, is filtered as well. A generation is retired after the expiration or when it reaches capacity,
+// whichever comes first.
+type BloomFilter struct {
+	mu         sync.Mutex
+	current    *bloom
+	previous   *bloom
+	rotated    time.Time
+	expiration time.Duration
+	capacity   uint
+
+	now func() time.Time
+	log *slog.Logger
+}
+
+// NewBloomFilter returns a BloomFilter holding up to capacity resources per expiration, at the given false positive
+// rate. It uses about 2 * capacity * -ln(falsePositiveRate) / ln(2)^2 bits.
+func NewBloomFilter(expiration time.Duration, capacity uint, falsePositiveRate float64) *BloomFilter {
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		panic("false positive rate should be between 0 and 1")
+	}
+
+	m, k := bloomSize(capacity, falsePositiveRate)
+
+	return &BloomFilter{
+		current:    newBloom(m, k),
+		previous:   newBloom(m, k),
+		rotated:    time.Now(),
+		expiration: expiration,
+		capacity:   capacity,
+		now:        time.Now,
+		log:        instr.New().Component("sniffer"),
+	}
+}
+
+// rotate retires the previous generation when the current one expired or reached capacity.
+func (f *BloomFilter) rotate() {
+	now := f.now()
+
+	if now.Sub(f.rotated) < f.expiration && f.current.count < f.capacity {
+		return
+	}
+
+	f.log.Debug("Rotating Bloom filter", "count", f.current.count, "capacity", f.capacity, "age", now.Sub(f.rotated))
+
+	f.previous.reset()
+	f.current, f.previous = f.previous, f.current
+	f.rotated = now
+}
+
+// Filter takes a Provider and returns true when it is to be included, false
+// when not and an error when unexpected condition occur.
+func (f *BloomFilter) Filter(p t.Provider) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rotate()
+
+	h1, h2 := hash(p.Resource)
+
+	if f.current.test(h1, h2) || f.previous.test(h1, h2) {
+		return false, nil
+	}
+
+	f.current.add(h1, h2)
+
+	return true, nil
+}
+
+func max(a, b uint) uint {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,127 @@
+package providerfilters
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ipfs-search/ipfs-search/types"
+)
+
+// makeProviders returns providers for n distinct resources.
+func makeProviders(n int) []types.Provider {
+	providers := make([]types.Provider, n)
+
+	for i := range providers {
+		providers[i] = *makeProvider(&types.Resource{
+			Protocol: types.IPFSProtocol,
+			ID:       fmt.Sprintf("bafkreiblvqc3q73ygovlzaxz4iilm5fopppcdc3uzkrtepjsgk%d", i),
+		})
+	}
+
+	return providers
+}
+
+func TestBloomFilterExpiration(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	f := NewBloomFilter(time.Hour, 1000, 0.01)
+	f.now = func() time.Time { return now }
+	f.rotated = now
+
+	p := *makeProvider(nil)
+
+	include, err := f.Filter(p)
+	assert.NoError(err)
+	assert.True(include)
+
+	include, _ = f.Filter(p)
+	assert.False(include)
+
+	// Seen within the previous generation.
+	now = now.Add(time.Hour)
+	include, _ = f.Filter(p)
+	assert.False(include)
+
+	now = now.Add(time.Hour)
+	include, _ = f.Filter(p)
+	assert.True(include)
+}
+
+func TestBloomFilterCapacity(t *testing.T) {
+	assert := assert.New(t)
+
+	const capacity = 10000
+
+	f := NewBloomFilter(time.Hour, capacity, 0.01)
+	providers := makeProviders(3 * capacity)
+
+	included := 0
+	for _, p := range providers[:capacity] {
+		if include, _ := f.Filter(p); include {
+			included++
+		}
+	}
+
+	// New resources are filtered at about the false positive rate.
+	assert.InDelta(capacity, included, capacity*0.02)
+
+	// Past capacity, the first generation is retired, but still filters.
+	for _, p := range providers[capacity : 2*capacity] {
+		f.Filter(p)
+	}
+
+	include, _ := f.Filter(providers[0])
+	assert.False(include)
+
+	for _, p := range providers[2*capacity:] {
+		f.Filter(p)
+	}
+
+	include, _ = f.Filter(providers[0])
+	assert.True(include)
+}
+
+func TestBloomSize(t *testing.T) {
+	m, k := bloomSize(1000000, 0.001)
+
+	// About 14.4 bits and 10 hash functions per resource.
+	assert.InDelta(t, 14377588, m, 10)
+	assert.Equal(t, uint64(10), k)
+}
+
+// benchmarkFilter filters providers for n distinct resources, cycling through them, after having seen them all.
+func benchmarkFilter(b *testing.B, f Filter, n int) {
+	providers := makeProviders(n)
+
+	for _, p := range providers {
+		if _, err := f.Filter(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := f.Filter(providers[i%n]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLastSeenFilter(b *testing.B) {
+	benchmarkFilter(b, NewLastSeenFilter(time.Hour, 1<<20), 1<<16)
+}
+
+// BenchmarkLastSeenFilterPruning benchmarks the LastSeenFilter above its prune length, which it scans on every call.
+func BenchmarkLastSeenFilterPruning(b *testing.B) {
+	benchmarkFilter(b, NewLastSeenFilter(time.Hour, 1<<12), 1<<13)
+}
+
+func BenchmarkBloomFilter(b *testing.B) {
+	benchmarkFilter(b, NewBloomFilter(time.Hour, 1<<20, 0.001), 1<<16)
+}
@@ -15,7 +15,7 @@ var (
 	errUnsupportedCodec    = errors.New("unsupported codec")
 )
 
-// CidFilter filters out invalid CID's or those which are not Raw or DagProtobuf.
+// CidFilter filters out invalid CID's or those which are not Raw, DagProtobuf, DagCBOR or DAG-JSON.
 type CidFilter struct{}
 
 // NewCidFilter returns a pointer to a new CidFilter.
@@ -40,6 +40,9 @@ func (f *CidFilter) Filter(p t.Provider) (bool, error) {
 	case cid.Raw, cid.DagProtobuf:
 		// (Potential) files and directories
 		return true, nil
+	case cid.DagCBOR, t.DagJSONCodec:
+		// Structured IPLD data
+		return true, nil
 	default:
 		// Can't handle other types (for now)
 		return false, fmt.Errorf("%w: %s for %v", errUnsupportedCodec, cid.CodecToStr[cidType], p)
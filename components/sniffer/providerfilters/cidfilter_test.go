@@ -70,6 +70,38 @@ func TestCid1Protobuf(t *testing.T) {
 	assert.True(result)
 }
 
+func TestCid1DagCBOR(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &types.Resource{
+		Protocol: types.IPFSProtocol,
+		ID:       "bafyreifuu2zzh3vxdpgi5qlsewfkktkvz46cm5h6sxrlpmi7tvfrzf5y6e",
+	}
+
+	p := makeProvider(r)
+
+	result, err := filter.Filter(*p)
+
+	assert.Empty(err)
+	assert.True(result)
+}
+
+func TestCid1DagJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &types.Resource{
+		Protocol: types.IPFSProtocol,
+		ID:       "baguqeeratv5fmloucioidrpxq7xoeqgx2aa2me5oulp5mlckcpllfn7sxi4q",
+	}
+
+	p := makeProvider(r)
+
+	result, err := filter.Filter(*p)
+
+	assert.Empty(err)
+	assert.True(result)
+}
+
 func TestUnsupported(t *testing.T) {
 	assert := assert.New(t)
 
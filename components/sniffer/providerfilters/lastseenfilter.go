@@ -1,9 +1,12 @@
 package providerfilters
 
 import (
-	"log"
+	"sync"
 	"time"
 
+	"golang.org/x/exp/slog"
+
+	"github.com/ipfs-search/ipfs-search/instr"
 	t "github.com/ipfs-search/ipfs-search/types"
 )
 
@@ -11,10 +14,13 @@ const logEvery = 1000
 
 // LastSeenFilter filters out recently seen Providers.
 type LastSeenFilter struct {
+	mu         sync.Mutex
 	resources  map[string]time.Time
 	icount     uint // Iteration counter.
 	Expiration time.Duration
 	PruneLen   int
+
+	log *slog.Logger
 }
 
 // NewLastSeenFilter initialises a new LastSeenFilter and returns a pointer to it.
@@ -26,6 +32,42 @@ func NewLastSeenFilter(expiration time.Duration, pruneLen int) *LastSeenFilter {
 		Expiration: expiration,
 		PruneLen:   pruneLen,
 		resources:  r,
+		log:        instr.New().Component("sniffer"),
+	}
+}
+
+// Snapshot returns the times at which resources were last seen, by resource, leaving out expired resources.
+func (f *LastSeenFilter) Snapshot() map[string]time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	resources := make(map[string]time.Time, len(f.resources))
+
+	for r, t := range f.resources {
+		if now.Sub(t) <= f.Expiration {
+			resources[r] = t
+		}
+	}
+
+	return resources
+}
+
+// Restore adds resources from a Snapshot, leaving out resources which expired since. Resources seen later are kept.
+func (f *LastSeenFilter) Restore(resources map[string]time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+
+	for r, t := range resources {
+		if now.Sub(t) > f.Expiration {
+			continue
+		}
+
+		if lastSeen, present := f.resources[r]; !present || t.After(lastSeen) {
+			f.resources[r] = t
+		}
 	}
 }
 
@@ -42,7 +84,7 @@ func (f *LastSeenFilter) prune() {
 			}
 		}
 
-		log.Printf("Pruned %d resources, len: %d, pruneLen: %d", cnt, len(f.resources), f.PruneLen)
+		f.log.Debug("Pruned LastSeen", "pruned", cnt, "len", len(f.resources), "prune_len", f.PruneLen)
 	}
 }
 
@@ -53,6 +95,9 @@ func (f *LastSeenFilter) shouldLog() bool {
 // Filter takes a Provider and returns true when it is to be included, false
 // when not and an error when unexpected condition occur.
 func (f *LastSeenFilter) Filter(p t.Provider) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	f.icount++
 
 	f.prune()
@@ -62,7 +107,7 @@ func (f *LastSeenFilter) Filter(p t.Provider) (bool, error) {
 	if !present {
 		// Not present, add it!
 		if f.shouldLog() {
-			log.Printf("Adding LastSeen: %v, len: %d", p, len(f.resources))
+			f.log.Debug("Adding LastSeen", "cid", p.ID, "provider", p.Provider, "len", len(f.resources))
 		}
 		f.resources[p.Resource.String()] = p.Date
 
@@ -73,7 +118,7 @@ func (f *LastSeenFilter) Filter(p t.Provider) (bool, error) {
 	if p.Date.Sub(lastSeen) > f.Expiration {
 		// Last seen longer than expiration ago, update last seen.
 		if f.shouldLog() {
-			log.Printf("Updating LastSeen: %v, len: %d", p, len(f.resources))
+			f.log.Debug("Updating LastSeen", "cid", p.ID, "provider", p.Provider, "len", len(f.resources))
 		}
 
 		f.resources[p.Resource.String()] = p.Date
@@ -84,7 +129,7 @@ func (f *LastSeenFilter) Filter(p t.Provider) (bool, error) {
 
 	// Too recent, don't index
 	if f.shouldLog() {
-		log.Printf("Filtering recent %v, LastSeen %s", p, lastSeen)
+		f.log.Debug("Filtering recent", "cid", p.ID, "provider", p.Provider, "last_seen", lastSeen)
 	}
 	return false, nil
 }
@@ -49,11 +49,13 @@ func (q *Queuer) iterate(ctx context.Context) error {
 			), trace.WithSpanKind(trace.SpanKindProducer))
 			defer span.End()
 
-			// TODO: Queue provider here, not AnnotatedResource.
-
 			r := t.AnnotatedResource{
 				Resource: p.Resource,
 				Source:   t.SnifferSource,
+				Provider: &t.ProviderRecord{
+					PeerID: p.Provider,
+					Date:   p.Date,
+				},
 			}
 
 			// Add with highest priority (9), as this is supposed to be available
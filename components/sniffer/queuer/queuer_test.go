@@ -29,6 +29,10 @@ func (s *QueuerTestSuite) SetupTest() {
 	s.r = &t.AnnotatedResource{
 		Resource: s.p.Resource,
 		Source:   t.SnifferSource,
+		Provider: &t.ProviderRecord{
+			PeerID: s.p.Provider,
+			Date:   s.p.Date,
+		},
 	}
 }
 
@@ -0,0 +1,108 @@
+package sniffer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	filters "github.com/ipfs-search/ipfs-search/components/sniffer/providerfilters"
+)
+
+// snapshotFilter returns the filter to persist, or nil when snapshots are not configured.
+// New rejects snapshots with other dedup filters.
+func (s *Sniffer) snapshotFilter() *filters.LastSeenFilter {
+	if s.cfg.LastSeenSnapshot == "" {
+		return nil
+	}
+
+	lastSeen, _ := s.dedup.(*filters.LastSeenFilter)
+
+	return lastSeen
+}
+
+// loadLastSeen restores the last-seen resources from the snapshot file, when configured and present.
+func (s *Sniffer) loadLastSeen() error {
+	lastSeen := s.snapshotFilter()
+	if lastSeen == nil {
+		return nil
+	}
+
+	path := s.cfg.LastSeenSnapshot
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var resources map[string]time.Time
+	if err := json.Unmarshal(data, &resources); err != nil {
+		return fmt.Errorf("reading last-seen snapshot %s: %w", path, err)
+	}
+
+	lastSeen.Restore(resources)
+
+	s.log.Info("Restored last-seen resources", "file", path, "resources", len(resources))
+
+	return nil
+}
+
+// saveLastSeen atomically writes the last-seen resources to the snapshot file, when configured.
+func (s *Sniffer) saveLastSeen() error {
+	lastSeen := s.snapshotFilter()
+	if lastSeen == nil {
+		return nil
+	}
+
+	path := s.cfg.LastSeenSnapshot
+
+	s.snapshotMutex.Lock()
+	defer s.snapshotMutex.Unlock()
+
+	resources := lastSeen.Snapshot()
+
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	s.log.Debug("Persisted last-seen resources", "file", path, "resources", len(resources))
+
+	return nil
+}
+
+// persistLastSeen periodically writes the last-seen resources to the snapshot file, until ctx is closed.
+func (s *Sniffer) persistLastSeen(ctx context.Context) {
+	if s.snapshotFilter() == nil || s.cfg.SnapshotInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.SnapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.saveLastSeen(); err != nil {
+				s.log.ErrorCtx(ctx, "Error persisting last-seen resources", "err", err)
+			}
+		}
+	}
+}
@@ -10,9 +10,10 @@ package sniffer
 import (
 	"context"
 	"fmt"
-	"log"
+	"sync"
 	"time"
 
+	"golang.org/x/exp/slog"
 	"golang.org/x/sync/errgroup"
 
 	// "go.opentelemetry.io/otel/codes"
@@ -33,15 +34,44 @@ import (
 // Sniffer allows sniffing Batching datastore's events, effectively allowing sniffing of the IPFS DHT.
 // To effectively use the Sniffer, the proxied datastore needs to be acquired by calling `Batching()` on the Sniffer.
 type Sniffer struct {
-	cfg *Config
-	es  eventsource.EventSource
-	pub queue.PublisherFactory
+	cfg   *Config
+	es    eventsource.EventSource
+	pub   queue.PublisherFactory
+	dedup filters.Filter // Filters recently seen resources.
+
+	snapshotMutex sync.Mutex
 
 	*instr.Instrumentation
+	log *slog.Logger
+}
+
+// newDedupFilter returns the configured filter for recently seen resources.
+func newDedupFilter(cfg *Config) (filters.Filter, error) {
+	switch cfg.DedupFilter {
+	case DedupLastSeen:
+		return filters.NewLastSeenFilter(cfg.LastSeenExpiration, cfg.LastSeenPruneLen), nil
+	case DedupBloom:
+		if cfg.BloomFPRate <= 0 || cfg.BloomFPRate >= 1 {
+			return nil, fmt.Errorf("invalid Bloom filter false positive rate: %v", cfg.BloomFPRate)
+		}
+
+		if cfg.LastSeenSnapshot != "" {
+			return nil, fmt.Errorf("last-seen snapshots require the %s dedup filter", DedupLastSeen)
+		}
+
+		return filters.NewBloomFilter(cfg.LastSeenExpiration, cfg.BloomCapacity, cfg.BloomFPRate), nil
+	default:
+		return nil, fmt.Errorf("unknown dedup filter: %s", cfg.DedupFilter)
+	}
 }
 
 // New creates a new Sniffer based on a datastore, or returns an error.
 func New(cfg *Config, ds datastore.Batching, pub queue.PublisherFactory, i *instr.Instrumentation) (*Sniffer, error) {
+	dedup, err := newDedupFilter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	bus := eventbus.NewBus()
 
 	es, err := eventsource.New(bus, ds)
@@ -49,14 +79,20 @@ func New(cfg *Config, ds datastore.Batching, pub queue.PublisherFactory, i *inst
 		return nil, fmt.Errorf("failed to get eventsource: %w", err)
 	}
 
-	s := Sniffer{
+	s := &Sniffer{
 		cfg:             cfg,
 		es:              es,
 		pub:             pub,
+		dedup:           dedup,
 		Instrumentation: i,
+		log:             i.Component("sniffer"),
+	}
+
+	if err := s.loadLastSeen(); err != nil {
+		s.log.Warn("Error restoring last-seen resources, starting without them", "err", err)
 	}
 
-	return &s, nil
+	return s, nil
 }
 
 // Batching returns the datastore wrapped with sniffing hooks.
@@ -80,9 +116,8 @@ func (s *Sniffer) filter(ctx context.Context, in <-chan t.Provider, out chan<- t
 	// ctx, span := s.Tracer.Start(ctx, "sniffer.filter")
 	// defer span.End()
 
-	lastSeenFilter := filters.NewLastSeenFilter(s.cfg.LastSeenExpiration, s.cfg.LastSeenPruneLen)
 	cidFilter := filters.NewCidFilter()
-	mutliFilter := filters.NewMultiFilter(lastSeenFilter, cidFilter)
+	mutliFilter := filters.NewMultiFilter(s.dedup, cidFilter)
 	f := filter.New(mutliFilter, in, out)
 
 	err := f.Filter(ctx)
@@ -135,21 +170,25 @@ func (s *Sniffer) Sniff(ctx context.Context) error {
 	sniffed := make(chan t.Provider, s.cfg.BufferSize)
 	filtered := make(chan t.Provider, s.cfg.BufferSize)
 
+	go s.persistLastSeen(ctx)
+
 	for {
 		err := s.iterate(ctx, sniffed, filtered)
 
 		// Closing the parent context should cause a return, other errors cause a restart
 		if err := ctx.Err(); err != nil {
-			log.Printf("Parent context closed with error '%s', returning error", err)
+			if err := s.saveLastSeen(); err != nil {
+				s.log.Error("Error persisting last-seen resources", "err", err)
+			}
+
+			s.log.Info("Parent context closed, returning error", "err", err)
 			// span.RecordError(err)
 			// span.SetStatus(codes.Internal, err.Error())
 			return err
 		}
 
-		log.Printf("Wait group exited with error '%s', restarting", err)
-
 		// TODO: Add circuit breaker here
-		log.Printf("Stubbornly restarting in 1s")
+		s.log.Error("Wait group exited with error, stubbornly restarting in 1s", "err", err)
 		time.Sleep(time.Second)
 	}
 }
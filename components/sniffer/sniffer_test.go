@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/ipfs-search/ipfs-search/components/queue"
+	filters "github.com/ipfs-search/ipfs-search/components/sniffer/providerfilters"
 	"github.com/ipfs-search/ipfs-search/instr"
 	t "github.com/ipfs-search/ipfs-search/types"
 )
@@ -228,6 +230,60 @@ func (s *SnifferTestSuite) TestHandleToPublish() {
 // 	cancel()
 // }
 
+// TestDedupFilter tests selection of the filter for recently seen resources.
+func (s *SnifferTestSuite) TestDedupFilter() {
+	cfg := DefaultConfig()
+	cfg.DedupFilter = DedupBloom
+
+	sniffy, err := New(cfg, s.ds, s.f, instr.New())
+	s.NoError(err)
+	s.IsType(&filters.BloomFilter{}, sniffy.dedup)
+
+	cfg.LastSeenSnapshot = filepath.Join(s.T().TempDir(), "lastseen.json")
+	_, err = New(cfg, s.ds, s.f, instr.New())
+	s.Error(err)
+
+	cfg.LastSeenSnapshot = ""
+	cfg.BloomFPRate = 0
+	_, err = New(cfg, s.ds, s.f, instr.New())
+	s.Error(err)
+
+	cfg.DedupFilter = "cuckoo"
+	_, err = New(cfg, s.ds, s.f, instr.New())
+	s.Error(err)
+}
+
+// TestLastSeenSnapshot tests whether last-seen resources are filtered after restarting from a snapshot.
+func (s *SnifferTestSuite) TestLastSeenSnapshot() {
+	cfg := DefaultConfig()
+	cfg.LastSeenSnapshot = filepath.Join(s.T().TempDir(), "lastseen.json")
+
+	p := t.Provider{
+		Resource: &t.Resource{
+			Protocol: t.IPFSProtocol,
+			ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+		},
+		Date:     time.Now(),
+		Provider: "QmeTtFXm42Jb2todcKR538j6qHYxXt6suUzpF3rtT9FPSd",
+	}
+
+	sniffy, err := New(cfg, s.ds, s.f, instr.New())
+	s.NoError(err)
+
+	include, err := sniffy.dedup.Filter(p)
+	s.NoError(err)
+	s.True(include)
+
+	s.NoError(sniffy.saveLastSeen())
+
+	sniffy, err = New(cfg, s.ds, s.f, instr.New())
+	s.NoError(err)
+
+	include, err = sniffy.dedup.Filter(p)
+	s.NoError(err)
+	s.False(include)
+}
+
 func TestSnifferTestSuite(t *testing.T) {
 	suite.Run(t, new(SnifferTestSuite))
 }
@@ -46,10 +46,17 @@ func (f *Filter) iterate(ctx context.Context) error {
 
 			include, err := f.f.Filter(p)
 
-			if err != nil {
+			result := "drop"
+			switch {
+			case err != nil:
 				span.RecordError(err)
+				result = "error"
+			case include:
+				result = "pass"
 			}
 
+			f.Metrics.SnifferFiltered.Add(ctx, 1, attribute.String("result", result))
+
 			if include {
 				// Set new span context on resource
 				p.SpanContext = span.SpanContext()
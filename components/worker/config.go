@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"time"
+)
+
+// RetryConfig specifies the retry policy for failed deliveries.
+type RetryConfig struct {
+	InitialDelay          time.Duration // Delay before the first retry.
+	MaxDelay              time.Duration // Maximum delay between retries.
+	Multiplier            float64       // Factor by which the delay increases for every subsequent retry.
+	MaxTransientRetries   uint          // Maximum retries for transient (infrastructure) errors.
+	MaxUnavailableRetries uint          // Maximum retries for unavailable content (timeouts).
+}
+
+// Tiers returns the distinct delays between retries in increasing order; from InitialDelay, multiplied by Multiplier
+// for every subsequent retry, up to MaxDelay.
+func (c *RetryConfig) Tiers() []time.Duration {
+	var tiers []time.Duration
+
+	for delay := float64(c.InitialDelay); delay < float64(c.MaxDelay); delay *= c.Multiplier {
+		tiers = append(tiers, time.Duration(delay))
+
+		if c.Multiplier <= 1 {
+			return tiers
+		}
+	}
+
+	return append(tiers, c.MaxDelay)
+}
+
+// DefaultRetryConfig returns the default retry policy.
+func DefaultRetryConfig() *RetryConfig {
+	return &RetryConfig{
+		InitialDelay:          time.Minute,
+		MaxDelay:              time.Hour,
+		Multiplier:            4,
+		MaxTransientRetries:   5,
+		MaxUnavailableRetries: 2,
+	}
+}
@@ -2,9 +2,9 @@ package pool
 
 import (
 	"context"
-	"log"
 
 	"github.com/ipfs-search/ipfs-search/components/crawler"
+	"github.com/ipfs-search/ipfs-search/config"
 )
 
 func (p *Pool) getCrawler(ctx context.Context) (*crawler.Crawler, error) {
@@ -14,19 +14,21 @@ func (p *Pool) getCrawler(ctx context.Context) (*crawler.Crawler, error) {
 		err     error
 	)
 
-	log.Println("Getting publish queues.")
+	p.log.InfoCtx(ctx, "Getting publish queues")
 	if queues, err = p.getQueues(ctx); err != nil {
 		return nil, err
 	}
 
-	log.Println("Getting indexes.")
+	p.log.InfoCtx(ctx, "Getting indexes")
 	if indexes, err = p.getIndexes(ctx); err != nil {
 		return nil, err
 	}
 
 	protocol := p.getProtocol()
 	extractors := p.getExtractors(protocol)
-	config := p.config.CrawlerConfig()
 
-	return crawler.New(config, indexes, queues, protocol, extractors, p.Instrumentation), nil
+	c := crawler.New(p.config.CrawlerConfig(), indexes, queues, protocol, extractors, p.Instrumentation)
+	p.addReloader(func(cfg *config.Config) { c.SetConfig(cfg.CrawlerConfig()) })
+
+	return c, nil
 }
@@ -1,23 +1,49 @@
 package pool
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/ipfs-search/ipfs-search/components/extractor"
+	"github.com/ipfs-search/ipfs-search/components/extractor/native"
 	"github.com/ipfs-search/ipfs-search/components/extractor/nsfw"
 	"github.com/ipfs-search/ipfs-search/components/extractor/tika"
 	"github.com/ipfs-search/ipfs-search/components/protocol"
+	"github.com/ipfs-search/ipfs-search/config"
 	"github.com/ipfs-search/ipfs-search/utils"
 )
 
+func (p *Pool) getFallbackExtractor(getter utils.HTTPBodyGetter, protocol protocol.Protocol) extractor.Extractor {
+	switch fallback := p.config.Native.Fallback; fallback {
+	case native.FallbackTika:
+		e := tika.New(p.config.TikaConfig(), getter, protocol, p.Instrumentation)
+		p.addReloader(func(cfg *config.Config) { e.(*tika.Extractor).SetConfig(cfg.TikaConfig()) })
+
+		return e
+	case native.FallbackNone:
+		return nil
+	default:
+		panic(fmt.Sprintf("unknown fallback extractor: %s", fallback))
+	}
+}
+
 func (p *Pool) getExtractors(protocol protocol.Protocol) []extractor.Extractor {
 	// Limited extractor connections (as resources are generally known to be available by now)
-	extractorTransport := utils.GetHTTPTransport(p.dialer.DialContext, p.config.Workers.MaxExtractorConns)
+	extractorTransport := utils.GuardTransport(
+		utils.GetHTTPTransport(p.dialer.DialContext, p.config.Workers.MaxExtractorConns),
+		p.getGuards(),
+	)
 
 	getter := utils.NewHTTPBodyGetter(&http.Client{Transport: extractorTransport}, p.Instrumentation)
 
-	tikaExtractor := tika.New(p.config.TikaConfig(), getter, protocol, p.Instrumentation)
+	fallback := p.getFallbackExtractor(getter, protocol)
+	nativeExtractor := native.New(p.config.NativeConfig(), getter, protocol, fallback, p.Instrumentation)
 	nsfwExtractor := nsfw.New(p.config.NSFWConfig(), getter, p.Instrumentation)
 
-	return []extractor.Extractor{tikaExtractor, nsfwExtractor}
+	p.addReloader(func(cfg *config.Config) {
+		nativeExtractor.(*native.Extractor).SetConfig(cfg.NativeConfig())
+		nsfwExtractor.(*nsfw.Extractor).SetConfig(cfg.NSFWConfig())
+	})
+
+	return []extractor.Extractor{nativeExtractor, nsfwExtractor}
 }
@@ -0,0 +1,43 @@
+package pool
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/ipfs-search/ipfs-search/config"
+	"github.com/ipfs-search/ipfs-search/utils"
+)
+
+// getGuards returns the guards for the crawler's HTTP dependencies, by host. They are shared between all clients of
+// the pool.
+func (p *Pool) getGuards() map[string]*utils.Guard {
+	if p.guards != nil {
+		return p.guards
+	}
+
+	cfg := p.config.Guards
+
+	dependencies := []struct {
+		name  string
+		url   string
+		guard config.Guard
+	}{
+		{"ipfs", p.config.IPFS.APIURL, cfg.IPFS},
+		{"gateway", p.config.IPFS.GatewayURL, cfg.Gateway},
+		{"tika", p.config.Tika.TikaExtractorURL, cfg.Tika},
+		{"nsfw", p.config.NSFW.NSFWServerURL, cfg.NSFW},
+	}
+
+	p.guards = make(map[string]*utils.Guard, len(dependencies))
+
+	for _, d := range dependencies {
+		u, err := url.Parse(d.url)
+		if err != nil {
+			panic(fmt.Sprintf("could not parse %s URL, error: %v", d.name, err))
+		}
+
+		p.guards[u.Host] = utils.NewGuard(d.name, d.guard.GuardConfig(), p.Instrumentation)
+	}
+
+	return p.guards
+}
@@ -2,20 +2,25 @@ package pool
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
+	"golang.org/x/exp/slog"
+
 	"github.com/ipfs-search/ipfs-search/components/crawler"
 	"github.com/ipfs-search/ipfs-search/components/index"
+	"github.com/ipfs-search/ipfs-search/components/index/bolt"
 	"github.com/ipfs-search/ipfs-search/components/index/cache"
 	"github.com/ipfs-search/ipfs-search/components/index/opensearch"
 	"github.com/ipfs-search/ipfs-search/components/index/redis"
 	indexTypes "github.com/ipfs-search/ipfs-search/components/index/types"
+	"github.com/ipfs-search/ipfs-search/config"
 	"github.com/ipfs-search/ipfs-search/utils"
 )
 
-func osWorkLoop(ctx context.Context, workFunc func(context.Context) error) {
+func osWorkLoop(ctx context.Context, log *slog.Logger, workFunc func(context.Context) error) {
 	for {
 		// Keep starting worker unless context is done.
 		select {
@@ -23,7 +28,7 @@ func osWorkLoop(ctx context.Context, workFunc func(context.Context) error) {
 			return
 		default:
 			if err := workFunc(ctx); err != nil {
-				log.Printf("Error in worker: %s, restarting.", err)
+				log.ErrorCtx(ctx, "Error in OpenSearch worker, restarting", "err", err)
 				// Prevent overly tight restart loop
 				time.Sleep(time.Second)
 			}
@@ -73,54 +78,189 @@ func getOsIndex(c *opensearch.Client, name string) index.Index {
 	)
 }
 
-func (w *Pool) getIndexes(ctx context.Context) (*crawler.Indexes, error) {
-	os, err := w.getOpenSearchClient()
+// backends lazily instantiates and starts clients for the index backends in use, which are closed when ctx is closed.
+type backends struct {
+	ctx   context.Context
+	pool  *Pool
+	os    *opensearch.Client
+	redis *redis.Client
+	bolt  *bolt.Client
+
+	closed sync.WaitGroup // Done when all clients are closed, after ctx is closed.
+}
+
+// goClose runs f in a goroutine, tracking it until the clients are closed.
+func (b *backends) goClose(f func()) {
+	b.closed.Add(1)
+
+	go func() {
+		defer b.closed.Done()
+		f()
+	}()
+}
+
+func (b *backends) getOpenSearch() (*opensearch.Client, error) {
+	if b.os != nil {
+		return b.os, nil
+	}
+
+	os, err := b.pool.getOpenSearchClient()
 	if err != nil {
 		return nil, err
 	}
 
-	redis, err := w.getRedisClient()
+	b.goClose(func() { osWorkLoop(b.ctx, b.pool.log, os.Work) })
+	b.pool.addCheck("opensearch", os.Ping)
+
+	b.os = os
+
+	return os, nil
+}
+
+func (b *backends) getRedis() (*redis.Client, error) {
+	if b.redis != nil {
+		return b.redis, nil
+	}
+
+	redis, err := b.pool.getRedisClient()
 	if err != nil {
 		return nil, err
 	}
 
-	go osWorkLoop(ctx, os.Work)
+	if err := redis.Start(b.ctx); err != nil {
+		return nil, err
+	}
+
+	b.goClose(func() {
+		<-b.ctx.Done()
+		redis.Close(b.ctx)
+	})
+	b.pool.addCheck("redis", redis.Ping)
 
-	if err := redis.Start(ctx); err != nil {
+	b.redis = redis
+
+	return redis, nil
+}
+
+func (b *backends) getBolt() (*bolt.Client, error) {
+	if b.bolt != nil {
+		return b.bolt, nil
+	}
+
+	bolt, err := bolt.NewClient(b.pool.config.BoltConfig(), b.pool.Instrumentation)
+	if err != nil {
 		return nil, err
 	}
 
-	go func() {
-		<-ctx.Done()
-		redis.Close(ctx)
-	}()
+	if err := bolt.Start(b.ctx); err != nil {
+		return nil, err
+	}
+
+	b.goClose(func() {
+		<-b.ctx.Done()
+		bolt.Close(b.ctx)
+	})
+
+	b.bolt = bolt
+
+	return bolt, nil
+}
+
+// getIndex returns an index for the configured backend. OpenSearch indexes are cached in Redis
+// when cachingType is non-nil; existsOnly determines whether Redis only records the existence of items.
+func (b *backends) getIndex(cfg config.Index, cachingType interface{}, existsOnly bool) (index.Index, error) {
+	switch cfg.Backend {
+	case config.OpenSearchBackend:
+		os, err := b.getOpenSearch()
+		if err != nil {
+			return nil, err
+		}
+
+		if cachingType == nil {
+			return os.NewIndex(cfg.Name), nil
+		}
+
+		redis, err := b.getRedis()
+		if err != nil {
+			return nil, err
+		}
+
+		return cache.New(
+			os.NewIndex(cfg.Name),
+			redis.NewIndex(cfg.Name, cfg.Prefix, existsOnly),
+			cachingType,
+			b.pool.Instrumentation,
+		), nil
+
+	case config.RedisBackend:
+		redis, err := b.getRedis()
+		if err != nil {
+			return nil, err
+		}
+
+		return redis.NewIndex(cfg.Name, cfg.Prefix, existsOnly), nil
+
+	case config.BoltBackend:
+		bolt, err := b.getBolt()
+		if err != nil {
+			return nil, err
+		}
+
+		return bolt.NewIndex(cfg.Name), nil
+
+	default:
+		return nil, fmt.Errorf("unknown backend '%s' for index %s", cfg.Backend, cfg.Name)
+	}
+}
+
+func (w *Pool) getIndexes(ctx context.Context) (*crawler.Indexes, error) {
+	w.backends = &backends{ctx: ctx, pool: w}
+
+	return w.backends.getIndexes()
+}
+
+func (b *backends) getIndexes() (*crawler.Indexes, error) {
+	var (
+		cfg = b.pool.config.Indexes
+
+		indexes = new(crawler.Indexes)
+		err     error
+	)
+
+	if indexes.Files, err = b.getIndex(cfg.Files, indexTypes.Update{}, false); err != nil {
+		return nil, err
+	}
+
+	if indexes.Directories, err = b.getIndex(cfg.Directories, indexTypes.Update{}, false); err != nil {
+		return nil, err
+	}
+
+	if indexes.Invalids, err = b.getIndex(cfg.Invalids, struct{}{}, true); err != nil {
+		return nil, err
+	}
+
+	if indexes.Partials, err = b.getIndex(cfg.Partials, struct{}{}, true); err != nil {
+		return nil, err
+	}
+
+	if indexes.Dags, err = b.getIndex(cfg.Dags, indexTypes.Update{}, false); err != nil {
+		return nil, err
+	}
+
+	// Names are not cached; resolving requires the full resolution history.
+	if indexes.Names, err = b.getIndex(cfg.Names, nil, false); err != nil {
+		return nil, err
+	}
+
+	// Checkpoints are transient and, by default, only kept in Redis.
+	if indexes.Checkpoints, err = b.getIndex(cfg.Checkpoints, nil, false); err != nil {
+		return nil, err
+	}
+
+	// Extraction results are too large to cache in Redis and persist across reindexing of files.
+	if indexes.Extractions, err = b.getIndex(cfg.Extractions, nil, false); err != nil {
+		return nil, err
+	}
 
-	cfg := w.config.Indexes
-
-	return &crawler.Indexes{
-		Files: cache.New(
-			os.NewIndex(cfg.Files.Name),
-			redis.NewIndex(cfg.Files.Name, cfg.Files.Prefix, false),
-			indexTypes.Update{},
-			w.Instrumentation,
-		),
-		Directories: cache.New(
-			os.NewIndex(cfg.Directories.Name),
-			redis.NewIndex(cfg.Directories.Name, cfg.Directories.Prefix, false),
-			indexTypes.Update{},
-			w.Instrumentation,
-		),
-		Invalids: cache.New(
-			os.NewIndex(cfg.Invalids.Name),
-			redis.NewIndex(cfg.Invalids.Name, cfg.Invalids.Prefix, true),
-			struct{}{},
-			w.Instrumentation,
-		),
-		Partials: cache.New(
-			os.NewIndex(cfg.Partials.Name),
-			redis.NewIndex(cfg.Partials.Name, cfg.Partials.Prefix, true),
-			struct{}{},
-			w.Instrumentation,
-		),
-	}, nil
+	return indexes, nil
 }
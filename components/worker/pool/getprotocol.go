@@ -6,11 +6,23 @@ import (
 	"github.com/ipfs-search/ipfs-search/components/protocol"
 	"github.com/ipfs-search/ipfs-search/components/protocol/ipfs"
 	"github.com/ipfs-search/ipfs-search/utils"
+
+	t "github.com/ipfs-search/ipfs-search/types"
 )
 
 func (p *Pool) getProtocol() protocol.Protocol {
-	ipfsTransport := utils.GetHTTPTransport(p.dialer.DialContext, p.config.Workers.MaxIPFSConns)
+	ipfsTransport := utils.GuardTransport(
+		utils.GetHTTPTransport(p.dialer.DialContext, p.config.Workers.MaxIPFSConns),
+		p.getGuards(),
+	)
 	ipfsClient := &http.Client{Transport: ipfsTransport}
 
-	return ipfs.New(p.config.IPFSConfig(), ipfsClient, p.Instrumentation)
+	i := ipfs.New(p.config.IPFSConfig(), ipfsClient, p.Instrumentation)
+
+	registry := protocol.NewRegistry()
+	registry.Register(t.IPFSProtocol, i)
+	// IPNS names and DNSLink domains are resolved through the IPFS API.
+	registry.Register(t.IPNSProtocol, i)
+
+	return registry
 }
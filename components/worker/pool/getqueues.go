@@ -2,26 +2,57 @@ package pool
 
 import (
 	"context"
-	"log"
+	"fmt"
 
 	samqp "github.com/rabbitmq/amqp091-go"
 
 	"github.com/ipfs-search/ipfs-search/components/crawler"
 	"github.com/ipfs-search/ipfs-search/components/queue/amqp"
+	"github.com/ipfs-search/ipfs-search/components/queue/memory"
+	"github.com/ipfs-search/ipfs-search/config"
 )
 
-func (p *Pool) getQueues(ctx context.Context) (*crawler.Queues, error) {
+func (p *Pool) getAMQPConnection(ctx context.Context) (*amqp.Connection, error) {
 	amqpConfig := &samqp.Config{
 		Dial: p.dialer.Dial,
 	}
 
-	log.Println("Connecting to AMQP.")
-	amqpConnection, err := amqp.NewConnection(ctx, p.config.AMQPConfig(), amqpConfig, p.Instrumentation)
+	p.log.InfoCtx(ctx, "Connecting to AMQP")
+	conn, err := amqp.NewConnection(ctx, p.config.AMQPConfig(), amqpConfig, p.Instrumentation)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Println("Creating AMQP channels.")
+	p.addCheck("amqp", conn.Check)
+	p.connections = append(p.connections, conn)
+
+	return conn, nil
+}
+
+// getBroker returns the broker for in-process queues, shared by all users of queues in the pool.
+func (p *Pool) getBroker(ctx context.Context) (*memory.Broker, error) {
+	if p.broker != nil {
+		return p.broker, nil
+	}
+
+	p.log.InfoCtx(ctx, "Starting in-process queues")
+	broker := memory.NewBroker(p.config.MemoryQueueConfig(), p.Instrumentation)
+	if err := broker.Start(ctx); err != nil {
+		return nil, err
+	}
+
+	p.broker = broker
+
+	return broker, nil
+}
+
+func (p *Pool) getAMQPQueues(ctx context.Context) (*crawler.Queues, error) {
+	amqpConnection, err := p.getAMQPConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.log.InfoCtx(ctx, "Creating AMQP channels")
 	fq, err := amqpConnection.NewChannelQueue(ctx, p.config.Queues.Files.Name, p.config.Workers.FileWorkers)
 	if err != nil {
 		return nil, err
@@ -37,9 +68,52 @@ func (p *Pool) getQueues(ctx context.Context) (*crawler.Queues, error) {
 		return nil, err
 	}
 
+	for _, q := range []*amqp.Queue{fq, dq, hq} {
+		p.addCheck("amqp", q.Check)
+	}
+
 	return &crawler.Queues{
 		Files:       fq,
 		Directories: dq,
 		Hashes:      hq,
 	}, nil
 }
+
+func (p *Pool) getMemoryQueues(ctx context.Context) (*crawler.Queues, error) {
+	broker, err := p.getBroker(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fq, err := broker.Queue(p.config.Queues.Files.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	dq, err := broker.Queue(p.config.Queues.Directories.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	hq, err := broker.Queue(p.config.Queues.Hashes.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &crawler.Queues{
+		Files:       fq,
+		Directories: dq,
+		Hashes:      hq,
+	}, nil
+}
+
+func (p *Pool) getQueues(ctx context.Context) (*crawler.Queues, error) {
+	switch backend := p.config.Queues.Backend; backend {
+	case config.AMQPBackend:
+		return p.getAMQPQueues(ctx)
+	case config.MemoryBackend:
+		return p.getMemoryQueues(ctx)
+	default:
+		return nil, fmt.Errorf("unknown queue backend '%s'", backend)
+	}
+}
@@ -0,0 +1,117 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs-search/ipfs-search/components/queue/amqp"
+	"github.com/ipfs-search/ipfs-search/components/queue/memory"
+	"github.com/ipfs-search/ipfs-search/components/worker"
+	"github.com/ipfs-search/ipfs-search/config"
+)
+
+type retriers struct {
+	Files       *worker.Retrier
+	Directories *worker.Retrier
+	Hashes      *worker.Retrier
+}
+
+// retrierFunc returns a Retrier for a crawler queue.
+type retrierFunc func(ctx context.Context, q config.Queue) (*worker.Retrier, error)
+
+func (p *Pool) getAMQPRetrierFunc(ctx context.Context) (retrierFunc, error) {
+	conn, err := p.getAMQPConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only used for publishing; prefetch is irrelevant.
+	ch, err := conn.NewChannel(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	p.addCheck("amqp", ch.Check)
+
+	return func(ctx context.Context, q config.Queue) (*worker.Retrier, error) {
+		return p.getAMQPRetrier(ctx, ch, q)
+	}, nil
+}
+
+func (p *Pool) getAMQPRetrier(ctx context.Context, ch *amqp.Channel, q config.Queue) (*worker.Retrier, error) {
+	delay, err := ch.DelayQueue(ctx, q.DelayName(), q.Name, p.config.RetryConfig().Tiers())
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetter, err := ch.DeadLetterQueue(ctx, q.DeadLetterName())
+	if err != nil {
+		return nil, err
+	}
+
+	return worker.NewRetrier(p.config.RetryConfig(), delay, deadLetter, p.Instrumentation), nil
+}
+
+func (p *Pool) getMemoryRetrierFunc(ctx context.Context) (retrierFunc, error) {
+	broker, err := p.getBroker(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, q config.Queue) (*worker.Retrier, error) {
+		return p.getMemoryRetrier(broker, q)
+	}, nil
+}
+
+func (p *Pool) getMemoryRetrier(broker *memory.Broker, q config.Queue) (*worker.Retrier, error) {
+	delay, err := broker.DelayQueue(q.DelayName(), q.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetter, err := broker.Queue(q.DeadLetterName())
+	if err != nil {
+		return nil, err
+	}
+
+	return worker.NewRetrier(p.config.RetryConfig(), delay, deadLetter, p.Instrumentation), nil
+}
+
+func (p *Pool) getRetrierFunc(ctx context.Context) (retrierFunc, error) {
+	switch backend := p.config.Queues.Backend; backend {
+	case config.AMQPBackend:
+		return p.getAMQPRetrierFunc(ctx)
+	case config.MemoryBackend:
+		return p.getMemoryRetrierFunc(ctx)
+	default:
+		return nil, fmt.Errorf("unknown queue backend '%s'", backend)
+	}
+}
+
+func (p *Pool) getRetriers(ctx context.Context) (*retriers, error) {
+	getRetrier, err := p.getRetrierFunc(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fr, err := getRetrier(ctx, p.config.Queues.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	dr, err := getRetrier(ctx, p.config.Queues.Directories)
+	if err != nil {
+		return nil, err
+	}
+
+	hr, err := getRetrier(ctx, p.config.Queues.Hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &retriers{
+		Files:       fr,
+		Directories: dr,
+		Hashes:      hr,
+	}, nil
+}
@@ -3,84 +3,309 @@ package pool
 import (
 	"context"
 	"fmt"
-	"log"
 	"net"
+	"sync"
 	"time"
 
-	samqp "github.com/rabbitmq/amqp091-go"
+	"golang.org/x/exp/slog"
 
+	"github.com/ipfs-search/ipfs-search/components/admin"
 	"github.com/ipfs-search/ipfs-search/components/crawler"
+	"github.com/ipfs-search/ipfs-search/components/extractor"
+	"github.com/ipfs-search/ipfs-search/components/protocol"
+	"github.com/ipfs-search/ipfs-search/components/queue/amqp"
+	"github.com/ipfs-search/ipfs-search/components/queue/memory"
 	"github.com/ipfs-search/ipfs-search/components/worker"
 	"github.com/ipfs-search/ipfs-search/config"
 	"github.com/ipfs-search/ipfs-search/instr"
 	"github.com/ipfs-search/ipfs-search/utils"
 )
 
-type consumeChans struct {
-	Files       <-chan samqp.Delivery
-	Directories <-chan samqp.Delivery
-	Hashes      <-chan samqp.Delivery
-}
-
 // Pool represents a pool of pools.
 type Pool struct {
-	config  *config.Config
-	dialer  *utils.RetryingDialer
-	crawler *crawler.Crawler
+	mu          sync.RWMutex // Protects config, which is replaced by Reload.
+	config      *config.Config
+	dialer      *utils.RetryingDialer
+	crawler     *crawler.Crawler
+	broker      *memory.Broker // In-process queues, when using the memory queue backend.
+	connections []*amqp.Connection
+	backends    *backends
+	guards      map[string]*utils.Guard // By host.
+	checks      map[string]admin.CheckFunc
+	pools       []*workerPool
+	reloaders   []func(*config.Config)
 
-	*consumeChans
+	crawlCtx     context.Context // Closed when in-flight crawls are to be interrupted.
+	cancelCrawls context.CancelFunc
+	workers      sync.WaitGroup
+
+	*retriers
 	*instr.Instrumentation
+	log *slog.Logger
 }
 
-func (p *Pool) startWorkers(ctx context.Context, deliveries <-chan samqp.Delivery, workers int, poolName string) {
-	ctx, span := p.Tracer.Start(ctx, "crawler.pool.start")
+// Start launches the pool, consuming deliveries until ctx is closed. Deliveries being crawled at that time are
+// finished or requeued by Drain.
+func (p *Pool) Start(ctx context.Context) error {
+	ctx, span := p.Tracer.Start(ctx, "crawler.pool.Start")
 	defer span.End()
 
-	log.Printf("Starting %d workers for %s", workers, poolName)
+	var err error
 
-	for i := 0; i < workers; i++ {
-		name := fmt.Sprintf("%s-%d", poolName, i)
-		worker := worker.New(name, p.crawler, p.Instrumentation)
-		go worker.Start(ctx, deliveries)
+	p.log.InfoCtx(ctx, "Initializing consuming channels")
+	if p.pools, err = p.getWorkerPools(ctx); err != nil {
+		return err
 	}
+
+	for _, wp := range p.pools {
+		wp.start()
+	}
+
+	if interval := p.config.Workers.AdaptInterval; interval > 0 {
+		go p.adapt(ctx, interval)
+	}
+
+	return nil
 }
 
-// Start launches the pool.
-func (p *Pool) Start(ctx context.Context) {
-	ctx, span := p.Tracer.Start(ctx, "crawler.pool.Start")
-	defer span.End()
+// adapt adapts the amount of workers to their activity, every interval until ctx is closed.
+func (p *Pool) adapt(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cfg := p.workersConfig()
 
-	p.startWorkers(ctx, p.consumeChans.Files, p.config.Workers.FileWorkers, "files")
-	p.startWorkers(ctx, p.consumeChans.Hashes, p.config.Workers.HashWorkers, "hashes")
-	p.startWorkers(ctx, p.consumeChans.Directories, p.config.Workers.DirectoryWorkers, "directories")
+			for _, wp := range p.pools {
+				wp.adapt(cfg)
+			}
+		}
+	}
 }
 
-func (p *Pool) init(ctx context.Context) error {
-	var err error
+// WorkerLimits returns the limits of the amount of workers, by pool.
+func (p *Pool) WorkerLimits() map[string]admin.WorkerLimits {
+	limits := make(map[string]admin.WorkerLimits, len(p.pools))
+
+	for _, wp := range p.pools {
+		limits[wp.name] = wp.getLimits()
+	}
+
+	return limits
+}
+
+// SetWorkerLimits changes the limits of the amount of workers of the named pool. Without adaptive concurrency,
+// the pool runs the maximum amount of workers.
+func (p *Pool) SetWorkerLimits(name string, limits admin.WorkerLimits) error {
+	for _, wp := range p.pools {
+		if wp.name == name {
+			return wp.setLimits(limits, p.workersConfig().AdaptInterval > 0)
+		}
+	}
+
+	return fmt.Errorf("unknown worker pool: %s", name)
+}
+
+// workersConfig returns the current worker configuration.
+func (p *Pool) workersConfig() *config.Workers {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	cfg := p.config.Workers
+
+	return &cfg
+}
+
+// addReloader registers f to apply reloaded configuration to a component.
+func (p *Pool) addReloader(f func(*config.Config)) {
+	p.reloaders = append(p.reloaders, f)
+}
+
+// Reload applies changed configuration of the crawler, extractors and worker limits to the running pool. Worker
+// limits are only reset when the amount of workers is changed in the configuration. Other changes, including the
+// adapt interval, take effect after a restart.
+func (p *Pool) Reload(cfg *config.Config) error {
+	p.mu.Lock()
+	old := p.config
+	p.config = cfg
+	p.mu.Unlock()
+
+	for _, f := range p.reloaders {
+		f(cfg)
+	}
+
+	oldWorkers, newWorkers := maxWorkers(&old.Workers), maxWorkers(&cfg.Workers)
+	adaptive := old.Workers.AdaptInterval > 0
+
+	for _, wp := range p.pools {
+		if oldWorkers[wp.name] == newWorkers[wp.name] && old.Workers.MinWorkers == cfg.Workers.MinWorkers {
+			continue
+		}
+
+		limits := configLimits(&cfg.Workers, newWorkers[wp.name])
+		if err := wp.setLimits(limits, adaptive); err != nil {
+			return err
+		}
+
+		p.log.Info("Changed worker limits", "pool", wp.name, "min", limits.Min, "max", limits.Max)
+	}
+
+	return nil
+}
+
+// DrainSummary summarizes the deliveries being crawled when the pool stopped consuming.
+type DrainSummary struct {
+	InFlight int64         // Deliveries being crawled when draining started.
+	Finished int64         // In-flight deliveries finished within the grace period.
+	Requeued int64         // In-flight deliveries requeued because their crawls were interrupted.
+	Duration time.Duration // Time taken to drain.
+}
+
+// Drain waits for the workers to finish their in-flight deliveries, after the context passed to Start has been
+// closed. Crawls still running after gracePeriod are interrupted and their deliveries requeued.
+func (p *Pool) Drain(gracePeriod time.Duration) *DrainSummary {
+	start := time.Now()
+	s := &DrainSummary{}
+
+	for _, wp := range p.pools {
+		s.InFlight += wp.stats.InFlight()
+	}
+
+	p.log.Info("Draining workers", "in_flight", s.InFlight, "grace_period", gracePeriod)
+
+	done := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+		p.log.Warn("Grace period expired, interrupting crawls")
+		p.cancelCrawls()
+		<-done
+	}
+
+	p.cancelCrawls()
+
+	for _, wp := range p.pools {
+		s.Requeued += wp.stats.Interrupted()
+	}
+
+	s.Finished = s.InFlight - s.Requeued
+	s.Duration = time.Since(start)
+
+	return s
+}
+
+// Queues returns the queues the pool consumes from, e.g. to seed them with resources to crawl. In-process queues
+// are shared with the pool's workers.
+func (p *Pool) Queues(ctx context.Context) (*crawler.Queues, error) {
+	return p.getQueues(ctx)
+}
+
+// Stats returns the worker counts for each of the pool's worker pools, by name.
+func (p *Pool) Stats() map[string]*worker.Stats {
+	stats := make(map[string]*worker.Stats, len(p.pools))
+
+	for _, wp := range p.pools {
+		stats[wp.name] = wp.stats
+	}
+
+	return stats
+}
+
+// Checks returns checks for the availability of the pool's dependencies, by name.
+func (p *Pool) Checks() map[string]admin.CheckFunc {
+	return p.checks
+}
 
-	p.dialer = &utils.RetryingDialer{
+// addCheck adds a check for a dependency, combining it with existing checks of the same name.
+func (p *Pool) addCheck(name string, f admin.CheckFunc) {
+	prev, ok := p.checks[name]
+	if !ok {
+		p.checks[name] = f
+		return
+	}
+
+	p.checks[name] = func(ctx context.Context) error {
+		if err := prev(ctx); err != nil {
+			return err
+		}
+
+		return f(ctx)
+	}
+}
+
+func getDialer(ctx context.Context, i *instr.Instrumentation) *utils.RetryingDialer {
+	return &utils.RetryingDialer{
 		Dialer: net.Dialer{
 			Timeout:   30 * time.Second,
 			KeepAlive: 30 * time.Second,
 			DualStack: false,
 		},
 		Context: ctx,
+		Logger:  i.Component("dialer"),
 	}
+}
+
+func (p *Pool) init(ctx context.Context) error {
+	var err error
 
-	log.Println("Initializing crawler.")
+	p.dialer = getDialer(ctx, p.Instrumentation)
+
+	p.log.InfoCtx(ctx, "Initializing crawler")
 	if p.crawler, err = p.getCrawler(ctx); err != nil {
 		return err
 	}
 
-	log.Println("Initializing consuming channels.")
-	if p.consumeChans, err = p.getConsumeChans(ctx); err != nil {
+	p.log.InfoCtx(ctx, "Initializing retry and dead-letter queues")
+	if p.retriers, err = p.getRetriers(ctx); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// New initializes and returns a new pool pool.
+// Close releases resources held by the pool, after draining it and closing the context passed to New. It waits for
+// clients of the indexes to be flushed and closed, persists in-process queues, if any, and closes AMQP connections,
+// returning unacknowledged deliveries to their queues.
+func (p *Pool) Close(ctx context.Context) error {
+	var closeErr error
+
+	if p.backends != nil {
+		p.backends.closed.Wait()
+	}
+
+	if p.broker != nil {
+		if err := p.broker.Close(ctx); err != nil {
+			p.log.ErrorCtx(ctx, "Error persisting in-process queues", "err", err)
+			closeErr = err
+		}
+	}
+
+	for _, conn := range p.connections {
+		if err := conn.Close(); err != nil {
+			p.log.ErrorCtx(ctx, "Error closing AMQP connection", "err", err)
+			if closeErr == nil {
+				closeErr = err
+			}
+		}
+	}
+
+	return closeErr
+}
+
+// New initializes and returns a new pool pool. Clients and connections are used until ctx is closed, which should
+// outlive the context passed to Start so that in-flight crawls can be finished.
 func New(ctx context.Context, c *config.Config, i *instr.Instrumentation) (*Pool, error) {
 	if i == nil {
 		panic("Instrumentation cannot be null.")
@@ -92,10 +317,55 @@ func New(ctx context.Context, c *config.Config, i *instr.Instrumentation) (*Pool
 
 	p := &Pool{
 		config:          c,
+		checks:          make(map[string]admin.CheckFunc),
 		Instrumentation: i,
+		log:             i.Component("pool"),
 	}
 
+	p.crawlCtx, p.cancelCrawls = context.WithCancel(ctx)
+
 	err := p.init(ctx)
 
 	return p, err
 }
+
+// standalone returns a pool which is not initialized, to get individual components from.
+func standalone(ctx context.Context, c *config.Config, i *instr.Instrumentation) *Pool {
+	return &Pool{
+		config:          c,
+		dialer:          getDialer(ctx, i),
+		checks:          make(map[string]admin.CheckFunc),
+		Instrumentation: i,
+		log:             i.Component("pool"),
+	}
+}
+
+// GetIndexes returns the crawler's indexes as configured, without starting a pool; e.g. for maintenance.
+// Clients for the index backends are closed when ctx is closed; the returned function waits for this,
+// ensuring that pending writes have been flushed.
+func GetIndexes(ctx context.Context, c *config.Config, i *instr.Instrumentation) (*crawler.Indexes, func(), error) {
+	b := &backends{ctx: ctx, pool: standalone(ctx, c, i)}
+	indexes, err := b.getIndexes()
+
+	return indexes, b.closed.Wait, err
+}
+
+// GetQueues returns the crawler's publishing queues as configured, without starting a pool.
+// In-process queues are not shared with other processes.
+func GetQueues(ctx context.Context, c *config.Config, i *instr.Instrumentation) (*crawler.Queues, error) {
+	return standalone(ctx, c, i).getQueues(ctx)
+}
+
+// GetProtocol returns the crawler's protocol as configured, without starting a pool.
+func GetProtocol(ctx context.Context, c *config.Config, i *instr.Instrumentation) protocol.Protocol {
+	return standalone(ctx, c, i).getProtocol()
+}
+
+// GetExtractors returns the crawler's extractors as configured, without starting a pool.
+func GetExtractors(ctx context.Context, c *config.Config, i *instr.Instrumentation, protocol protocol.Protocol) []extractor.Extractor {
+	return standalone(ctx, c, i).getExtractors(protocol)
+}
+
+// Compile-time assurance that implementation satisfies interface.
+var _ admin.Scaler = &Pool{}
+var _ config.Reloader = &Pool{}
@@ -0,0 +1,268 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/ipfs-search/ipfs-search/components/admin"
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	"github.com/ipfs-search/ipfs-search/components/worker"
+	"github.com/ipfs-search/ipfs-search/config"
+)
+
+// sample holds the activity of a worker pool since the previous sample.
+type sample struct {
+	workers  int
+	inFlight int
+	ready    int // Messages ready in the queue, -1 when unknown.
+	crawls   int64
+	failures int64
+	latency  time.Duration // Total duration of crawls.
+}
+
+// nextWorkers returns the amount of workers for a pool with n workers, given its activity and limits, and the
+// reason for changing it. Workers are reduced by a quarter when dependencies fail or slow down, and added or
+// removed by a tenth as long as there is a backlog or idle workers, respectively.
+func nextWorkers(s sample, limits admin.WorkerLimits, cfg *config.Workers) (int, string) {
+	var (
+		n      = s.workers
+		step   = max(1, n/10)
+		reason string
+	)
+
+	switch {
+	case s.crawls > 0 && float64(s.failures)/float64(s.crawls) > cfg.MaxErrorRate:
+		n, reason = n-max(1, n/4), "error rate"
+	case s.crawls > 0 && s.latency/time.Duration(s.crawls) > cfg.MaxLatency:
+		n, reason = n-max(1, n/4), "latency"
+	case s.inFlight*5 >= n*4 && s.ready != 0:
+		n, reason = n+step, "backlog"
+	case s.inFlight*2 < n:
+		n, reason = n-step, "idle"
+	}
+
+	return min(max(n, limits.Min), limits.Max), reason
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// workerPool runs a variable amount of workers consuming a queue.
+type workerPool struct {
+	name       string
+	pool       *Pool
+	queue      queue.Queue
+	deliveries <-chan queue.Delivery
+	retrier    *worker.Retrier
+	stats      *worker.Stats
+	log        *slog.Logger
+
+	mu      sync.Mutex
+	ctx     context.Context // Closed to stop consuming.
+	limits  admin.WorkerLimits
+	stops   []context.CancelFunc // Stop functions of running workers.
+	started int                  // Workers started, for naming.
+	last    sample
+}
+
+// maxWorkers returns the configured maximum amount of workers, by pool.
+func maxWorkers(cfg *config.Workers) map[string]int {
+	return map[string]int{
+		"files":       cfg.FileWorkers,
+		"hashes":      cfg.HashWorkers,
+		"directories": cfg.DirectoryWorkers,
+	}
+}
+
+// configLimits returns the configured limits for a pool with at most maxWorkers workers.
+func configLimits(cfg *config.Workers, maxWorkers int) admin.WorkerLimits {
+	return admin.WorkerLimits{
+		Min: min(cfg.MinWorkers, maxWorkers),
+		Max: maxWorkers,
+	}
+}
+
+func (p *Pool) newWorkerPool(ctx context.Context, name string, q queue.Queue, retrier *worker.Retrier, limits admin.WorkerLimits) (*workerPool, error) {
+	deliveries, err := q.Consume(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workerPool{
+		name:       name,
+		pool:       p,
+		queue:      q,
+		deliveries: deliveries,
+		retrier:    retrier,
+		stats:      new(worker.Stats),
+		log:        p.log.With("pool", name),
+		ctx:        ctx,
+		limits:     limits,
+	}, nil
+}
+
+// resize starts or stops workers, to n. Stopped workers finish their current delivery.
+func (wp *workerPool) resize(n int) {
+	ctx := wp.ctx
+
+	for len(wp.stops) < n {
+		name := fmt.Sprintf("%s-%d", wp.name, wp.started)
+		w := worker.New(name, wp.pool.crawler, wp.retrier, wp.stats, wp.pool.Instrumentation)
+
+		workerCtx, stop := context.WithCancel(ctx)
+		wp.stops = append(wp.stops, stop)
+		wp.started++
+
+		wp.pool.workers.Add(1)
+		go func() {
+			defer wp.pool.workers.Done()
+			w.Start(workerCtx, wp.pool.crawlCtx, wp.deliveries)
+		}()
+	}
+
+	for len(wp.stops) > n {
+		last := len(wp.stops) - 1
+		wp.stops[last]()
+		wp.stops = wp.stops[:last]
+	}
+
+	if p, ok := wp.queue.(queue.Prefetcher); ok && ctx.Err() == nil {
+		if err := p.SetPrefetch(ctx, n); err != nil {
+			wp.log.ErrorCtx(ctx, "Error setting prefetch", "prefetch", n, "err", err)
+		}
+	}
+}
+
+// start starts the maximum amount of workers.
+func (wp *workerPool) start() {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	wp.log.InfoCtx(wp.ctx, "Starting workers", "workers", wp.limits.Max)
+	wp.resize(wp.limits.Max)
+}
+
+// sample returns the activity since the previous sample.
+func (wp *workerPool) sample() sample {
+	s := sample{
+		workers:  len(wp.stops),
+		inFlight: int(wp.stats.InFlight()),
+		ready:    -1,
+		crawls:   wp.stats.Crawls(),
+		failures: wp.stats.Failures(),
+		latency:  wp.stats.Latency(),
+	}
+
+	if i, ok := wp.queue.(queue.Inspector); ok {
+		ready, err := i.Ready(wp.ctx)
+		if err != nil {
+			wp.log.WarnCtx(wp.ctx, "Error inspecting queue", "err", err)
+		} else {
+			s.ready = ready
+		}
+	}
+
+	delta := s
+	delta.crawls -= wp.last.crawls
+	delta.failures -= wp.last.failures
+	delta.latency -= wp.last.latency
+
+	wp.last = s
+
+	return delta
+}
+
+// adapt adjusts the amount of workers to their activity since the previous call.
+func (wp *workerPool) adapt(cfg *config.Workers) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	s := wp.sample()
+
+	n, reason := nextWorkers(s, wp.limits, cfg)
+	if n == s.workers {
+		return
+	}
+
+	wp.log.InfoCtx(wp.ctx, "Scaling workers", "from", s.workers, "to", n, "reason", reason,
+		"crawls", s.crawls, "failures", s.failures, "in_flight", s.inFlight, "ready", s.ready)
+
+	wp.resize(n)
+}
+
+// setLimits changes the worker limits, adjusting the amount of workers to them; when not adaptive, it is set to
+// the maximum.
+func (wp *workerPool) setLimits(limits admin.WorkerLimits, adaptive bool) error {
+	if limits.Min < 1 || limits.Max < limits.Min {
+		return fmt.Errorf("invalid worker limits for %s: min %d, max %d", wp.name, limits.Min, limits.Max)
+	}
+
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	wp.limits = limits
+
+	n := limits.Max
+	if adaptive {
+		n = min(max(len(wp.stops), limits.Min), limits.Max)
+	}
+
+	wp.resize(n)
+
+	return nil
+}
+
+func (wp *workerPool) getLimits() admin.WorkerLimits {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	return wp.limits
+}
+
+func (p *Pool) getWorkerPools(ctx context.Context) ([]*workerPool, error) {
+	queues, err := p.getQueues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &p.config.Workers
+	workers := maxWorkers(cfg)
+
+	pools := []struct {
+		name    string
+		queue   queue.Queue
+		retrier *worker.Retrier
+	}{
+		{"files", queues.Files, p.retriers.Files},
+		{"hashes", queues.Hashes, p.retriers.Hashes},
+		{"directories", queues.Directories, p.retriers.Directories},
+	}
+
+	workerPools := make([]*workerPool, len(pools))
+
+	for i, wp := range pools {
+		limits := configLimits(cfg, workers[wp.name])
+		if workerPools[i], err = p.newWorkerPool(ctx, wp.name, wp.queue, wp.retrier, limits); err != nil {
+			return nil, err
+		}
+	}
+
+	return workerPools, nil
+}
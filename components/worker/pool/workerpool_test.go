@@ -0,0 +1,76 @@
+package pool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ipfs-search/ipfs-search/components/admin"
+	"github.com/ipfs-search/ipfs-search/config"
+)
+
+func TestNextWorkers(t *testing.T) {
+	cfg := config.WorkersDefaults()
+	limits := admin.WorkerLimits{Min: 10, Max: 120}
+
+	testCases := []struct {
+		name   string
+		s      sample
+		n      int
+		reason string
+	}{
+		{
+			"failing dependencies",
+			sample{workers: 100, inFlight: 100, ready: 1000, crawls: 100, failures: 50, latency: 100 * time.Second},
+			75, "error rate",
+		},
+		{
+			"slow crawls",
+			sample{workers: 100, inFlight: 100, ready: 1000, crawls: 10, latency: 20 * time.Minute},
+			75, "latency",
+		},
+		{
+			"backlog",
+			sample{workers: 100, inFlight: 90, ready: 1000, crawls: 100, latency: 100 * time.Second},
+			110, "backlog",
+		},
+		{
+			"backlog in queue without inspection",
+			sample{workers: 100, inFlight: 90, ready: -1},
+			110, "backlog",
+		},
+		{
+			"up to maximum",
+			sample{workers: 115, inFlight: 115, ready: 1000},
+			120, "backlog",
+		},
+		{
+			"busy without backlog",
+			sample{workers: 100, inFlight: 90, ready: 0},
+			100, "",
+		},
+		{
+			"idle",
+			sample{workers: 100, inFlight: 10, ready: 0},
+			90, "idle",
+		},
+		{
+			"down to minimum",
+			sample{workers: 10, inFlight: 0, ready: 0},
+			10, "idle",
+		},
+		{
+			"single worker",
+			sample{workers: 1, inFlight: 1, ready: 5},
+			10, "backlog",
+		},
+	}
+
+	for _, tc := range testCases {
+		n, reason := nextWorkers(tc.s, limits, &cfg)
+
+		assert.Equal(t, tc.n, n, tc.name)
+		assert.Equal(t, tc.reason, reason, tc.name)
+	}
+}
@@ -0,0 +1,181 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+// Headers set on retried and dead-lettered deliveries.
+const (
+	AttemptHeader    = "x-attempt"     // Amount of failed attempts.
+	ErrorHeader      = "x-error"       // Error of the last attempt.
+	ErrorClassHeader = "x-error-class" // ErrorClass of the last attempt.
+)
+
+// ErrorClass distinguishes errors by their likeliness of succeeding on a retry.
+type ErrorClass string
+
+const (
+	// PermanentError is an error which will not resolve by retrying, e.g. a malformed delivery.
+	PermanentError ErrorClass = "permanent"
+	// UnavailableError signifies content which could not be retrieved in time; it might become available.
+	UnavailableError ErrorClass = "unavailable"
+	// TransientError signifies a (likely) temporary failure of infrastructure; e.g. a failed request to an index.
+	TransientError ErrorClass = "transient"
+)
+
+// errMalformedDelivery is returned for deliveries which do not contain a valid resource.
+var errMalformedDelivery = errors.New("malformed delivery")
+
+// Classify returns the ErrorClass for an error returned from crawling a delivery.
+func Classify(err error) ErrorClass {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+
+	switch {
+	case errors.Is(err, errMalformedDelivery), errors.Is(err, t.ErrInvalidResource),
+		errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return PermanentError
+	case errors.Is(err, context.DeadlineExceeded):
+		return UnavailableError
+	default:
+		return TransientError
+	}
+}
+
+// Retrier republishes failed deliveries to a delay queue, from which they return to the original queue,
+// or to a dead-letter queue when no (more) retries are allowed.
+type Retrier struct {
+	config     *RetryConfig
+	delay      queue.DeliveryPublisher
+	deadLetter queue.DeliveryPublisher
+
+	*instr.Instrumentation
+	log *slog.Logger
+}
+
+// NewRetrier returns a new Retrier.
+func NewRetrier(config *RetryConfig, delay queue.DeliveryPublisher, deadLetter queue.DeliveryPublisher, i *instr.Instrumentation) *Retrier {
+	return &Retrier{
+		config, delay, deadLetter, i,
+		i.Component("worker"),
+	}
+}
+
+// maxRetries returns the maximum retries for an ErrorClass.
+func (r *Retrier) maxRetries(class ErrorClass) uint {
+	switch class {
+	case TransientError:
+		return r.config.MaxTransientRetries
+	case UnavailableError:
+		return r.config.MaxUnavailableRetries
+	default:
+		return 0
+	}
+}
+
+// backoff returns the delay before the given retry, starting at 1.
+func (r *Retrier) backoff(retry uint) time.Duration {
+	tiers := r.config.Tiers()
+
+	if retry > uint(len(tiers)) {
+		return tiers[len(tiers)-1]
+	}
+
+	return tiers[retry-1]
+}
+
+// attempts returns the amount of earlier failed attempts for a delivery.
+func attempts(d queue.Delivery) uint {
+	switch v := d.Headers()[AttemptHeader].(type) {
+	case int32:
+		return uint(v)
+	case int64:
+		return uint(v)
+	case int:
+		return uint(v)
+	default:
+		return 0
+	}
+}
+
+// Retry schedules a delivery which failed with err for retry, or dead-letters it when its retries are exhausted.
+// The original delivery should be acknowledged when Retry returns without error.
+func (r *Retrier) Retry(ctx context.Context, d queue.Delivery, err error) error {
+	class := Classify(err)
+	attempt := attempts(d) + 1
+
+	ctx, span := r.Tracer.Start(ctx, "crawler.worker.Retry", trace.WithAttributes(
+		attribute.String("class", string(class)),
+		attribute.Int("attempt", int(attempt)),
+	))
+	defer span.End()
+
+	headers := queue.Headers{
+		AttemptHeader:    int32(attempt),
+		ErrorHeader:      err.Error(),
+		ErrorClassHeader: string(class),
+	}
+
+	var publishErr error
+
+	if attempt > r.maxRetries(class) {
+		r.log.WarnCtx(ctx, "Dead-lettering delivery", "attempts", attempt, "class", class, "err", err)
+		span.AddEvent("dead-letter")
+
+		publishErr = r.deadLetter.PublishDelivery(ctx, d, headers, 0)
+	} else {
+		delay := r.backoff(attempt)
+
+		r.log.InfoCtx(ctx, "Retrying delivery", "delay", delay, "attempts", attempt, "class", class, "err", err)
+		span.AddEvent("retry", trace.WithAttributes(attribute.Stringer("delay", delay)))
+
+		publishErr = r.delay.PublishDelivery(ctx, d, headers, delay)
+	}
+
+	if publishErr != nil {
+		span.RecordError(publishErr)
+		return fmt.Errorf("republishing delivery: %w", publishErr)
+	}
+
+	return nil
+}
+
+// Postpone schedules a delivery which could not be crawled as a dependency's circuit breaker is open, err, for the
+// shortest retry delay without counting an attempt. The original delivery should be acknowledged when Postpone
+// returns without error.
+func (r *Retrier) Postpone(ctx context.Context, d queue.Delivery, err error) error {
+	ctx, span := r.Tracer.Start(ctx, "crawler.worker.Postpone")
+	defer span.End()
+
+	headers := make(queue.Headers, len(d.Headers())+1)
+	for k, v := range d.Headers() {
+		headers[k] = v
+	}
+
+	headers[ErrorHeader] = err.Error()
+
+	delay := r.backoff(1)
+
+	r.log.DebugCtx(ctx, "Postponing delivery, circuit breaker open", "delay", delay, "err", err)
+
+	if err := r.delay.PublishDelivery(ctx, d, headers, delay); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("republishing delivery: %w", err)
+	}
+
+	return nil
+}
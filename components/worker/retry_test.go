@@ -0,0 +1,160 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+type RetryTestSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	cfg        *RetryConfig
+	delay      *queue.Mock
+	deadLetter *queue.Mock
+	r          *Retrier
+}
+
+func (s *RetryTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.cfg = DefaultRetryConfig()
+	s.delay = &queue.Mock{}
+	s.deadLetter = &queue.Mock{}
+	s.r = NewRetrier(s.cfg, s.delay, s.deadLetter, instr.New())
+}
+
+func (s *RetryTestSuite) assertExpectations() {
+	s.delay.AssertExpectations(s.T())
+	s.deadLetter.AssertExpectations(s.T())
+}
+
+func (s *RetryTestSuite) TestClassify() {
+	var syntaxErr error = &json.SyntaxError{}
+
+	testCases := []struct {
+		err   error
+		class ErrorClass
+	}{
+		{fmt.Errorf("%w: bogus", errMalformedDelivery), PermanentError},
+		{t.ErrUnsupportedType, PermanentError},
+		{fmt.Errorf("wrapped: %w", syntaxErr), PermanentError},
+		{fmt.Errorf("listing: %w", context.DeadlineExceeded), UnavailableError},
+		{fmt.Errorf("%w: 429 Too Many Requests", t.ErrUnexpectedResponse), TransientError},
+		{errors.New("connection refused"), TransientError},
+	}
+
+	for _, tc := range testCases {
+		s.Equal(tc.class, Classify(tc.err), tc.err.Error())
+	}
+}
+
+func (s *RetryTestSuite) TestBackoff() {
+	s.cfg.InitialDelay = time.Second
+	s.cfg.Multiplier = 2
+	s.cfg.MaxDelay = 5 * time.Second
+
+	s.Equal(time.Second, s.r.backoff(1))
+	s.Equal(2*time.Second, s.r.backoff(2))
+	s.Equal(4*time.Second, s.r.backoff(3))
+	s.Equal(5*time.Second, s.r.backoff(4))
+}
+
+func (s *RetryTestSuite) TestTiers() {
+	s.Equal([]time.Duration{time.Minute, 4 * time.Minute, 16 * time.Minute, time.Hour}, s.cfg.Tiers())
+
+	s.cfg.Multiplier = 1
+	s.Equal([]time.Duration{time.Minute}, s.cfg.Tiers())
+
+	s.cfg.InitialDelay = 2 * time.Hour
+	s.Equal([]time.Duration{time.Hour}, s.cfg.Tiers())
+}
+
+func (s *RetryTestSuite) TestRetryFirstAttempt() {
+	d := queue.NewMockDelivery([]byte("{}"), nil, 0)
+	err := errors.New("connection refused")
+
+	s.delay.
+		On("PublishDelivery", mock.Anything, d, queue.Headers{
+			AttemptHeader:    int32(1),
+			ErrorHeader:      err.Error(),
+			ErrorClassHeader: string(TransientError),
+		}, s.cfg.InitialDelay).
+		Return(nil).
+		Once()
+
+	s.NoError(s.r.Retry(s.ctx, d, err))
+	s.assertExpectations()
+}
+
+func (s *RetryTestSuite) TestRetryBackoff() {
+	d := queue.NewMockDelivery([]byte("{}"), queue.Headers{AttemptHeader: int32(1)}, 0)
+	err := context.DeadlineExceeded
+
+	s.delay.
+		On("PublishDelivery", mock.Anything, d, mock.MatchedBy(func(h queue.Headers) bool {
+			return h[AttemptHeader] == int32(2) && h[ErrorClassHeader] == string(UnavailableError)
+		}), s.r.backoff(2)).
+		Return(nil).
+		Once()
+
+	s.NoError(s.r.Retry(s.ctx, d, err))
+	s.assertExpectations()
+}
+
+func (s *RetryTestSuite) TestRetryExhausted() {
+	d := queue.NewMockDelivery([]byte("{}"), queue.Headers{AttemptHeader: int32(s.cfg.MaxUnavailableRetries)}, 0)
+	err := context.DeadlineExceeded
+
+	s.deadLetter.
+		On("PublishDelivery", mock.Anything, d, mock.MatchedBy(func(h queue.Headers) bool {
+			return h[AttemptHeader] == int32(s.cfg.MaxUnavailableRetries+1)
+		}), time.Duration(0)).
+		Return(nil).
+		Once()
+
+	s.NoError(s.r.Retry(s.ctx, d, err))
+	s.assertExpectations()
+}
+
+func (s *RetryTestSuite) TestRetryPermanent() {
+	d := queue.NewMockDelivery([]byte("bogus"), nil, 0)
+	err := fmt.Errorf("%w: bogus", errMalformedDelivery)
+
+	s.deadLetter.
+		On("PublishDelivery", mock.Anything, d, mock.MatchedBy(func(h queue.Headers) bool {
+			return h[ErrorClassHeader] == string(PermanentError)
+		}), time.Duration(0)).
+		Return(nil).
+		Once()
+
+	s.NoError(s.r.Retry(s.ctx, d, err))
+	s.assertExpectations()
+}
+
+func (s *RetryTestSuite) TestRetryPublishError() {
+	d := queue.NewMockDelivery([]byte("{}"), nil, 0)
+	publishErr := errors.New("channel closed")
+
+	s.delay.
+		On("PublishDelivery", mock.Anything, d, mock.Anything, mock.Anything).
+		Return(publishErr).
+		Once()
+
+	s.ErrorIs(s.r.Retry(s.ctx, d, errors.New("connection refused")), publishErr)
+	s.assertExpectations()
+}
+
+func TestRetryTestSuite(t *testing.T) {
+	suite.Run(t, new(RetryTestSuite))
+}
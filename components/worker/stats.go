@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"time"
+)
+
+// Stats counts the running workers of a pool, the deliveries they are processing and the crawls they have done.
+// It is safe for concurrent use.
+type Stats struct {
+	workers     atomic.Int64
+	inFlight    atomic.Int64
+	interrupted atomic.Int64
+	crawls      atomic.Int64
+	failures    atomic.Int64
+	latency     atomic.Int64 // Total duration of crawls, in nanoseconds.
+}
+
+// Workers returns the number of running workers.
+func (s *Stats) Workers() int64 {
+	return s.workers.Load()
+}
+
+// InFlight returns the number of deliveries being processed.
+func (s *Stats) InFlight() int64 {
+	return s.inFlight.Load()
+}
+
+// Interrupted returns the number of deliveries requeued because their crawl was interrupted by shutting down.
+func (s *Stats) Interrupted() int64 {
+	return s.interrupted.Load()
+}
+
+// Crawls returns the number of completed crawls, successful or not.
+func (s *Stats) Crawls() int64 {
+	return s.crawls.Load()
+}
+
+// Failures returns the number of crawls failing because of errors in our infrastructure, rather than the resource.
+func (s *Stats) Failures() int64 {
+	return s.failures.Load()
+}
+
+// Latency returns the total duration of completed crawls.
+func (s *Stats) Latency() time.Duration {
+	return time.Duration(s.latency.Load())
+}
+
+// recordCrawl records a completed crawl.
+func (s *Stats) recordCrawl(d time.Duration, failed bool) {
+	s.crawls.Add(1)
+	s.latency.Add(int64(d))
+
+	if failed {
+		s.failures.Add(1)
+	}
+}
+
+// MarshalJSON returns the counts as a JSON object.
+func (s *Stats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Workers     int64 `json:"workers"`
+		InFlight    int64 `json:"in_flight"`
+		Interrupted int64 `json:"interrupted"`
+		Crawls      int64 `json:"crawls"`
+		Failures    int64 `json:"failures"`
+	}{s.Workers(), s.InFlight(), s.Interrupted(), s.Crawls(), s.Failures()})
+}
@@ -3,13 +3,15 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"time"
 
-	samqp "github.com/rabbitmq/amqp091-go"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
 
 	"github.com/ipfs-search/ipfs-search/components/crawler"
+	"github.com/ipfs-search/ipfs-search/components/queue"
 	"github.com/ipfs-search/ipfs-search/instr"
 	t "github.com/ipfs-search/ipfs-search/types"
 )
@@ -18,22 +20,31 @@ import (
 type Worker struct {
 	name    string
 	crawler *crawler.Crawler
+	retrier *Retrier
+	stats   *Stats
 
 	*instr.Instrumentation
+	log *slog.Logger
 }
 
-// New returns a new worker.
-func New(name string, crawler *crawler.Crawler, i *instr.Instrumentation) *Worker {
+// New returns a new worker, counted in stats.
+func New(name string, crawler *crawler.Crawler, retrier *Retrier, stats *Stats, i *instr.Instrumentation) *Worker {
 	return &Worker{
-		name, crawler, i,
+		name, crawler, retrier, stats, i,
+		i.Component("worker").With("worker", name),
 	}
 }
 
-// Start crawling deliveries, synchronously.
-func (w *Worker) Start(ctx context.Context, deliveries <-chan samqp.Delivery) {
-	ctx, span := w.Tracer.Start(ctx, "crawler.pool.startWorker")
+// Start crawling deliveries, synchronously, until ctx is closed. Crawls run in crawlCtx, which may outlive ctx so
+// that in-flight deliveries are finished when stopping; deliveries of crawls interrupted by closing crawlCtx are
+// requeued.
+func (w *Worker) Start(ctx, crawlCtx context.Context, deliveries <-chan queue.Delivery) {
+	crawlCtx, span := w.Tracer.Start(crawlCtx, "crawler.pool.startWorker")
 	defer span.End()
 
+	w.stats.workers.Add(1)
+	defer w.stats.workers.Add(-1)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -43,25 +54,94 @@ func (w *Worker) Start(ctx context.Context, deliveries <-chan samqp.Delivery) {
 				// This is a fatal error; it should never happen - crash the program!
 				panic("unexpected channel close")
 			}
-			if err := w.crawlDelivery(ctx, d); err != nil {
-				// By default, do not retry.
-				shouldRetry := false
-
-				span.RecordError(err)
 
-				if err := d.Reject(shouldRetry); err != nil {
-					span.RecordError(err)
-				}
-			} else {
-				if err := d.Ack(false); err != nil {
+			if ctx.Err() != nil {
+				// Stopped while receiving; leave the delivery for the next worker.
+				if err := d.Nack(true); err != nil {
 					span.RecordError(err)
 				}
+
+				return
 			}
+
+			w.process(crawlCtx, d)
 		}
 	}
 }
 
-func (w *Worker) crawlDelivery(ctx context.Context, d samqp.Delivery) error {
+// process crawls a delivery, acknowledging it when done.
+func (w *Worker) process(ctx context.Context, d queue.Delivery) {
+	span := trace.SpanFromContext(ctx)
+
+	w.stats.inFlight.Add(1)
+	defer w.stats.inFlight.Add(-1)
+
+	start := time.Now()
+	err := w.crawlDelivery(ctx, d)
+
+	if ctx.Err() == nil {
+		w.stats.recordCrawl(time.Since(start), isFailure(err))
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		w.handleError(ctx, d, err)
+
+		return
+	}
+
+	if err := d.Ack(); err != nil {
+		span.RecordError(err)
+	}
+}
+
+// isFailure returns true for errors of the crawler's dependencies, as opposed to errors caused by resources.
+func isFailure(err error) bool {
+	return err != nil && (errors.Is(err, t.ErrCircuitOpen) || Classify(err) == TransientError)
+}
+
+// handleError schedules a failed delivery for retry, requeueing it when that is not possible.
+func (w *Worker) handleError(ctx context.Context, d queue.Delivery, err error) {
+	span := trace.SpanFromContext(ctx)
+
+	if ctx.Err() != nil {
+		// We're shutting down; leave the delivery for the next worker.
+		w.stats.interrupted.Add(1)
+		w.log.InfoCtx(ctx, "Requeueing interrupted delivery", "err", err)
+
+		if err := d.Nack(true); err != nil {
+			span.RecordError(err)
+		}
+
+		return
+	}
+
+	var retryErr error
+
+	if errors.Is(err, t.ErrCircuitOpen) {
+		// A dependency is failing; delay without counting a retry attempt, rather than requeueing right away.
+		retryErr = w.retrier.Postpone(ctx, d, err)
+	} else {
+		retryErr = w.retrier.Retry(ctx, d, err)
+	}
+
+	if err := retryErr; err != nil {
+		w.log.ErrorCtx(ctx, "Requeueing delivery, error scheduling retry", "err", err)
+		span.RecordError(err)
+
+		if err := d.Nack(true); err != nil {
+			span.RecordError(err)
+		}
+
+		return
+	}
+
+	if err := d.Ack(); err != nil {
+		span.RecordError(err)
+	}
+}
+
+func (w *Worker) crawlDelivery(ctx context.Context, d queue.Delivery) error {
 	ctx, span := w.Tracer.Start(ctx, "crawler.pool.crawlDelivery", trace.WithNewRoot())
 	defer span.End()
 
@@ -69,23 +149,25 @@ func (w *Worker) crawlDelivery(ctx context.Context, d samqp.Delivery) error {
 		Resource: &t.Resource{},
 	}
 
-	if err := json.Unmarshal(d.Body, r); err != nil {
+	if err := json.Unmarshal(d.Body(), r); err != nil {
 		span.RecordError(err)
 		return err
 	}
 
 	if !r.IsValid() {
-		err := fmt.Errorf("Invalid resource: %v", r)
+		err := fmt.Errorf("%w: invalid resource %v", errMalformedDelivery, r)
 		span.RecordError(err)
 		return err
 	}
 
-	log.Printf("Crawling '%s'", r)
-	err := w.crawler.Crawl(ctx, r)
-	log.Printf("Done crawling '%s', result: %v", r, err)
+	w.log.DebugCtx(ctx, "Crawling", "cid", r.ID, "resource", r.String())
 
+	err := w.crawler.Crawl(ctx, r)
 	if err != nil {
+		w.log.WarnCtx(ctx, "Error crawling", "cid", r.ID, "err", err)
 		span.RecordError(err)
+	} else {
+		w.log.DebugCtx(ctx, "Done crawling", "cid", r.ID)
 	}
 
 	return err
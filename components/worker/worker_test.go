@@ -0,0 +1,116 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/components/queue"
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+type WorkerTestSuite struct {
+	suite.Suite
+
+	delay      *queue.Mock
+	deadLetter *queue.Mock
+	stats      *Stats
+	w          *Worker
+}
+
+func (s *WorkerTestSuite) SetupTest() {
+	i := instr.New()
+
+	s.delay = &queue.Mock{}
+	s.deadLetter = &queue.Mock{}
+	s.stats = new(Stats)
+
+	// Malformed deliveries fail before crawling, so no crawler is required.
+	r := NewRetrier(DefaultRetryConfig(), s.delay, s.deadLetter, i)
+	s.w = New("test-0", nil, r, s.stats, i)
+}
+
+// run starts the worker, sends d and stops it, returning when the worker has returned.
+func (s *WorkerTestSuite) run(crawlCtx context.Context, d queue.Delivery) {
+	ctx, cancel := context.WithCancel(context.Background())
+	deliveries := make(chan queue.Delivery)
+	done := make(chan struct{})
+
+	go func() {
+		s.w.Start(ctx, crawlCtx, deliveries)
+		close(done)
+	}()
+
+	deliveries <- d
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.FailNow("worker did not stop")
+	}
+}
+
+func (s *WorkerTestSuite) TestProcess() {
+	d := queue.NewMockDelivery([]byte("bogus"), nil, 0)
+
+	s.deadLetter.
+		On("PublishDelivery", mock.Anything, d, mock.Anything, time.Duration(0)).
+		Return(nil).
+		Once()
+	d.On("Ack").Return(nil).Once()
+
+	s.run(context.Background(), d)
+
+	s.deadLetter.AssertExpectations(s.T())
+	d.AssertExpectations(s.T())
+	s.Zero(s.stats.Workers())
+	s.Zero(s.stats.InFlight())
+	s.Zero(s.stats.Interrupted())
+	s.Equal(int64(1), s.stats.Crawls())
+	s.Zero(s.stats.Failures(), "malformed deliveries are not failures of dependencies")
+}
+
+func (s *WorkerTestSuite) TestInterrupted() {
+	crawlCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	d := queue.NewMockDelivery([]byte("bogus"), nil, 0)
+	d.On("Nack", true).Return(nil).Once()
+
+	s.run(crawlCtx, d)
+
+	d.AssertExpectations(s.T())
+	s.deadLetter.AssertNotCalled(s.T(), "PublishDelivery")
+	s.Equal(int64(1), s.stats.Interrupted())
+	s.Zero(s.stats.InFlight())
+	s.Zero(s.stats.Crawls(), "interrupted crawls are not recorded")
+}
+
+func (s *WorkerTestSuite) TestCircuitOpen() {
+	d := queue.NewMockDelivery([]byte("{}"), queue.Headers{AttemptHeader: int32(1)}, 0)
+	d.On("Ack").Return(nil).Once()
+
+	// Delayed, without counting an attempt.
+	s.delay.
+		On("PublishDelivery", mock.Anything, d, mock.MatchedBy(func(h queue.Headers) bool {
+			return h[AttemptHeader] == int32(1) && h[ErrorHeader] == "circuit open: tika"
+		}), DefaultRetryConfig().InitialDelay).
+		Return(nil).
+		Once()
+
+	s.w.handleError(context.Background(), d, fmt.Errorf("%w: tika", t.ErrCircuitOpen))
+
+	d.AssertExpectations(s.T())
+	s.delay.AssertExpectations(s.T())
+	s.deadLetter.AssertNotCalled(s.T(), "PublishDelivery")
+}
+
+func TestWorkerTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkerTestSuite))
+}
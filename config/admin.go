@@ -0,0 +1,25 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ipfs-search/ipfs-search/components/admin"
+)
+
+// Admin contains configuration for the admin server of the crawler.
+type Admin struct {
+	Listen       string        `yaml:"listen" env:"ADMIN_LISTEN" optional:"true"`               // Address to listen on; disabled when empty.
+	CheckTimeout time.Duration `yaml:"check_timeout"`                                           // Timeout for readiness checks.
+	AllowChanges bool          `yaml:"allow_changes" env:"ADMIN_ALLOW_CHANGES" optional:"true"` // Allow changes at runtime, such as worker limits.
+}
+
+// AdminConfig returns component-specific configuration from the canonical central configuration.
+func (c *Config) AdminConfig() *admin.Config {
+	cfg := admin.Config(c.Admin)
+	return &cfg
+}
+
+// AdminDefaults wraps the defaults from the component-specific configuration.
+func AdminDefaults() Admin {
+	return Admin(*admin.DefaultConfig())
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ipfs-search/ipfs-search/components/api"
+)
+
+// API contains configuration for the search API server.
+type API struct {
+	Listen          string        `yaml:"listen" env:"API_LISTEN"` // Address to listen on.
+	DefaultPageSize uint          `yaml:"default_page_size"`       // Results per page when not specified.
+	MaxPageSize     uint          `yaml:"max_page_size"`           // Maximum results per page.
+	MaxResults      uint          `yaml:"max_results"`             // Maximum results to page through.
+	Timeout         time.Duration `yaml:"timeout"`                 // Timeout for handling requests.
+}
+
+// APIConfig returns component-specific configuration from the canonical central configuration.
+func (c *Config) APIConfig() *api.Config {
+	cfg := api.Config(c.API)
+	return &cfg
+}
+
+// APIDefaults wraps the defaults from the component-specific configuration.
+func APIDefaults() API {
+	return API(*api.DefaultConfig())
+}
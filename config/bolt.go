@@ -0,0 +1,24 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ipfs-search/ipfs-search/components/index/bolt"
+)
+
+// Bolt holds configuration for the embedded bolt index backend.
+type Bolt struct {
+	Path        string        `yaml:"path" env:"BOLT_PATH"` // Path of the database file.
+	LockTimeout time.Duration `yaml:"lock_timeout"`         // Time to wait for the database lock held by other processes.
+}
+
+// BoltConfig returns component-specific configuration from the canonical central configuration.
+func (c *Config) BoltConfig() *bolt.ClientConfig {
+	cfg := bolt.ClientConfig(c.Bolt)
+	return &cfg
+}
+
+// BoltDefaults wraps the defaults from the component-specific configuration.
+func BoltDefaults() Bolt {
+	return Bolt(*bolt.DefaultClientConfig())
+}
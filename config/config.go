@@ -41,9 +41,11 @@ type Config struct {
 	IPFS       `yaml:"ipfs"`
 	OpenSearch `yaml:"opensearch"`
 	Redis      `yaml:"redis"`
+	Bolt       `yaml:"bolt"`
 	AMQP       `yaml:"amqp"`
 	Tika       `yaml:"tika"`
 	NSFW       `yaml:"nsfw"`
+	Native     `yaml:"native"`
 
 	Instr   `yaml:"instrumentation"`
 	Crawler `yaml:"crawler"`
@@ -51,6 +53,13 @@ type Config struct {
 	Indexes `yaml:"indexes"`
 	Queues  `yaml:"queues"`
 	Workers `yaml:"workers"`
+	Guards  `yaml:"guards"`
+	Retry   `yaml:"retry"`
+	API     `yaml:"api"`
+	Admin   `yaml:"admin"`
+
+	Recrawler   `yaml:"recrawler"`
+	MemoryQueue `yaml:"memory_queue"`
 }
 
 // String renders config as YAML
@@ -10,8 +10,14 @@ type Crawler struct {
 	DirEntryBufferSize uint          `yaml:"direntry_buffer_size"` // Size of buffer for processing directory entry channels.
 	MinUpdateAge       time.Duration `yaml:"min_update_age"`       // The minimum age for items to be updated.
 	StatTimeout        time.Duration `yaml:"stat_timeout"`         // Timeout for Stat() calls.
+	ResolveTimeout     time.Duration `yaml:"resolve_timeout"`      // Timeout for Resolve() calls.
 	DirEntryTimeout    time.Duration `yaml:"direntry_timeout"`     // Timeout *between* directory entries.
-	MaxDirSize         uint          `yaml:"max_dirsize"`          // Maximum number of directory entries
+	MaxDirSize         uint          `yaml:"max_dirsize"`          // Maximum number of directory entries per document (page).
+	CheckpointTTL      time.Duration `yaml:"checkpoint_ttl"`       // Maximum age of directory checkpoints for resuming crawls.
+	DagTimeout         time.Duration `yaml:"dag_timeout"`          // Timeout for GetDag() calls.
+	MaxDagFields       uint          `yaml:"max_dag_fields"`       // Maximum number of fields (and links) indexed for DAGs; only indexed links are queued.
+	MaxProviders       uint          `yaml:"max_providers"`        // Maximum number of providing peers recorded per document.
+	MaxNameHistory     uint          `yaml:"max_name_history"`     // Maximum number of (most recent) resolutions recorded per name.
 }
 
 // CrawlerConfig returns component-specific configuration from the canonical central configuration.
@@ -6,14 +6,22 @@ func Default() *Config {
 		IPFSDefaults(),
 		OpenSearchDefaults(),
 		RedisDefaults(),
+		BoltDefaults(),
 		AMQPDefaults(),
 		TikaDefaults(),
 		NSFWDefaults(),
+		NativeDefaults(),
 		InstrDefaults(),
 		CrawlerDefaults(),
 		SnifferDefaults(),
 		IndexesDefaults(),
 		QueuesDefaults(),
 		WorkersDefaults(),
+		GuardsDefaults(),
+		RetryDefaults(),
+		APIDefaults(),
+		AdminDefaults(),
+		RecrawlerDefaults(),
+		MemoryQueueDefaults(),
 	}
 }
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ipfs-search/ipfs-search/utils"
+)
+
+// Guard holds the rate limit and circuit breaker for requests to a dependency.
+type Guard struct {
+	Rate          float64       `yaml:"rate" optional:"true"`            // Maximum requests per second, 0 for unlimited.
+	Burst         int           `yaml:"burst"`                           // Requests allowed at once, regardless of rate.
+	MaxFailures   uint          `yaml:"max_failures" optional:"true"`    // Consecutive failures opening the circuit breaker, 0 to disable.
+	OpenTimeout   time.Duration `yaml:"open_timeout"`                    // Time requests are refused before trying again.
+	FailOnTimeout bool          `yaml:"fail_on_timeout" optional:"true"` // Count timeouts as failures.
+}
+
+// Guards represents the guards for the dependencies of the crawler.
+type Guards struct {
+	IPFS    Guard `yaml:"ipfs"`    // IPFS API.
+	Gateway Guard `yaml:"gateway"` // IPFS gateway, used by the native extractor.
+	Tika    Guard `yaml:"tika"`    // Tika extractor.
+	NSFW    Guard `yaml:"nsfw"`    // NSFW server.
+}
+
+// GuardConfig returns component-specific configuration for a Guard.
+func (g Guard) GuardConfig() *utils.GuardConfig {
+	cfg := utils.GuardConfig(g)
+	return &cfg
+}
+
+// GuardsDefaults returns the default guards. Timeouts of the IPFS API and gateway are not counted as failures, as
+// these are common for unavailable content; extractors time out when overloaded.
+func GuardsDefaults() Guards {
+	guard := Guard(*utils.DefaultGuardConfig())
+
+	extractorGuard := guard
+	extractorGuard.FailOnTimeout = true
+
+	return Guards{
+		IPFS:    guard,
+		Gateway: guard,
+		Tika:    extractorGuard,
+		NSFW:    extractorGuard,
+	}
+}
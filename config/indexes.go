@@ -1,9 +1,17 @@
 package config
 
+// Index backends.
+const (
+	OpenSearchBackend = "opensearch" // OpenSearch, cached in Redis where applicable.
+	RedisBackend      = "redis"      // Redis only.
+	BoltBackend       = "bolt"       // Embedded, on-disk, bolt database.
+)
+
 // Index represents the configuration for a single Index.
 type Index struct {
-	Name   string
-	Prefix string
+	Name    string
+	Prefix  string
+	Backend string
 }
 
 // Indexes represents the various indexes we're using
@@ -12,26 +20,54 @@ type Indexes struct {
 	Directories Index `yaml:"directories"`
 	Invalids    Index `yaml:"invalids"`
 	Partials    Index `yaml:"partials"`
+	Names       Index `yaml:"names"`
+	Dags        Index `yaml:"dags"`
+	Checkpoints Index `yaml:"checkpoints"`
+	Extractions Index `yaml:"extractions"`
 }
 
 // IndexesDefaults returns the default indexes.
 func IndexesDefaults() Indexes {
 	return Indexes{
 		Files: Index{
-			Name:   "ipfs_files",
-			Prefix: "f",
+			Name:    "ipfs_files",
+			Prefix:  "f",
+			Backend: OpenSearchBackend,
 		},
 		Directories: Index{
-			Name:   "ipfs_directories",
-			Prefix: "d",
+			Name:    "ipfs_directories",
+			Prefix:  "d",
+			Backend: OpenSearchBackend,
 		},
 		Invalids: Index{
-			Name:   "ipfs_invalids",
-			Prefix: "i",
+			Name:    "ipfs_invalids",
+			Prefix:  "i",
+			Backend: OpenSearchBackend,
 		},
 		Partials: Index{
-			Name:   "ipfs_partials",
-			Prefix: "p",
+			Name:    "ipfs_partials",
+			Prefix:  "p",
+			Backend: OpenSearchBackend,
+		},
+		Names: Index{
+			Name:    "ipfs_names",
+			Prefix:  "n",
+			Backend: OpenSearchBackend,
+		},
+		Dags: Index{
+			Name:    "ipfs_dags",
+			Prefix:  "g",
+			Backend: OpenSearchBackend,
+		},
+		Checkpoints: Index{
+			Name:    "ipfs_checkpoints",
+			Prefix:  "c",
+			Backend: RedisBackend,
+		},
+		Extractions: Index{
+			Name:    "ipfs_extractions",
+			Prefix:  "x",
+			Backend: OpenSearchBackend,
 		},
 	}
 }
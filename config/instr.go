@@ -6,8 +6,18 @@ import (
 
 // Instr specifies the configuration for instrumentation.
 type Instr struct {
-	SamplingRatio  float64 `yaml:"sampling_ratio" env:"OTEL_TRACE_SAMPLER_ARG"`         // Parent-based sampling ratio (fraction of sniffed hashes traced). Defaults to `0.01` (1%). For some reason, setting this as an environment option fails.
-	JaegerEndpoint string  `yaml:"jaeger_endpoint" env:"OTEL_EXPORTER_JAEGER_ENDPOINT"` // Send spans to Jaeger HTTP endpoint, for example `http://jaeger:14268/api/traces`.
+	SamplingRatio      float64 `yaml:"sampling_ratio" env:"OTEL_TRACE_SAMPLER_ARG"`                           // Parent-based sampling ratio (fraction of sniffed hashes traced). Defaults to `0.01` (1%). For some reason, setting this as an environment option fails.
+	Exporter           string  `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`                                   // Trace exporter: `otlp`, `jaeger`, `stdout` or `none`.
+	JaegerEndpoint     string  `yaml:"jaeger_endpoint" env:"OTEL_EXPORTER_JAEGER_ENDPOINT"`                   // Send spans to Jaeger HTTP endpoint, for example `http://jaeger:14268/api/traces`.
+	OTLPProtocol       string  `yaml:"otlp_protocol" env:"OTEL_EXPORTER_OTLP_PROTOCOL"`                       // OTLP protocol: `grpc` or `http/protobuf`.
+	OTLPEndpoint       string  `yaml:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`                       // URL of the OTLP receiver, for example `https://otlp.example.com:4317`. TLS is used unless the scheme is `http`.
+	OTLPHeaders        string  `yaml:"otlp_headers" env:"OTEL_EXPORTER_OTLP_HEADERS" optional:"true"`         // Headers for OTLP exports, for example `authorization=Bearer%20token,x-tenant=search`.
+	OTLPCertificate    string  `yaml:"otlp_certificate" env:"OTEL_EXPORTER_OTLP_CERTIFICATE" optional:"true"` // CA certificate file to verify the OTLP receiver, instead of the system's certificates.
+	ResourceAttributes string  `yaml:"resource_attributes" env:"OTEL_RESOURCE_ATTRIBUTES" optional:"true"`    // Resource attributes, for example `service.instance.id=crawler-1,service.version=1.2.3`.
+	MetricsAddress     string  `yaml:"metrics_address" env:"METRICS_ADDRESS" optional:"true"`                 // Serve Prometheus metrics on /metrics at this address, for example `:9464`. Disabled when empty.
+	LogLevel           string  `yaml:"log_level" env:"LOG_LEVEL"`                                             // Minimum level of log records: `debug`, `info`, `warn` or `error`.
+	LogFormat          string  `yaml:"log_format" env:"LOG_FORMAT"`                                           // Log format: `text` or `json`.
+	LogLevels          string  `yaml:"log_levels" env:"LOG_LEVELS" optional:"true"`                           // Log levels for components, overriding `log_level`, for example `cache=debug,crawler=warn`.
 }
 
 // InstrConfig returns component-specific configuration from the canonical central configuration.
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ipfs-search/ipfs-search/components/queue/memory"
+)
+
+// MemoryQueue holds configuration for in-process queues, used with the memory queue backend.
+type MemoryQueue struct {
+	Persistence      string        `yaml:"persistence" env:"MEMORY_QUEUE_PERSISTENCE"` // Either 'disk' or 'none'.
+	Dir              string        `yaml:"dir" env:"MEMORY_QUEUE_DIR"`                 // Directory holding persisted queues.
+	SnapshotInterval time.Duration `yaml:"snapshot_interval"`                          // Interval between persisting queues.
+}
+
+// MemoryQueueConfig returns component-specific configuration from the canonical central configuration.
+func (c *Config) MemoryQueueConfig() *memory.Config {
+	cfg := memory.Config(c.MemoryQueue)
+	return &cfg
+}
+
+// MemoryQueueDefaults wraps the defaults from the component-specific configuration.
+func MemoryQueueDefaults() MemoryQueue {
+	return MemoryQueue(*memory.DefaultConfig())
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"time"
+
+	"github.com/c2h5oh/datasize"
+
+	"github.com/ipfs-search/ipfs-search/components/extractor/native"
+)
+
+// Native is configuration pertaining to the native extractor.
+type Native struct {
+	MimeTypes      []string          `yaml:"mime_types"`
+	RequestTimeout time.Duration     `yaml:"timeout"`
+	MaxFileSize    datasize.ByteSize `yaml:"max_file_size"`
+	Fallback       string            `yaml:"fallback" env:"NATIVE_FALLBACK"`
+}
+
+// NativeConfig returns component-specific configuration from the canonical central configuration.
+func (c *Config) NativeConfig() *native.Config {
+	cfg := native.Config(c.Native)
+	return &cfg
+}
+
+// NativeDefaults returns the defaults for component configuration, based on the component-specific configuration.
+func NativeDefaults() Native {
+	return Native(*native.DefaultConfig())
+}
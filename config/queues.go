@@ -1,20 +1,38 @@
 package config
 
+// Queue backends.
+const (
+	AMQPBackend   = "amqp"   // RabbitMQ (or another AMQP broker).
+	MemoryBackend = "memory" // In-process queues, optionally persisted to disk.
+)
+
 // Queue holds the configuration for a single Queue.
 type Queue struct {
 	Name string `yaml:"name"` // Name of the Queue.
 }
 
+// DelayName returns the name of the queue holding deliveries scheduled for retry.
+func (q Queue) DelayName() string {
+	return q.Name + ".delay"
+}
+
+// DeadLetterName returns the name of the queue holding deliveries which exhausted their retries.
+func (q Queue) DeadLetterName() string {
+	return q.Name + ".dead"
+}
+
 // Queues represents the various queues we're using
 type Queues struct {
-	Files       Queue `yaml:"files"`       // Resources known to be files.
-	Directories Queue `yaml:"directories"` // Resources known to be directories.
-	Hashes      Queue `yaml:"hashes"`      // Resources with unknown type.
+	Backend     string `yaml:"backend" env:"QUEUE_BACKEND"` // AMQPBackend or MemoryBackend.
+	Files       Queue  `yaml:"files"`                       // Resources known to be files.
+	Directories Queue  `yaml:"directories"`                 // Resources known to be directories.
+	Hashes      Queue  `yaml:"hashes"`                      // Resources with unknown type.
 }
 
 // QueuesDefaults returns the default queues.
 func QueuesDefaults() Queues {
 	return Queues{
+		Backend: AMQPBackend,
 		Files: Queue{
 			Name: "files",
 		},
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ipfs-search/ipfs-search/components/recrawler"
+)
+
+// Recrawler contains configuration for scheduling re-crawls of stale documents.
+type Recrawler struct {
+	MaxAge          time.Duration `yaml:"max_age" env:"RECRAWL_MAX_AGE"` // Documents not seen (nor checked) for longer are re-crawled.
+	Interval        time.Duration `yaml:"interval"`                      // Time in between scheduling runs.
+	BatchSize       uint          `yaml:"batch_size"`                    // Amount of documents retrieved at once.
+	MaxDocuments    uint          `yaml:"max_documents"`                 // Maximum amount of documents scheduled per index per run.
+	ScrollKeepAlive time.Duration `yaml:"scroll_keepalive"`              // Time to keep the scroll alive in between batches.
+}
+
+// RecrawlerConfig returns component-specific configuration from the canonical central configuration.
+func (c *Config) RecrawlerConfig() *recrawler.Config {
+	cfg := recrawler.Config(c.Recrawler)
+	return &cfg
+}
+
+// RecrawlerDefaults wraps the defaults from the component-specific configuration.
+func RecrawlerDefaults() Recrawler {
+	return Recrawler(*recrawler.DefaultConfig())
+}
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/exp/slog"
+	yaml "gopkg.in/yaml.v3"
+
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+// PollInterval is the interval at which a Watcher checks its configuration file for changes.
+var PollInterval = 5 * time.Second
+
+// Reloader is implemented by components which apply configuration changes while running.
+type Reloader interface {
+	Reload(*Config) error
+}
+
+// ReloaderFunc allows the use of an ordinary function as Reloader.
+type ReloaderFunc func(*Config) error
+
+// Reload calls f(c).
+func (f ReloaderFunc) Reload(c *Config) error {
+	return f(c)
+}
+
+// Change represents a changed configuration option, identified by its YAML path.
+type Change struct {
+	Key string
+	Old string
+	New string
+}
+
+// flatten adds the values of a YAML document to values, by path.
+func flatten(prefix string, v interface{}, values map[string]string) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		values[prefix] = fmt.Sprint(v)
+		return
+	}
+
+	for k, v := range m {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+
+		flatten(k, v, values)
+	}
+}
+
+// values returns the configuration options of c as strings, by YAML path.
+func (c *Config) values() (map[string]string, error) {
+	bs, err := c.Marshall()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(bs, &doc); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	flatten("", doc, values)
+
+	return values, nil
+}
+
+// redact hides credentials in option values, so that they can be logged.
+func redact(key, value string) string {
+	if strings.HasSuffix(key, "headers") && value != "" {
+		return "xxxxx"
+	}
+
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+
+	return value
+}
+
+// Diff returns the options which differ between old and new, ordered by key. Credentials in values are redacted.
+func Diff(old, new *Config) ([]Change, error) {
+	oldValues, err := old.values()
+	if err != nil {
+		return nil, err
+	}
+
+	newValues, err := new.values()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+
+	for k, v := range newValues {
+		if oldValues[k] != v {
+			changes = append(changes, Change{k, redact(k, oldValues[k]), redact(k, v)})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+
+	return changes, nil
+}
+
+// Watcher reloads configuration on SIGHUP and when its configuration file changes, passing it on to reloaders after
+// it has been checked.
+type Watcher struct {
+	file      string
+	reloaders []Reloader
+
+	mu      sync.Mutex
+	current *Config
+	modTime time.Time
+
+	log *slog.Logger
+}
+
+// NewWatcher returns a Watcher for configuration read from file, which may be empty to read only the environment,
+// with current as the configuration in use.
+func NewWatcher(file string, current *Config, i *instr.Instrumentation) *Watcher {
+	w := &Watcher{
+		file:    file,
+		current: current,
+		log:     i.Component("config"),
+	}
+
+	w.modTime, _ = w.stat()
+
+	return w
+}
+
+// Add registers r to be called with reloaded configuration.
+func (w *Watcher) Add(r Reloader) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.reloaders = append(w.reloaders, r)
+}
+
+// stat returns the modification time of the configuration file, if any.
+func (w *Watcher) stat() (time.Time, error) {
+	if w.file == "" {
+		return time.Time{}, nil
+	}
+
+	fi, err := os.Stat(w.file)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return fi.ModTime(), nil
+}
+
+// Reload reads and checks the configuration, logs changed options and passes it to the reloaders. Invalid
+// configuration is not applied. When reloaders fail, the first error is returned.
+func (w *Watcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	cfg, err := Get(w.file)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Check(); err != nil {
+		return err
+	}
+
+	changes, err := Diff(w.current, cfg)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		w.log.Info("Configuration unchanged")
+		return nil
+	}
+
+	for _, c := range changes {
+		w.log.Info("Configuration changed", "key", c.Key, "old", c.Old, "new", c.New)
+	}
+
+	var reloadErr error
+
+	for _, r := range w.reloaders {
+		if err := r.Reload(cfg); err != nil {
+			w.log.Error("Error applying configuration", "err", err)
+
+			if reloadErr == nil {
+				reloadErr = err
+			}
+		}
+	}
+
+	w.current = cfg
+
+	return reloadErr
+}
+
+// Watch reloads configuration on SIGHUP and when the modification time of the configuration file changes, until
+// ctx is closed. Errors are logged.
+func (w *Watcher) Watch(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			w.log.Info("Received SIGHUP, reloading configuration")
+		case <-ticker.C:
+			modTime, err := w.stat()
+			if err != nil || modTime.Equal(w.modTime) {
+				continue
+			}
+
+			w.modTime = modTime
+			w.log.Info("Configuration file changed, reloading", "file", w.file)
+		}
+
+		if err := w.Reload(); err != nil {
+			w.log.Error("Error reloading configuration", "err", err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ipfs-search/ipfs-search/components/worker"
+)
+
+// Retry contains the retry policy for failed crawls.
+type Retry struct {
+	InitialDelay          time.Duration `yaml:"initial_delay" env:"RETRY_INITIAL_DELAY"`                     // Delay before the first retry.
+	MaxDelay              time.Duration `yaml:"max_delay" env:"RETRY_MAX_DELAY"`                             // Maximum delay between retries.
+	Multiplier            float64       `yaml:"multiplier"`                                                  // Factor by which the delay increases for every subsequent retry.
+	MaxTransientRetries   uint          `yaml:"max_transient_retries" env:"RETRY_MAX_TRANSIENT_RETRIES"`     // Maximum retries for transient (infrastructure) errors.
+	MaxUnavailableRetries uint          `yaml:"max_unavailable_retries" env:"RETRY_MAX_UNAVAILABLE_RETRIES"` // Maximum retries for unavailable content (timeouts).
+}
+
+// RetryConfig returns component-specific configuration from the canonical central configuration.
+func (c *Config) RetryConfig() *worker.RetryConfig {
+	cfg := worker.RetryConfig(c.Retry)
+	return &cfg
+}
+
+// RetryDefaults wraps the defaults from the component-specific configuration.
+func RetryDefaults() Retry {
+	return Retry(*worker.DefaultRetryConfig())
+}
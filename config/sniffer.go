@@ -9,6 +9,11 @@ import (
 type Sniffer struct {
 	LastSeenExpiration time.Duration `yaml:"lastseen_expiration" env:"SNIFFER_LASTSEEN_EXPIRATION"`
 	LastSeenPruneLen   int           `yaml:"lastseen_prunelen" env:"SNIFFER_LASTSEEN_PRUNELEN"`
+	LastSeenSnapshot   string        `yaml:"lastseen_snapshot" env:"SNIFFER_LASTSEEN_SNAPSHOT" optional:"true"`
+	SnapshotInterval   time.Duration `yaml:"snapshot_interval"`
+	DedupFilter        string        `yaml:"dedup_filter" env:"SNIFFER_DEDUP_FILTER"`
+	BloomCapacity      uint          `yaml:"bloom_capacity" env:"SNIFFER_BLOOM_CAPACITY"`
+	BloomFPRate        float64       `yaml:"bloom_fp_rate" env:"SNIFFER_BLOOM_FP_RATE"`
 	LoggerTimeout      time.Duration `yaml:"logger_timeout"`
 	BufferSize         uint          `yaml:"buffer_size" env:"SNIFFER_BUFFER_SIZE"`
 }
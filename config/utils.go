@@ -5,7 +5,8 @@ import (
 	"reflect"
 )
 
-// findZeroElements returns a slice of all (nested) struct fields with a zero value.
+// findZeroElements returns a slice of all (nested) struct fields with a zero value, except for those tagged
+// `optional:"true"`.
 func findZeroElements(s interface{}) []string {
 	var output []string
 
@@ -14,7 +15,12 @@ func findZeroElements(s interface{}) []string {
 	// Iterate over fields
 	for i := 0; i < v.NumField(); i++ {
 		f := v.Field(i)
-		name := v.Type().Field(i).Tag.Get("yaml")
+		tag := v.Type().Field(i).Tag
+		name := tag.Get("yaml")
+
+		if tag.Get("optional") == "true" {
+			continue
+		}
 
 		switch f.Kind() {
 		case reflect.Struct:
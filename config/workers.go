@@ -1,5 +1,9 @@
 package config
 
+import (
+	"time"
+)
+
 /*
 Workers contains the configuration for the worker pool.
 
@@ -11,6 +15,14 @@ type Workers struct {
 	DirectoryWorkers  int `yaml:"directory_workers" env:"DIRECTORY_WORKERS"`
 	MaxIPFSConns      int `yaml:"ipfs_max_connections" env:"IPFS_MAX_CONNECTIONS"`
 	MaxExtractorConns int `yaml:"extractor_max_connections" env:"EXTRACTOR_MAX_CONNECTIONS"`
+
+	GracePeriod time.Duration `yaml:"grace_period" env:"WORKER_GRACE_PERIOD"` // Time to finish in-flight crawls on shutdown.
+
+	// Adaptive concurrency; the amounts of workers above are the maximum.
+	MinWorkers    int           `yaml:"min_workers"`                                                // Minimum workers per queue.
+	AdaptInterval time.Duration `yaml:"adapt_interval" env:"WORKER_ADAPT_INTERVAL" optional:"true"` // Adapt workers this often, 0 for fixed amounts.
+	MaxLatency    time.Duration `yaml:"max_latency"`                                                // Reduce workers when crawls take longer on average.
+	MaxErrorRate  float64       `yaml:"max_error_rate"`                                             // Reduce workers when more crawls fail on dependencies.
 }
 
 // WorkersDefaults returns the default configuration for the workerpool.
@@ -21,5 +33,10 @@ func WorkersDefaults() Workers {
 		DirectoryWorkers:  70,
 		MaxIPFSConns:      1000,
 		MaxExtractorConns: 100,
+		GracePeriod:       20 * time.Second, // Leaves time to flush within the default termination grace period of Kubernetes.
+		MinWorkers:        10,
+		AdaptInterval:     30 * time.Second,
+		MaxLatency:        time.Minute,
+		MaxErrorRate:      0.2,
 	}
 }
@@ -2,14 +2,39 @@ package instr
 
 // Config specifies the configuration for the instrumentation.
 type Config struct {
-	SamplingRatio  float64 // Parent-based sampling ratio (fraction of sniffed hashes traced).
-	JaegerEndpoint string  // Send spans to Jaeger HTTP endpoint.
+	SamplingRatio      float64 // Parent-based sampling ratio (fraction of sniffed hashes traced).
+	Exporter           string  // Trace exporter; OTLPExporter, JaegerExporter, StdoutExporter or NoExporter.
+	JaegerEndpoint     string  // Send spans to Jaeger HTTP endpoint.
+	OTLPProtocol       string  // Protocol for the OTLP exporter; OTLPGRPC or OTLPHTTP.
+	OTLPEndpoint       string  // URL of the OTLP receiver; TLS is used unless the scheme is http.
+	OTLPHeaders        string  // Headers for OTLP exports as comma-separated key=value pairs, e.g. for authentication.
+	OTLPCertificate    string  // CA certificate file to verify the OTLP receiver, instead of the system's certificates.
+	ResourceAttributes string  // Resource attributes as comma-separated key=value pairs, e.g. service.instance.id.
+	MetricsAddress     string  // Serve Prometheus metrics on /metrics at this address; disabled when empty.
+	LogLevel           string  // Minimum level of log records; debug, info, warn or error.
+	LogFormat          string  // Log format; TextFormat or JSONFormat.
+	LogLevels          string  // Log levels overriding LogLevel for components as comma-separated component=level pairs.
+}
+
+// WithoutMetricsServer returns a copy of the configuration which does not serve metrics; for short-lived commands,
+// whose listeners would otherwise conflict with those of long-running ones.
+func (c *Config) WithoutMetricsServer() *Config {
+	cfg := *c
+	cfg.MetricsAddress = ""
+
+	return &cfg
 }
 
 // DefaultConfig returns the default configuration for the instrumentation.
 func DefaultConfig() *Config {
 	return &Config{
 		SamplingRatio:  0.01,
+		Exporter:       JaegerExporter,
 		JaegerEndpoint: "http://localhost:14268/api/traces",
+		OTLPProtocol:   OTLPGRPC,
+		OTLPEndpoint:   "http://localhost:4317",
+		MetricsAddress: ":9464",
+		LogLevel:       "info",
+		LogFormat:      TextFormat,
 	}
 }
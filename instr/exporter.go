@@ -0,0 +1,178 @@
+package instr
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// Trace exporters.
+const (
+	OTLPExporter   = "otlp"   // OpenTelemetry protocol, over OTLPProtocol.
+	JaegerExporter = "jaeger" // Jaeger HTTP collector; deprecated upstream in favour of OTLP.
+	StdoutExporter = "stdout" // Pretty-printed spans on stdout, for debugging.
+	NoExporter     = "none"   // Do not export spans.
+)
+
+// OTLP protocols.
+const (
+	OTLPGRPC = "grpc"
+	OTLPHTTP = "http/protobuf"
+)
+
+var (
+	errUnknownExporter = errors.New("unknown trace exporter")
+	errUnknownProtocol = errors.New("unknown OTLP protocol")
+	errInvalidKeyValue = errors.New("invalid key=value pair")
+	errCertificate     = errors.New("no certificates found")
+)
+
+// parseKeyValues parses comma-separated key=value pairs, with URL-encoded values, as used for OTLP headers
+// and resource attributes.
+func parseKeyValues(s string) (map[string]string, error) {
+	kvs := make(map[string]string)
+
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		k, v, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("%w: '%s'", errInvalidKeyValue, pair)
+		}
+
+		v, err := url.QueryUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s': %v", errInvalidKeyValue, pair, err)
+		}
+
+		kvs[strings.TrimSpace(k)] = v
+	}
+
+	return kvs, nil
+}
+
+// resourceAttributes returns the configured resource attributes.
+func resourceAttributes(config *Config) ([]attribute.KeyValue, error) {
+	kvs, err := parseKeyValues(config.ResourceAttributes)
+	if err != nil {
+		return nil, fmt.Errorf("resource attributes: %w", err)
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(kvs))
+	for k, v := range kvs {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+
+	return attrs, nil
+}
+
+// tlsConfig returns the TLS configuration for OTLP, trusting the configured CA certificate when set and
+// the system's certificates otherwise.
+func tlsConfig(config *Config) (*tls.Config, error) {
+	if config.OTLPCertificate == "" {
+		return &tls.Config{}, nil
+	}
+
+	pem, err := os.ReadFile(config.OTLPCertificate)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w in %s", errCertificate, config.OTLPCertificate)
+	}
+
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// newOTLPExporter returns an OTLP exporter for the configured protocol. The endpoint is a URL, where the http
+// scheme disables TLS.
+func newOTLPExporter(ctx context.Context, config *Config) (tracesdk.SpanExporter, error) {
+	u, err := url.Parse(config.OTLPEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("OTLP endpoint: %w", err)
+	}
+
+	headers, err := parseKeyValues(config.OTLPHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("OTLP headers: %w", err)
+	}
+
+	insecure := u.Scheme == "http"
+
+	var tlsCfg *tls.Config
+	if !insecure {
+		if tlsCfg, err = tlsConfig(config); err != nil {
+			return nil, fmt.Errorf("OTLP certificate: %w", err)
+		}
+	}
+
+	switch config.OTLPProtocol {
+	case OTLPGRPC:
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(u.Host),
+			otlptracegrpc.WithHeaders(headers),
+		}
+
+		if insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		} else {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		}
+
+		return otlptracegrpc.New(ctx, opts...)
+
+	case OTLPHTTP:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(u.Host),
+			otlptracehttp.WithHeaders(headers),
+		}
+
+		if u.Path != "" && u.Path != "/" {
+			opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+		}
+
+		if insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		} else {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsCfg))
+		}
+
+		return otlptracehttp.New(ctx, opts...)
+
+	default:
+		return nil, fmt.Errorf("%w: '%s', should be '%s' or '%s'", errUnknownProtocol, config.OTLPProtocol, OTLPGRPC, OTLPHTTP)
+	}
+}
+
+// newExporter returns the configured span exporter, or nil when spans are not exported.
+func newExporter(ctx context.Context, config *Config) (tracesdk.SpanExporter, error) {
+	switch config.Exporter {
+	case OTLPExporter:
+		return newOTLPExporter(ctx, config)
+	case JaegerExporter:
+		return jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(config.JaegerEndpoint)))
+	case StdoutExporter:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case NoExporter:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: '%s'", errUnknownExporter, config.Exporter)
+	}
+}
@@ -0,0 +1,69 @@
+package instr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKeyValues(t *testing.T) {
+	kvs, err := parseKeyValues("authorization=Bearer%20token, x-tenant = search,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer token", "x-tenant": "search"}, kvs)
+
+	kvs, err = parseKeyValues("")
+	assert.NoError(t, err)
+	assert.Empty(t, kvs)
+
+	_, err = parseKeyValues("service.version")
+	assert.ErrorIs(t, err, errInvalidKeyValue)
+}
+
+func TestNewExporter(t *testing.T) {
+	ctx := context.Background()
+
+	for _, c := range []struct {
+		exporter, protocol, endpoint string
+	}{
+		{StdoutExporter, "", ""},
+		{JaegerExporter, "", ""},
+		{OTLPExporter, OTLPGRPC, "http://localhost:4317"},
+		{OTLPExporter, OTLPGRPC, "https://otlp.example.com:4317"},
+		{OTLPExporter, OTLPHTTP, "http://localhost:4318/custom/traces"},
+	} {
+		cfg := DefaultConfig()
+		cfg.Exporter, cfg.OTLPProtocol, cfg.OTLPEndpoint = c.exporter, c.protocol, c.endpoint
+
+		exp, err := newExporter(ctx, cfg)
+		assert.NoError(t, err, c.exporter)
+		assert.NotNil(t, exp, c.exporter)
+	}
+}
+
+func TestNewExporterNone(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Exporter = NoExporter
+
+	exp, err := newExporter(context.Background(), cfg)
+	assert.NoError(t, err)
+	assert.Nil(t, exp)
+}
+
+func TestNewExporterErrors(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Exporter = "zipkin"
+
+	_, err := newExporter(context.Background(), cfg)
+	assert.ErrorIs(t, err, errUnknownExporter)
+
+	cfg.Exporter, cfg.OTLPProtocol = OTLPExporter, "http/json"
+
+	_, err = newExporter(context.Background(), cfg)
+	assert.ErrorIs(t, err, errUnknownProtocol)
+
+	cfg.OTLPProtocol, cfg.OTLPEndpoint, cfg.OTLPCertificate = OTLPGRPC, "https://otlp.example.com", "/nonexistent/ca.pem"
+
+	_, err = newExporter(context.Background(), cfg)
+	assert.Error(t, err)
+}
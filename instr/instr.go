@@ -3,16 +3,27 @@ package instr
 
 import (
 	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/attribute"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/propagation"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
 )
 
 const (
@@ -21,12 +32,66 @@ const (
 
 // Instrumentation provides a canonical representation of instrumentation.
 type Instrumentation struct {
-	Tracer trace.Tracer
+	Tracer  trace.Tracer
+	Meter   metric.Meter
+	Metrics *Metrics
+	Logger  *slog.Logger // Loggers should be obtained after Install(), to use the configured handler.
 }
 
-// Install configures and installs a Jaeger tracing pipeline. The first returned argument is a flusher, which should be called on program exit.
+// Install configures and installs the default logger, a tracing pipeline with the configured exporter and a Prometheus metrics
+// pipeline, serving metrics on the configured address. The first returned argument is a flusher, which should be
+// called on program exit.
 func Install(config *Config, serviceName string) (func(context.Context), error) {
-	log.Printf("Creating Jaeger pipeline for service '%s' at ratio %f to endpoint %s", serviceName, config.SamplingRatio, config.JaegerEndpoint)
+	if err := installLogging(config, os.Stderr); err != nil {
+		return nil, err
+	}
+
+	attrs, err := resourceAttributes(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Configured attributes take precedence, e.g. to set service.instance.id or service.version.
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		append([]attribute.KeyValue{semconv.ServiceNameKey.String(serviceName)}, attrs...)...,
+	)
+
+	tp, err := installTracing(config, serviceName, res)
+	if err != nil {
+		return nil, err
+	}
+
+	mp, srv := installMetrics(config, res)
+
+	return func(ctx context.Context) {
+		// Do not make the application hang when it is shutdown.
+		ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+		defer cancel()
+
+		if srv != nil {
+			if err := srv.Shutdown(ctx); err != nil {
+				slog.Error("Error closing metrics server", "err", err)
+			}
+		}
+
+		if err := mp.Shutdown(ctx); err != nil {
+			slog.Error("Error shutting down metrics", "err", err)
+		}
+
+		// Without an exporter there are no span processors, and shutting down fails.
+		if config.Exporter == NoExporter {
+			return
+		}
+
+		if err := tp.Shutdown(ctx); err != nil {
+			log.Fatal(err)
+		}
+	}, nil
+}
+
+func installTracing(config *Config, serviceName string, res *resource.Resource) (*tracesdk.TracerProvider, error) {
+	slog.Info("Creating tracing pipeline", "service", serviceName, "ratio", config.SamplingRatio, "exporter", config.Exporter)
 
 	// Configure context propagation
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
@@ -34,44 +99,83 @@ func Install(config *Config, serviceName string) (func(context.Context), error)
 	// Configure sampler; default 1% of incoming requests (sniffed hashes)
 	sampler := tracesdk.ParentBased(tracesdk.TraceIDRatioBased(config.SamplingRatio))
 
-	exp, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(config.JaegerEndpoint)))
+	exp, err := newExporter(context.Background(), config)
 	if err != nil {
 		return nil, err
 	}
 
-	tp := tracesdk.NewTracerProvider(
-		// Always be sure to batch in production.
-		tracesdk.WithBatcher(exp),
+	opts := []tracesdk.TracerProviderOption{
 		tracesdk.WithSampler(sampler),
 		// Record information about this application in a Resource.
-		tracesdk.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(serviceName),
-		)),
-	)
+		tracesdk.WithResource(res),
+	}
+
+	if exp != nil {
+		// Always be sure to batch in production.
+		opts = append(opts, tracesdk.WithBatcher(exp))
+	}
+
+	tp := tracesdk.NewTracerProvider(opts...)
 
 	// Register our TracerProvider as the global so any imported
 	// instrumentation in the future will default to using it.
 	otel.SetTracerProvider(tp)
 
-	return func(ctx context.Context) {
-		// Do not make the application hang when it is shutdown.
-		ctx, cancel := context.WithTimeout(ctx, time.Second*5)
-		defer cancel()
-		if err := tp.Shutdown(ctx); err != nil {
-			log.Fatal(err)
+	return tp, nil
+}
+
+// installMetrics installs a MeterProvider exporting to Prometheus and, when an address is configured, starts
+// serving metrics on /metrics. Errors serving metrics are logged, rather than stopping the application.
+func installMetrics(config *Config, res *resource.Resource) (*metricsdk.MeterProvider, *http.Server) {
+	exp := otelprom.New()
+
+	// The exporter can only be collected from after registering it with the provider.
+	mp := metricsdk.NewMeterProvider(
+		metricsdk.WithReader(exp),
+		metricsdk.WithResource(res),
+	)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		exp.Collector,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	// Instruments created on the global meter before are delegated to the new provider.
+	global.SetMeterProvider(mp)
+
+	if config.MetricsAddress == "" {
+		return mp, nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	srv := &http.Server{
+		Addr:              config.MetricsAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	slog.Info("Serving metrics", "url", "http://"+config.MetricsAddress+"/metrics")
+
+	go func() {
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Error serving metrics", "err", err)
 		}
-	}, nil
-	// return jaeger.InstallNewPipeline(
-	// 	jaeger.WithCollectorEndpoint(config.JaegerEndpoint),
-	// 	jaeger.WithProcess(jaeger.Process{ServiceName: serviceName}),
-	// 	jaeger.WithSDK(&sdktrace.Config{DefaultSampler: sampler}),
-	// )
+	}()
+
+	return mp, srv
 }
 
-// New generates a representation of instrumentation containing the globally registered tracer and meter.
+// New generates a representation of instrumentation containing the globally registered tracer and meter and the
+// default logger.
 func New() *Instrumentation {
 	return &Instrumentation{
-		Tracer: otel.Tracer(name),
+		Tracer:  otel.Tracer(name),
+		Meter:   global.Meter(name),
+		Metrics: getMetrics(),
+		Logger:  logger(),
 	}
 }
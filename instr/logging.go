@@ -0,0 +1,197 @@
+package instr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// Log formats.
+const (
+	TextFormat = "text" // Human-readable key=value pairs.
+	JSONFormat = "json" // One JSON object per line.
+)
+
+// ComponentKey is the attribute key naming the component of a logger, used to apply per-component log levels.
+const ComponentKey = "component"
+
+var errUnknownFormat = errors.New("unknown log format")
+
+// logLevels holds the minimum log level, with overrides for components, which can be changed at runtime.
+type logLevels struct {
+	mu         sync.RWMutex
+	level      slog.Level
+	components map[string]slog.Level
+}
+
+// levels applies to all loggers returned by New().
+var levels = &logLevels{components: make(map[string]slog.Level)}
+
+// enabled returns whether records at level are logged for component, which is empty for loggers without one.
+func (l *logLevels) enabled(component string, level slog.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if min, ok := l.components[component]; ok {
+		return level >= min
+	}
+
+	return level >= l.level
+}
+
+func (l *logLevels) set(level slog.Level, components map[string]slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.level = level
+	l.components = components
+}
+
+// parseLogLevels returns the configured log level and per-component overrides.
+func parseLogLevels(config *Config) (slog.Level, map[string]slog.Level, error) {
+	var level slog.Level
+
+	if err := level.UnmarshalText([]byte(config.LogLevel)); err != nil {
+		return level, nil, fmt.Errorf("log level: %w", err)
+	}
+
+	kvs, err := parseKeyValues(config.LogLevels)
+	if err != nil {
+		return level, nil, fmt.Errorf("log levels: %w", err)
+	}
+
+	components := make(map[string]slog.Level, len(kvs))
+	for component, l := range kvs {
+		var cl slog.Level
+		if err := cl.UnmarshalText([]byte(l)); err != nil {
+			return level, nil, fmt.Errorf("log level for %s: %w", component, err)
+		}
+
+		components[component] = cl
+	}
+
+	return level, components, nil
+}
+
+// SetLogLevels applies the configured log level and per-component overrides to all loggers, including those
+// already created.
+func SetLogLevels(config *Config) error {
+	level, components, err := parseLogLevels(config)
+	if err != nil {
+		return err
+	}
+
+	levels.set(level, components)
+
+	return nil
+}
+
+// handler filters records by the level of its component and adds the trace and span ID's of the context.
+type handler struct {
+	slog.Handler
+	levels    *logLevels
+	component string
+}
+
+// Enabled returns whether records at level are logged for the handler's component.
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.levels.enabled(h.component, level)
+}
+
+// Handle adds trace_id and span_id attributes for recorded spans in ctx, passing the record on.
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a handler with attrs, taking the component from them when set.
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	component := h.component
+
+	for _, a := range attrs {
+		if a.Key == ComponentKey {
+			component = a.Value.String()
+		}
+	}
+
+	return &handler{h.Handler.WithAttrs(attrs), h.levels, component}
+}
+
+// WithGroup returns a handler qualifying subsequent attributes with name.
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{h.Handler.WithGroup(name), h.levels, h.component}
+}
+
+// newHandler returns a handler writing records in format to w.
+func newHandler(w io.Writer, format string) (slog.Handler, error) {
+	var opts slog.HandlerOptions
+
+	switch format {
+	case TextFormat:
+		return &handler{opts.NewTextHandler(w), levels, ""}, nil
+	case JSONFormat:
+		return &handler{opts.NewJSONHandler(w), levels, ""}, nil
+	default:
+		return nil, fmt.Errorf("%w: '%s', should be '%s' or '%s'", errUnknownFormat, format, TextFormat, JSONFormat)
+	}
+}
+
+// installLogging installs the default logger, writing in the configured format to w at the configured levels.
+// Output of the log package is logged at LevelInfo.
+func installLogging(config *Config, w io.Writer) error {
+	if err := SetLogLevels(config); err != nil {
+		return err
+	}
+
+	h, err := newHandler(w, config.LogFormat)
+	if err != nil {
+		return err
+	}
+
+	slog.SetDefault(slog.New(h))
+
+	return nil
+}
+
+// logger returns the default logger, filtered by log level when it has not been installed.
+func logger() *slog.Logger {
+	l := slog.Default()
+
+	if _, ok := l.Handler().(*handler); !ok {
+		return slog.New(&handler{l.Handler(), levels, ""})
+	}
+
+	return l
+}
+
+// Component returns a logger for the named component, which is subject to the component's log level.
+func (i *Instrumentation) Component(name string) *slog.Logger {
+	return i.Logger.With(ComponentKey, name)
+}
+
+// sprintValue formats its value as a string when logged.
+type sprintValue struct {
+	v interface{}
+}
+
+// LogValue returns the value formatted with fmt.Sprint.
+func (s sprintValue) LogValue() slog.Value {
+	return slog.StringValue(fmt.Sprint(s.v))
+}
+
+// Sprint returns a log value formatting v with fmt.Sprint, only when it is logged. It is used for values such as
+// indexes, which are otherwise encoded as JSON objects.
+func Sprint(v interface{}) slog.LogValuer {
+	return sprintValue{v}
+}
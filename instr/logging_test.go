@@ -0,0 +1,141 @@
+package instr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+)
+
+// testLogger returns a logger writing JSON to buf at the configured levels, restoring the default levels after
+// the test.
+func testLogger(t *testing.T, cfg *Config, buf *bytes.Buffer) *slog.Logger {
+	t.Cleanup(func() {
+		require.NoError(t, SetLogLevels(DefaultConfig()))
+	})
+
+	require.NoError(t, SetLogLevels(cfg))
+
+	h, err := newHandler(buf, JSONFormat)
+	require.NoError(t, err)
+
+	return slog.New(h)
+}
+
+// records returns the JSON records written to buf.
+func records(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var rs []map[string]interface{}
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		r := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal([]byte(line), &r))
+
+		rs = append(rs, r)
+	}
+
+	return rs
+}
+
+func TestComponentLevels(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LogLevels = "cache=debug,crawler=warn"
+
+	buf := new(bytes.Buffer)
+	l := testLogger(t, cfg, buf)
+
+	l.Debug("dropped")
+	l.Info("kept")
+	l.With(ComponentKey, "cache").Debug("cache hit")
+	l.With(ComponentKey, "crawler").Info("dropped")
+	l.With(ComponentKey, "crawler").Warn("crawler warning")
+
+	rs := records(t, buf)
+	require.Len(t, rs, 3)
+
+	assert.Equal(t, "kept", rs[0]["msg"])
+	assert.Equal(t, "cache hit", rs[1]["msg"])
+	assert.Equal(t, "cache", rs[1][ComponentKey])
+	assert.Equal(t, "crawler warning", rs[2]["msg"])
+}
+
+func TestSetLogLevelsRuntime(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := testLogger(t, DefaultConfig(), buf).With(ComponentKey, "bulkgetter")
+
+	l.Debug("dropped")
+
+	cfg := DefaultConfig()
+	cfg.LogLevels = "bulkgetter=debug"
+	require.NoError(t, SetLogLevels(cfg))
+
+	l.Debug("kept")
+
+	rs := records(t, buf)
+	require.Len(t, rs, 1)
+	assert.Equal(t, "kept", rs[0]["msg"])
+}
+
+func TestTraceIDs(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := testLogger(t, DefaultConfig(), buf)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	l.InfoCtx(ctx, "traced", "cid", "QmTest")
+	l.InfoCtx(context.Background(), "untraced")
+
+	rs := records(t, buf)
+	require.Len(t, rs, 2)
+
+	assert.Equal(t, "QmTest", rs[0]["cid"])
+	assert.Equal(t, traceID.String(), rs[0]["trace_id"])
+	assert.Equal(t, spanID.String(), rs[0]["span_id"])
+	assert.NotContains(t, rs[1], "trace_id")
+}
+
+func TestSprint(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := testLogger(t, DefaultConfig(), buf)
+
+	l.Info("get", "index", Sprint(namedIndex{}))
+
+	rs := records(t, buf)
+	require.Len(t, rs, 1)
+	assert.Equal(t, "ipfs_files", rs[0]["index"])
+}
+
+type namedIndex struct{}
+
+func (namedIndex) String() string { return "ipfs_files" }
+
+func TestLoggingConfigErrors(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LogLevel = "verbose"
+	assert.Error(t, SetLogLevels(cfg))
+
+	cfg = DefaultConfig()
+	cfg.LogLevels = "cache=verbose"
+	assert.Error(t, SetLogLevels(cfg))
+
+	cfg = DefaultConfig()
+	cfg.LogLevels = "cache"
+	assert.ErrorIs(t, SetLogLevels(cfg), errInvalidKeyValue)
+
+	_, err := newHandler(new(bytes.Buffer), "logfmt")
+	assert.ErrorIs(t, err, errUnknownFormat)
+}
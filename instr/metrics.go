@@ -0,0 +1,89 @@
+package instr
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+// Metrics holds the instruments recorded by components.
+//
+// Instruments can only be registered once with a MeterProvider, hence they're created once for all components;
+// instruments created before Install() record to the installed MeterProvider. Names follow Prometheus conventions,
+// as they're exported as-is.
+type Metrics struct {
+	Crawls             syncint64.Counter     // Crawled resources, by outcome.
+	CrawlDuration      syncfloat64.Histogram // Crawl duration in milliseconds, by outcome.
+	ExtractorDuration  syncfloat64.Histogram // Extractor latency in milliseconds, by extractor and result.
+	BulkIndexerFlushes syncint64.Histogram   // Documents per bulk indexer flush.
+	BulkIndexerErrors  syncint64.Counter     // Documents failing to be indexed and failed bulk indexer flushes, by kind.
+	BulkGetterBatches  syncint64.Histogram   // Requests per bulk getter batch.
+	SnifferFiltered    syncint64.Counter     // Providers passing or dropped by the sniffer's filters, by result.
+	QueuePublishErrors syncint64.Counter     // Errors publishing to a queue, by queue.
+	BreakerRejections  syncint64.Counter     // Requests refused by open circuit breakers, by dependency.
+}
+
+var (
+	metrics     *Metrics
+	metricsOnce sync.Once
+)
+
+// counter returns a new counter, or a no-op counter on errors.
+func counter(m metric.Meter, name, description string) syncint64.Counter {
+	c, err := m.SyncInt64().Counter(name, instrument.WithDescription(description))
+	if err != nil {
+		otel.Handle(err)
+		c, _ = metric.NewNoopMeter().SyncInt64().Counter(name)
+	}
+
+	return c
+}
+
+// histogram returns a new integer histogram, or a no-op histogram on errors.
+func histogram(m metric.Meter, name, description string) syncint64.Histogram {
+	h, err := m.SyncInt64().Histogram(name, instrument.WithDescription(description))
+	if err != nil {
+		otel.Handle(err)
+		h, _ = metric.NewNoopMeter().SyncInt64().Histogram(name)
+	}
+
+	return h
+}
+
+// durationHistogram returns a new histogram of durations in milliseconds, or a no-op histogram on errors.
+func durationHistogram(m metric.Meter, name, description string) syncfloat64.Histogram {
+	h, err := m.SyncFloat64().Histogram(name, instrument.WithDescription(description), instrument.WithUnit(unit.Milliseconds))
+	if err != nil {
+		otel.Handle(err)
+		h, _ = metric.NewNoopMeter().SyncFloat64().Histogram(name)
+	}
+
+	return h
+}
+
+// getMetrics returns the instruments, creating them on the global meter on first use.
+func getMetrics() *Metrics {
+	metricsOnce.Do(func() {
+		m := global.Meter(name)
+
+		metrics = &Metrics{
+			Crawls:             counter(m, "ipfs_search_crawler_crawls_total", "Crawled resources, by outcome."),
+			CrawlDuration:      durationHistogram(m, "ipfs_search_crawler_duration_milliseconds", "Duration of crawls, by outcome."),
+			ExtractorDuration:  durationHistogram(m, "ipfs_search_extractor_duration_milliseconds", "Duration of extraction, by extractor and result."),
+			BulkIndexerFlushes: histogram(m, "ipfs_search_opensearch_bulk_indexer_flush_size", "Documents per bulk indexer flush."),
+			BulkIndexerErrors:  counter(m, "ipfs_search_opensearch_bulk_indexer_errors_total", "Failed documents and flushes of the bulk indexer, by kind."),
+			BulkGetterBatches:  histogram(m, "ipfs_search_opensearch_bulk_getter_batch_size", "Requests per bulk getter batch."),
+			SnifferFiltered:    counter(m, "ipfs_search_sniffer_filtered_total", "Sniffed providers passing or dropped by filters, by result."),
+			QueuePublishErrors: counter(m, "ipfs_search_queue_publish_errors_total", "Errors publishing to queues, by queue."),
+			BreakerRejections:  counter(m, "ipfs_search_circuit_breaker_rejections_total", "Requests refused by open circuit breakers, by dependency."),
+		}
+	})
+
+	return metrics
+}
@@ -0,0 +1,32 @@
+package instr
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	// Instruments are created before installing, as they would be by components.
+	i := New()
+
+	mp, srv := installMetrics(&Config{MetricsAddress: "localhost:0"}, resource.Empty())
+	defer mp.Shutdown(ctx)
+	defer srv.Shutdown(ctx)
+
+	i.Metrics.Crawls.Add(ctx, 2, attribute.String("outcome", "new"))
+
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `ipfs_search_crawler_crawls_total{outcome="new"} 2`)
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+}
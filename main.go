@@ -4,9 +4,11 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/ipfs-search/ipfs-search/commands"
@@ -27,16 +29,139 @@ func main() {
 
 	app.Commands = []cli.Command{
 		{
-			Name:    "add",
-			Aliases: []string{"a"},
-			Usage:   "add `HASH` to crawler queue",
-			Action:  add,
+			Name:      "add",
+			Aliases:   []string{"a"},
+			Usage:     "add hashes or resource URIs to crawler queue",
+			ArgsUsage: "[HASH...]",
+			Description: "Queue hashes, resource URIs or paths given as arguments, one per line in a file (or stdin with\n" +
+				"   '-') or the roots and blocks of a CAR archive. Lines may also be JSON objects with hash, name and\n" +
+				"   source, e.g. {\"hash\": \"Qm...\", \"name\": \"file.txt\", \"source\": \"user\"}.",
+			Action: add,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "file, f",
+					Usage: "read hashes from `FILE`, one per line, or from stdin for '-'",
+				},
+				cli.StringFlag{
+					Name:  "car",
+					Usage: "read hashes from roots and blocks of CAR archive `FILE`, or from stdin for '-'",
+				},
+				cli.BoolFlag{
+					Name:  "roots-only",
+					Usage: "only add the roots of the CAR archive",
+				},
+				cli.UintFlag{
+					Name:  "priority",
+					Usage: "queue with `PRIORITY` from 0 to 9",
+					Value: 9,
+				},
+				cli.Float64Flag{
+					Name:  "rate",
+					Usage: "add at most `N` hashes per second, 0 for unlimited",
+					Value: 1000,
+				},
+			},
 		},
 		{
 			Name:    "crawl",
 			Aliases: []string{"c"},
 			Usage:   "start crawler",
 			Action:  crawl,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "seed",
+					Usage: "queue hashes from `FILE` once started, as with add, or from stdin for '-'",
+				},
+			},
+		},
+		{
+			Name:      "crawl-one",
+			Usage:     "crawl a single resource, tracing the crawler's decisions",
+			ArgsUsage: "CID",
+			Action:    crawlOne,
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "do not write to indexes or publish to queues",
+				},
+			},
+		},
+		{
+			Name:    "serve",
+			Aliases: []string{"s"},
+			Usage:   "start search API server",
+			Action:  serve,
+		},
+		{
+			Name:   "recrawl",
+			Usage:  "schedule re-crawling of stale documents",
+			Action: recrawl,
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "once",
+					Usage: "schedule once, instead of periodically",
+				},
+			},
+		},
+		{
+			Name:  "deadletter",
+			Usage: "dead-lettered deliveries, which exhausted their retries",
+			Subcommands: []cli.Command{
+				{
+					Name:      "inspect",
+					Usage:     "list dead-lettered deliveries for `QUEUE`",
+					ArgsUsage: "QUEUE",
+					Action:    inspectDeadLetters,
+				},
+				{
+					Name:      "replay",
+					Usage:     "move dead-lettered deliveries back to `QUEUE`",
+					ArgsUsage: "QUEUE",
+					Action:    replayDeadLetters,
+				},
+			},
+		},
+		{
+			Name:  "index",
+			Usage: "inspect and maintain the crawler's indexes",
+			Subcommands: []cli.Command{
+				{
+					Name:      "get",
+					Usage:     "show the document for `CID` from any of the indexes",
+					ArgsUsage: "CID",
+					Action:    getDocument,
+				},
+				{
+					Name:      "delete",
+					Usage:     "delete documents from `INDEX`, including its cache",
+					ArgsUsage: "INDEX CID...",
+					Action:    deleteDocuments,
+				},
+				{
+					Name:      "move",
+					Usage:     "move documents from index `FROM` to `TO`",
+					ArgsUsage: "FROM TO CID...",
+					Action:    moveDocuments,
+				},
+				{
+					Name:   "count",
+					Usage:  "count documents in each index",
+					Action: countDocuments,
+				},
+				{
+					Name:      "verify",
+					Usage:     "verify that the cache for `INDEX` agrees with the index for given or sampled documents",
+					ArgsUsage: "INDEX [CID...]",
+					Action:    verifyCache,
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "sample",
+							Usage: "verify `N` randomly sampled documents, when no CID's are given",
+							Value: 100,
+						},
+					},
+				},
+			},
 		},
 		{
 			Name:    "config",
@@ -128,23 +253,222 @@ func add(c *cli.Context) error {
 	// Allow SIGTERM / Control-C quit through context
 	onSigTerm(cancel)
 
+	file, car := c.String("file"), c.String("car")
+
+	if (file != "" && car != "") || (file != "" || car != "") == (c.NArg() > 0) {
+		return cli.NewExitError("Please supply hashes as arguments, or either --file or --car.", 1)
+	}
+
+	if c.Uint("priority") > 9 {
+		return cli.NewExitError("Priority should be between 0 and 9.", 1)
+	}
+
+	if !(c.Float64("rate") >= 0) {
+		return cli.NewExitError("Rate should be 0 or more.", 1)
+	}
+
+	cfg, err := getConfig(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	o := commands.DefaultAddOptions()
+	o.Priority = uint8(c.Uint("priority"))
+	o.Rate = c.Float64("rate")
+	o.Progress = os.Stderr
+
+	summary, err := addFrom(ctx, c, cfg, o)
+
+	if summary != nil {
+		fmt.Println(summary)
+	}
+
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+// addFrom adds hashes from the CAR archive, file or arguments specified.
+func addFrom(ctx context.Context, c *cli.Context, cfg *config.Config, o *commands.AddOptions) (*commands.AddSummary, error) {
+	name := c.String("car") + c.String("file")
+	if name == "" {
+		return commands.AddFromReader(ctx, cfg, strings.NewReader(strings.Join(c.Args(), "\n")), o)
+	}
+
+	r, err := openInput(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	if c.String("car") != "" {
+		return commands.AddFromCAR(ctx, cfg, r, c.Bool("roots-only"), o)
+	}
+
+	return commands.AddFromReader(ctx, cfg, r, o)
+}
+
+// openInput opens the named file, or stdin for "-".
+func openInput(name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	return os.Open(name)
+}
+
+func inspectDeadLetters(c *cli.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	if c.NArg() != 1 {
-		return cli.NewExitError("Please supply one hash as argument.", 1)
+		return cli.NewExitError("Please supply one queue as argument.", 1)
+	}
+
+	cfg, err := getConfig(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	if err := commands.InspectDeadLetters(ctx, cfg, c.Args().Get(0), os.Stdout); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func replayDeadLetters(c *cli.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Allow SIGTERM / Control-C quit through context
+	onSigTerm(cancel)
+
+	if c.NArg() != 1 {
+		return cli.NewExitError("Please supply one queue as argument.", 1)
+	}
+
+	cfg, err := getConfig(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	cnt, err := commands.ReplayDeadLetters(ctx, cfg, c.Args().Get(0))
+	fmt.Printf("Replayed %d deliveries\n", cnt)
+
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func getDocument(c *cli.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if c.NArg() != 1 {
+		return cli.NewExitError("Please supply one CID as argument.", 1)
+	}
+
+	cfg, err := getConfig(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	if err := commands.GetDocument(ctx, cfg, c.Args().Get(0), os.Stdout); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func deleteDocuments(c *cli.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if c.NArg() < 2 {
+		return cli.NewExitError("Please supply an index and one or more CID's as arguments.", 1)
+	}
+
+	cfg, err := getConfig(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	if err := commands.DeleteDocuments(ctx, cfg, c.Args().Get(0), c.Args().Tail(), os.Stdout); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func moveDocuments(c *cli.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if c.NArg() < 3 {
+		return cli.NewExitError("Please supply source and destination indexes and one or more CID's as arguments.", 1)
+	}
+
+	cfg, err := getConfig(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	args := c.Args()
+	if err := commands.MoveDocuments(ctx, cfg, args.Get(0), args.Get(1), args[2:], os.Stdout); err != nil {
+		return cli.NewExitError(err.Error(), 1)
 	}
-	hash := c.Args().Get(0)
+
+	return nil
+}
+
+func countDocuments(c *cli.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	cfg, err := getConfig(c)
 	if err != nil {
 		return cli.NewExitError(err.Error(), 1)
 	}
 
-	fmt.Printf("Adding hash '%s' to queue\n", hash)
+	if err := commands.CountDocuments(ctx, cfg, os.Stdout); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func verifyCache(c *cli.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Allow SIGTERM / Control-C quit through context
+	onSigTerm(cancel)
+
+	if c.NArg() < 1 {
+		return cli.NewExitError("Please supply an index and, optionally, CID's as arguments.", 1)
+	}
+
+	cfg, err := getConfig(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
 
-	err = commands.AddHash(ctx, cfg, hash)
+	summary, err := commands.VerifyCache(ctx, cfg, c.Args().Get(0), c.Args().Tail(), c.Int("sample"), os.Stdout)
 	if err != nil {
 		return cli.NewExitError(err.Error(), 1)
 	}
 
+	fmt.Println(summary)
+
+	if !summary.Consistent() {
+		return cli.NewExitError("Cache is inconsistent.", 2)
+	}
+
 	return nil
 }
 
@@ -183,7 +507,82 @@ func crawl(c *cli.Context) error {
 		return cli.NewExitError(err.Error(), 1)
 	}
 
-	err = commands.Crawl(ctx, cfg)
+	var seed io.Reader
+
+	if name := c.String("seed"); name != "" {
+		r, err := openInput(name)
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		defer r.Close()
+
+		seed = r
+	}
+
+	err = commands.Crawl(ctx, cfg, c.GlobalString("config"), seed)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func crawlOne(c *cli.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Allow SIGTERM / Control-C quit through context
+	onSigTerm(cancel)
+
+	if c.NArg() != 1 {
+		return cli.NewExitError("Please supply one CID as argument.", 1)
+	}
+
+	cfg, err := getConfig(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	err = commands.CrawlOne(ctx, cfg, c.Args().Get(0), c.Bool("dry-run"), os.Stdout)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func serve(c *cli.Context) error {
+	fmt.Println("Starting API server")
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Allow SIGTERM / Control-C quit through context
+	onSigTerm(cancel)
+
+	cfg, err := getConfig(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	err = commands.Serve(ctx, cfg)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func recrawl(c *cli.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Allow SIGTERM / Control-C quit through context
+	onSigTerm(cancel)
+
+	cfg, err := getConfig(c)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	err = commands.Recrawl(ctx, cfg, c.Bool("once"))
 	if err != nil {
 		return cli.NewExitError(err.Error(), 1)
 	}
@@ -10,6 +10,7 @@ type AnnotatedResource struct {
 	Source    SourceType `json:",omitempty"`
 	Reference `json:",omitempty"`
 	Stat      `json:",omitempty"`
+	Provider  *ProviderRecord `json:",omitempty"`
 }
 
 // String returns the first reference or the URI.
@@ -0,0 +1,4 @@
+package types
+
+// DagJSONCodec is the multicodec for DAG-JSON, which is not defined in go-cid v0.0.7.
+const DagJSONCodec = 0x0129
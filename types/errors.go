@@ -33,4 +33,7 @@ var (
 	// ErrRequest is returned on errors in upstream requests.
 	ErrRequest = errors.New("request error")
 
+	// ErrCircuitOpen is returned when requests to a failing dependency are refused for a while.
+	ErrCircuitOpen = errors.New("circuit open")
+
 )
@@ -1,20 +1,92 @@
 package types
 
+import (
+	"fmt"
+	"sync"
+)
+
 // Protocol is an enum specifying the protocol.
 type Protocol uint8
 
 const (
 	// InvalidProtocol (default) value signifies an invalid protocol.
 	InvalidProtocol Protocol = iota
-	// IPFSProtocol (currently only supported protocol)
+	// IPFSProtocol represents the Interplanetary Filesystem.
 	IPFSProtocol
+	// IPNSProtocol represents mutable names in the Interplanetary Name System, including DNSLink domains.
+	IPNSProtocol
 )
 
+var (
+	protocolsMutex sync.RWMutex
+	protocols      = map[Protocol]string{
+		IPFSProtocol: "ipfs",
+		IPNSProtocol: "ipns",
+	}
+)
+
+// RegisterProtocol registers a Protocol under a URI scheme, allowing it to be used in Resources.
+// Registering an already registered Protocol or scheme is a programming error and panics.
+func RegisterProtocol(p Protocol, scheme string) {
+	protocolsMutex.Lock()
+	defer protocolsMutex.Unlock()
+
+	if p == InvalidProtocol {
+		panic("cannot register InvalidProtocol")
+	}
+
+	if scheme == "" {
+		panic("cannot register protocol with empty scheme")
+	}
+
+	if s, ok := protocols[p]; ok {
+		panic(fmt.Sprintf("protocol %d already registered as %s", p, s))
+	}
+
+	for _, s := range protocols {
+		if s == scheme {
+			panic(fmt.Sprintf("scheme %s already registered", scheme))
+		}
+	}
+
+	protocols[p] = scheme
+}
+
+// ProtocolFromScheme returns the Protocol registered for a URI scheme, or InvalidProtocol when not registered.
+func ProtocolFromScheme(scheme string) Protocol {
+	protocolsMutex.RLock()
+	defer protocolsMutex.RUnlock()
+
+	for p, s := range protocols {
+		if s == scheme {
+			return p
+		}
+	}
+
+	return InvalidProtocol
+}
+
+// IsRegistered returns true when the Protocol has been registered.
+func (p Protocol) IsRegistered() bool {
+	protocolsMutex.RLock()
+	defer protocolsMutex.RUnlock()
+
+	_, ok := protocols[p]
+	return ok
+}
+
+// String returns the URI scheme for registered protocols.
 func (p Protocol) String() string {
-	switch p {
-	case IPFSProtocol:
-		return "ipfs"
-	default:
-		panic("Invalid value for Protocol.")
+	protocolsMutex.RLock()
+	defer protocolsMutex.RUnlock()
+
+	if s, ok := protocols[p]; ok {
+		return s
 	}
+
+	if p == InvalidProtocol {
+		return "invalid"
+	}
+
+	return fmt.Sprintf("unregistered(%d)", uint8(p))
 }
@@ -33,3 +33,9 @@ func MockProvider() Provider {
 		Provider: "QmeTtFXm42Jb2todcKR538j6qHYxXt6suUzpF3rtT9FPSd",
 	}
 }
+
+// ProviderRecord records the peer a Resource was provided by, and when.
+type ProviderRecord struct {
+	PeerID string
+	Date   time.Time
+}
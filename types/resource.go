@@ -1,9 +1,14 @@
 package types
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
+// ErrInvalidURI is returned when a URI cannot be parsed into a Resource.
+var ErrInvalidURI = errors.New("invalid URI")
+
 // Resource represents a resource on the dweb.
 type Resource struct {
 	Protocol        // Protocol, e.g. IPFSProtocol
@@ -24,3 +29,25 @@ func (r *Resource) String() string {
 func (r *Resource) IsValid() bool {
 	return r.Protocol != InvalidProtocol && r.ID != ""
 }
+
+// ParseURI returns the Resource for a URI as returned by URI(), for a registered Protocol.
+func ParseURI(uri string) (*Resource, error) {
+	scheme, id, found := strings.Cut(uri, "://")
+	if !found {
+		return nil, fmt.Errorf("%w: missing scheme in '%s'", ErrInvalidURI, uri)
+	}
+
+	p := ProtocolFromScheme(scheme)
+	if p == InvalidProtocol {
+		return nil, fmt.Errorf("%w: unregistered scheme '%s'", ErrInvalidURI, scheme)
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("%w: empty identifier in '%s'", ErrInvalidURI, uri)
+	}
+
+	return &Resource{
+		Protocol: p,
+		ID:       id,
+	}, nil
+}
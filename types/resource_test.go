@@ -0,0 +1,38 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURIRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &Resource{
+		Protocol: IPFSProtocol,
+		ID:       "QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp",
+	}
+
+	parsed, err := ParseURI(r.URI())
+
+	assert.NoError(err)
+	assert.Equal(r, parsed)
+}
+
+func TestParseURIUnregistered(t *testing.T) {
+	_, err := ParseURI("bogus://QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp")
+
+	assert.True(t, errors.Is(err, ErrInvalidURI))
+}
+
+func TestParseURINoScheme(t *testing.T) {
+	_, err := ParseURI("QmSKboVigcD3AY4kLsob117KJcMHvMUu6vNFqk1PQzYUpp")
+
+	assert.True(t, errors.Is(err, ErrInvalidURI))
+}
+
+func TestUnregisteredProtocolString(t *testing.T) {
+	assert.NotPanics(t, func() { _ = Protocol(200).String() })
+}
@@ -14,6 +14,8 @@ const (
 	DirectoryType
 	// PartialType represents *unreferenced* partial items.
 	PartialType
+	// DagType represents structured IPLD data (e.g. DAG-CBOR or DAG-JSON).
+	DagType
 )
 
 func (t ResourceType) String() string {
@@ -28,6 +30,8 @@ func (t ResourceType) String() string {
 		return "directory"
 	case PartialType:
 		return "partial"
+	case DagType:
+		return "dag"
 	default:
 		panic("Invalid value for ResourceType.")
 	}
@@ -14,8 +14,25 @@ const (
 	ManualSource
 	// UserSource represents items sourced from (untrusted) users.
 	UserSource
+	// NameSource represents items sourced from resolving a name.
+	NameSource
+	// DagSource represents items sourced from a link in a DAG.
+	DagSource
+	// RecrawlSource represents previously indexed items, scheduled to verify their availability.
+	RecrawlSource
 )
 
+// SourceTypeFromString returns the SourceType with the given String() representation and whether it exists.
+func SourceTypeFromString(s string) (SourceType, bool) {
+	for t := UnknownSource; t <= RecrawlSource; t++ {
+		if t.String() == s {
+			return t, true
+		}
+	}
+
+	return UnknownSource, false
+}
+
 func (t SourceType) String() string {
 	switch t {
 	case UnknownSource:
@@ -28,6 +45,12 @@ func (t SourceType) String() string {
 		return "manual"
 	case UserSource:
 		return "user"
+	case NameSource:
+		return "name"
+	case DagSource:
+		return "dag"
+	case RecrawlSource:
+		return "recrawl"
 	default:
 		panic("Invalid value for SourceType.")
 	}
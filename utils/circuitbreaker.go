@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"sync"
+	"time"
+
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+type breakerState int
+
+const (
+	breakerClosed   breakerState = iota // Requests are allowed.
+	breakerOpen                         // Requests are refused.
+	breakerHalfOpen                     // A single trial request is allowed.
+)
+
+// CircuitBreaker refuses requests after consecutive failures, until a trial request succeeds after a timeout.
+// Results of requests allowed before the breaker last opened are ignored, so that slow requests do not close it
+// again. It is safe for concurrent use.
+type CircuitBreaker struct {
+	maxFailures uint
+	openTimeout time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures uint
+	openedAt time.Time
+	opened   uint64 // Number of times the breaker opened, identifying the requests allowed since.
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker which opens after maxFailures consecutive failures, for
+// openTimeout.
+func NewCircuitBreaker(maxFailures uint, openTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		maxFailures: maxFailures,
+		openTimeout: openTimeout,
+	}
+}
+
+// Allow returns t.ErrCircuitOpen when a request is refused. Otherwise, the result of the request should be
+// recorded with Success, Failure or Abort, passing the returned ticket.
+func (b *CircuitBreaker) Allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return 0, t.ErrCircuitOpen
+		}
+
+		b.state = breakerHalfOpen
+
+		return b.opened, nil
+	case breakerHalfOpen:
+		// Only one trial request at a time.
+		return 0, t.ErrCircuitOpen
+	default:
+		return b.opened, nil
+	}
+}
+
+// stale returns true for tickets of requests allowed before the breaker last opened.
+func (b *CircuitBreaker) stale(ticket uint64) bool {
+	return ticket != b.opened
+}
+
+// Success records a successful request, returning true when it closes the breaker.
+func (b *CircuitBreaker) Success(ticket uint64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stale(ticket) {
+		return false
+	}
+
+	closed := b.state != breakerClosed
+
+	b.state = breakerClosed
+	b.failures = 0
+
+	return closed
+}
+
+// Failure records a failed request, returning true when it opens the breaker.
+func (b *CircuitBreaker) Failure(ticket uint64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stale(ticket) {
+		return false
+	}
+
+	b.failures++
+
+	if b.state == breakerHalfOpen || (b.state == breakerClosed && b.failures >= b.maxFailures) {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+		b.opened++
+
+		return true
+	}
+
+	return false
+}
+
+// Abort records a request which neither succeeded nor failed, e.g. because it was canceled.
+func (b *CircuitBreaker) Abort(ticket uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen && !b.stale(ticket) {
+		// Allow another trial request right away.
+		b.state = breakerOpen
+	}
+}
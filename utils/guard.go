@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/exp/slog"
+
+	"github.com/ipfs-search/ipfs-search/instr"
+)
+
+// GuardConfig configures rate limiting and circuit breaking for requests to a dependency.
+type GuardConfig struct {
+	Rate          float64       // Maximum requests per second, 0 for unlimited.
+	Burst         int           // Requests allowed at once, regardless of rate.
+	MaxFailures   uint          // Consecutive failures opening the circuit breaker, 0 to disable it.
+	OpenTimeout   time.Duration // Time requests are refused before a trial request is made.
+	FailOnTimeout bool          // Count timeouts as failures; not for dependencies which time out on unavailable content.
+}
+
+// DefaultGuardConfig returns the default configuration for a Guard.
+func DefaultGuardConfig() *GuardConfig {
+	return &GuardConfig{
+		Rate:        0,
+		Burst:       1,
+		MaxFailures: 10,
+		OpenTimeout: 30 * time.Second,
+	}
+}
+
+// Guard limits the rate of requests to a dependency and refuses requests while it is failing.
+type Guard struct {
+	name    string
+	config  *GuardConfig
+	limiter *TokenBucket    // Nil when unlimited.
+	breaker *CircuitBreaker // Nil when disabled.
+
+	*instr.Instrumentation
+	log *slog.Logger
+}
+
+// NewGuard returns a new Guard for the named dependency.
+func NewGuard(name string, config *GuardConfig, i *instr.Instrumentation) *Guard {
+	g := &Guard{
+		name:            name,
+		config:          config,
+		Instrumentation: i,
+		log:             i.Component("guard").With("dependency", name),
+	}
+
+	if config.Rate > 0 {
+		g.limiter = NewTokenBucket(config.Rate, config.Burst)
+	}
+
+	if config.MaxFailures > 0 {
+		g.breaker = NewCircuitBreaker(config.MaxFailures, config.OpenTimeout)
+	}
+
+	return g
+}
+
+// String returns the name of the dependency.
+func (g *Guard) String() string {
+	return g.name
+}
+
+// acquire waits for the rate limiter, returning types.ErrCircuitOpen right away when the circuit breaker is open.
+// The returned ticket identifies the request to the circuit breaker.
+func (g *Guard) acquire(ctx context.Context) (uint64, error) {
+	var ticket uint64
+
+	if g.breaker != nil {
+		var err error
+		if ticket, err = g.breaker.Allow(); err != nil {
+			g.Metrics.BreakerRejections.Add(ctx, 1, attribute.String("dependency", g.name))
+			return 0, fmt.Errorf("%w: %s", err, g.name)
+		}
+	}
+
+	if g.limiter != nil {
+		if err := g.limiter.Wait(ctx); err != nil {
+			if g.breaker != nil {
+				g.breaker.Abort(ticket)
+			}
+
+			return 0, err
+		}
+	}
+
+	return ticket, nil
+}
+
+// isTimeout returns true when a request timed out.
+func isTimeout(ctx context.Context, resp *http.Response, err error) bool {
+	if resp != nil {
+		return resp.StatusCode == http.StatusGatewayTimeout
+	}
+
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// isFailure returns true when a dependency failed to handle a request.
+func isFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// record records the result of a request with the circuit breaker.
+func (g *Guard) record(ctx context.Context, ticket uint64, resp *http.Response, err error) {
+	if g.breaker == nil {
+		return
+	}
+
+	switch {
+	case isTimeout(ctx, resp, err):
+		if !g.config.FailOnTimeout {
+			g.breaker.Abort(ticket)
+			return
+		}
+	case errors.Is(ctx.Err(), context.Canceled):
+		g.breaker.Abort(ticket)
+		return
+	case !isFailure(resp, err):
+		if g.breaker.Success(ticket) {
+			g.log.InfoCtx(ctx, "Circuit breaker closed")
+		}
+
+		return
+	}
+
+	if g.breaker.Failure(ticket) {
+		g.log.WarnCtx(ctx, "Circuit breaker opened, refusing requests", "timeout", g.config.OpenTimeout, "err", err)
+	}
+}
+
+// guardTransport guards requests to the hosts of dependencies.
+type guardTransport struct {
+	transport http.RoundTripper
+	guards    map[string]*Guard
+}
+
+// RoundTrip executes a request, guarded by the Guard for its host, if any.
+func (gt *guardTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	g, ok := gt.guards[req.URL.Host]
+	if !ok {
+		return gt.transport.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+
+	ticket, err := g.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := gt.transport.RoundTrip(req)
+	g.record(ctx, ticket, resp, err)
+
+	return resp, err
+}
+
+// GuardTransport returns a transport guarding requests by host, e.g. "localhost:8081", with guards. Requests to
+// other hosts are not guarded.
+func GuardTransport(transport http.RoundTripper, guards map[string]*Guard) http.RoundTripper {
+	return &guardTransport{transport, guards}
+}
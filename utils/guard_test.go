@@ -0,0 +1,224 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ipfs-search/ipfs-search/instr"
+	t "github.com/ipfs-search/ipfs-search/types"
+)
+
+type GuardTestSuite struct {
+	suite.Suite
+
+	status   atomic.Int64  // Status returned by the server.
+	requests atomic.Int64  // Requests handled by the server.
+	slow     chan struct{} // Requests to /slow succeed once closed.
+	srv      *httptest.Server
+
+	cfg    *GuardConfig
+	client *http.Client
+}
+
+func (s *GuardTestSuite) SetupTest() {
+	s.status.Store(http.StatusOK)
+	s.requests.Store(0)
+	s.slow = make(chan struct{})
+
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+
+		if r.URL.Path == "/slow" {
+			<-s.slow
+			w.WriteHeader(http.StatusOK)
+
+			return
+		}
+
+		w.WriteHeader(int(s.status.Load()))
+	}))
+
+	s.cfg = DefaultGuardConfig()
+	s.cfg.MaxFailures = 2
+	s.cfg.OpenTimeout = 50 * time.Millisecond
+}
+
+func (s *GuardTestSuite) TearDownTest() {
+	s.srv.Close()
+}
+
+// guard sets up the client with a guard for the test server.
+func (s *GuardTestSuite) guard() {
+	u, err := url.Parse(s.srv.URL)
+	s.Require().NoError(err)
+
+	guards := map[string]*Guard{
+		u.Host: NewGuard("test", s.cfg, instr.New()),
+	}
+
+	s.client = &http.Client{Transport: GuardTransport(http.DefaultTransport, guards)}
+}
+
+func (s *GuardTestSuite) get(ctx context.Context) error {
+	return s.getPath(ctx, "/")
+}
+
+func (s *GuardTestSuite) getPath(ctx context.Context, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.srv.URL+path, nil)
+	s.Require().NoError(err)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (s *GuardTestSuite) TestBreakerOpens() {
+	s.guard()
+	s.status.Store(http.StatusServiceUnavailable)
+
+	ctx := context.Background()
+
+	s.NoError(s.get(ctx))
+	s.NoError(s.get(ctx))
+
+	// Open after MaxFailures; fail fast without requests.
+	s.ErrorIs(s.get(ctx), t.ErrCircuitOpen)
+	s.Equal(int64(2), s.requests.Load())
+
+	// After OpenTimeout, a trial request closes the breaker again.
+	s.status.Store(http.StatusOK)
+	time.Sleep(s.cfg.OpenTimeout)
+
+	s.NoError(s.get(ctx))
+	s.NoError(s.get(ctx))
+	s.Equal(int64(4), s.requests.Load())
+}
+
+func (s *GuardTestSuite) TestTrialFails() {
+	s.cfg.MaxFailures = 1
+	s.guard()
+	s.status.Store(http.StatusBadGateway)
+
+	ctx := context.Background()
+
+	s.NoError(s.get(ctx))
+	s.ErrorIs(s.get(ctx), t.ErrCircuitOpen)
+
+	time.Sleep(s.cfg.OpenTimeout)
+
+	// Failing trial request opens the breaker right away.
+	s.NoError(s.get(ctx))
+	s.ErrorIs(s.get(ctx), t.ErrCircuitOpen)
+	s.Equal(int64(2), s.requests.Load())
+}
+
+func (s *GuardTestSuite) TestStaleSuccess() {
+	s.cfg.MaxFailures = 1
+	s.guard()
+	s.status.Store(http.StatusServiceUnavailable)
+
+	ctx := context.Background()
+
+	// Request allowed before the breaker opens, succeeding after.
+	slow := make(chan error)
+	go func() {
+		slow <- s.getPath(ctx, "/slow")
+	}()
+
+	s.Eventually(func() bool { return s.requests.Load() == 1 }, time.Second, time.Millisecond)
+
+	s.NoError(s.get(ctx))
+	close(s.slow)
+	s.NoError(<-slow)
+
+	// The slow success does not close the breaker.
+	s.ErrorIs(s.get(ctx), t.ErrCircuitOpen)
+	s.Equal(int64(2), s.requests.Load())
+}
+
+func (s *GuardTestSuite) TestSuccessResets() {
+	s.guard()
+
+	ctx := context.Background()
+
+	for _, status := range []int64{http.StatusServiceUnavailable, http.StatusOK, http.StatusServiceUnavailable} {
+		s.status.Store(status)
+		s.NoError(s.get(ctx))
+	}
+
+	// Not consecutive; a client error is not a failure of the dependency.
+	s.status.Store(http.StatusNotFound)
+	s.NoError(s.get(ctx))
+	s.Equal(int64(4), s.requests.Load())
+}
+
+func (s *GuardTestSuite) TestTimeouts() {
+	s.cfg.MaxFailures = 1
+	s.guard()
+	s.status.Store(http.StatusGatewayTimeout)
+
+	ctx := context.Background()
+
+	// Not counted by default.
+	s.NoError(s.get(ctx))
+	s.NoError(s.get(ctx))
+
+	s.cfg.FailOnTimeout = true
+	s.guard()
+
+	s.NoError(s.get(ctx))
+	s.ErrorIs(s.get(ctx), t.ErrCircuitOpen)
+}
+
+func (s *GuardTestSuite) TestRateLimit() {
+	s.cfg.Rate = 20
+	s.cfg.Burst = 2
+	s.guard()
+
+	ctx := context.Background()
+	start := time.Now()
+
+	for i := 0; i < 4; i++ {
+		s.NoError(s.get(ctx))
+	}
+
+	// Burst of 2, then 2 more at 20 per second.
+	s.GreaterOrEqual(time.Since(start), 90*time.Millisecond)
+}
+
+func (s *GuardTestSuite) TestRateLimitCanceled() {
+	s.cfg.Rate = 0.001
+	s.guard()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	s.NoError(s.get(ctx))
+	s.ErrorIs(s.get(ctx), context.DeadlineExceeded)
+	s.Equal(int64(1), s.requests.Load())
+}
+
+func (s *GuardTestSuite) TestUnguardedHost() {
+	s.client = &http.Client{Transport: GuardTransport(http.DefaultTransport, map[string]*Guard{})}
+	s.status.Store(http.StatusServiceUnavailable)
+
+	for i := 0; i < 5; i++ {
+		s.NoError(s.get(context.Background()))
+	}
+
+	s.Equal(int64(5), s.requests.Load())
+}
+
+func TestGuardTestSuite(t *testing.T) {
+	suite.Run(t, new(GuardTestSuite))
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -37,7 +38,11 @@ func (g *httpBodyGetterImpl) GetBody(ctx context.Context, url string, expect_sta
 
 	resp, err := g.get(ctx, url)
 	if err != nil {
-		err := fmt.Errorf("%w: %v", t.ErrRequest, err)
+		// Retain refusals of circuit breakers, so callers can fail fast.
+		if !errors.Is(err, t.ErrCircuitOpen) {
+			err = fmt.Errorf("%w: %v", t.ErrRequest, err)
+		}
+
 		span.RecordError(err)
 		return nil, err
 	}
@@ -4,10 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"net"
 	"syscall"
 	"time"
+
+	"golang.org/x/exp/slog"
 )
 
 const (
@@ -23,6 +24,15 @@ var ErrRetriesExhausted = errors.New("Dial retries exhausted")
 type RetryingDialer struct {
 	net.Dialer
 	context.Context
+	Logger *slog.Logger // Logs refused connections; the default logger is used when nil.
+}
+
+func (d *RetryingDialer) logger() *slog.Logger {
+	if d.Logger == nil {
+		return slog.Default()
+	}
+
+	return d.Logger
 }
 
 func (d *RetryingDialer) retrier(ctx context.Context, dial func() (net.Conn, error)) (net.Conn, error) {
@@ -44,7 +54,7 @@ func (d *RetryingDialer) retrier(ctx context.Context, dial func() (net.Conn, err
 			return c, err
 		}
 
-		log.Printf("Connection error (try %d of %d): %v, sleeping %s", tryCnt, maxTries, err, retryWait)
+		d.logger().WarnCtx(ctx, "Connection refused, retrying", "try", tryCnt+1, "max_tries", maxTries, "wait", retryWait, "err", err)
 		select {
 		case <-time.After(retryWait):
 			// Wait or cancel when context is canceled.